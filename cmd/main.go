@@ -2,13 +2,28 @@ package main
 
 import (
 	"Report-Storage/internal/config"
+	"Report-Storage/internal/jobs"
+	"Report-Storage/internal/localfs"
 	"Report-Storage/internal/logger"
+	"Report-Storage/internal/notifications"
+	"Report-Storage/internal/pdf"
+	"Report-Storage/internal/reports"
 	"Report-Storage/internal/s3cloud"
 	"Report-Storage/internal/server"
 	"Report-Storage/internal/stopsignal"
 	"Report-Storage/internal/storage/mongodb"
+	"Report-Storage/internal/tiles"
+	"context"
+	"os"
+	"time"
 )
 
+// fileStorage - интерфейс хранилища медиа файлов, общий для всех бэкендов.
+type fileStorage interface {
+	reports.FileSaver
+	jobs.FileLister
+}
+
 func main() {
 
 	// Инициализируем конфиг и логгер.
@@ -20,21 +35,97 @@ func main() {
 	st := mongodb.New(cfg)
 	log.Debug("Storage initialized")
 
-	// Инициализируем клиент S3 хранилища.
-	s3 := s3cloud.New(cfg.Endpoint, cfg.Bucket, cfg.AccessKey, cfg.SecretKey, cfg.Domain)
-	log.Debug("S3 client initialized")
+	// Инициализируем клиент SMTP сервера для уведомлений фоновых задач.
+	mail := notifications.New(cfg.Sender, cfg.SMTPLogin, cfg.SMTPPasswd, cfg.SMTPHost, cfg.SMTPPort)
 
 	// Инициализируем и запускаем HTTP сервер.
 	srv := server.New(cfg)
 	srv.Middleware()
-	srv.API(log, st, s3)
+
+	// Инициализируем хранилище медиа файлов.
+	var files fileStorage
+	switch cfg.MediaBackend {
+	case "local":
+		fs, err := localfs.New(cfg.LocalStorage.Dir, cfg.LocalStorage.URL)
+		if err != nil {
+			log.Error("failed to init local file storage", logger.Err(err))
+			os.Exit(1)
+		}
+		srv.Media(fs.Handler("/media/"))
+		files = fs
+		log.Debug("Local file storage initialized")
+	case "s3":
+		s3, err := s3cloud.New(cfg.Endpoint, cfg.Bucket, cfg.AccessKey, cfg.SecretKey, cfg.Domain, cfg.Insecure, cfg.PathStyle)
+		if err != nil {
+			log.Error("failed to init S3 client", logger.Err(err))
+			os.Exit(1)
+		}
+		// Устанавливаем автоматическое удаление неиспользованных файлов прямой
+		// загрузки. Ошибка не критична, но такие файлы придется удалять вручную.
+		if err := s3.ExpireStaging(context.Background(), cfg.StagingTTL); err != nil {
+			log.Warn("failed to set staging files expiration", logger.Err(err))
+		}
+		files = s3
+		log.Debug("S3 client initialized")
+	default:
+		log.Error("unknown media backend", "backend", cfg.MediaBackend)
+		os.Exit(1)
+	}
+
+	// Регистрируем и запускаем фоновые задачи. Задачи, изменяющие
+	// заявки, сбрасывают кэш векторных тайлов.
+	tc := srv.TileCache()
+	ctx, cancel := context.WithCancel(context.Background())
+	sch := jobs.NewScheduler(log)
+	gc := jobs.NewMediaGC(log, st, files, cfg.GCGrace)
+	sch.Add(jobs.MediaGCJob, cfg.GCInterval, func(ctx context.Context) (any, error) {
+		return gc.Run(ctx, cfg.GCDryRun)
+	})
+	tr := jobs.NewTrashRetention(log, st, files, cfg.TrashRetention)
+	sch.Add("trash_retention", cfg.TrashInterval, invalidate(tc, func(ctx context.Context) (any, error) {
+		return tr.Run(ctx)
+	}))
+	if cfg.ExpireDays > 0 {
+		after := time.Duration(cfg.ExpireDays) * time.Hour * 24
+		sch.Add("expire_unverified", cfg.LifecycleInterval, invalidate(tc, jobs.ExpireUnverified(log, st, mail, after)))
+	}
+	if cfg.ArchiveDays > 0 {
+		after := time.Duration(cfg.ArchiveDays) * time.Hour * 24
+		sch.Add("archive_closed", cfg.LifecycleInterval, invalidate(tc, jobs.ArchiveClosed(st, after)))
+	}
+	sch.Add("sla_digest", cfg.DigestInterval, jobs.SLADigest(st, mail, cfg.Rules(), cfg.Supervisors))
+	sch.Start(ctx)
+
+	// Загружаем шрифт для писем в формате PDF. Без шрифта сервер работает,
+	// но формирование писем по заявкам недоступно.
+	font, err := pdf.LoadFont(cfg.LetterFont)
+	if err != nil {
+		log.Warn("failed to load letter font, letters are disabled", logger.Err(err))
+	}
+
+	srv.API(log, st, files, font)
+	srv.Admin(log, gc, sch)
 	srv.Start()
 	log.Info("Server started")
 
 	// Блокируем выполнение основной горутины до сигнала прерывания.
 	stopsignal.Stop()
 
-	// После сигнала прерывания останавливаем сервер.
+	// После сигнала прерывания останавливаем сервер и фоновые задачи.
 	srv.Shutdown()
+	cancel()
+	sch.Wait()
 	log.Info("Server stopped")
 }
+
+// invalidate возвращает задачу, которая после успешного выполнения fn
+// сбрасывает кэш векторных тайлов.
+func invalidate(c *tiles.Cache, fn jobs.JobFunc) jobs.JobFunc {
+	return func(ctx context.Context) (any, error) {
+		res, err := fn(ctx)
+		if err == nil {
+			c.Invalidate()
+		}
+		return res, err
+	}
+}
@@ -3,6 +3,7 @@
 package config
 
 import (
+	"Report-Storage/internal/storage"
 	"log"
 	"os"
 	"time"
@@ -17,16 +18,81 @@ type Config struct {
 	StorageUser   string `yaml:"storage_user" env-default:"admin"`
 	StoragePasswd string `yaml:"storage_passwd" env:"MONGO_DB_PASSWD" env-required:"true"`
 	JwtSecret     string `yaml:"jwt_secret" env:"JWT_SECRET" env-required:"true"`
+	MediaBackend  string `yaml:"media_backend" env-default:"s3"`
 	S3Storage     `yaml:"s3storage"`
+	LocalStorage  `yaml:"local_storage"`
+	MediaGC       `yaml:"media_gc"`
+	Trash         `yaml:"trash"`
+	Lifecycle     `yaml:"lifecycle"`
+	SLA           `yaml:"sla"`
 	SMTP          `yaml:"smtp"`
+	Tiles         `yaml:"tiles"`
+	PublicStat    `yaml:"public_statistic"`
+	Letters       `yaml:"letters"`
 	HTTPServer    `yaml:"http_server"`
 }
 type S3Storage struct {
 	Endpoint  string `yaml:"endpoint" env-default:"s3.ru-1.storage.selcloud.ru"`
 	Bucket    string `yaml:"bucket" env-default:"ostorozhnoluk"`
-	AccessKey string `yaml:"access_key" env:"S3_ACCESS_KEY" env-required:"true"`
-	SecretKey string `yaml:"secret_key" env:"S3_SECRET_KEY" env-required:"true"`
+	AccessKey string `yaml:"access_key" env:"S3_ACCESS_KEY"`
+	SecretKey string `yaml:"secret_key" env:"S3_SECRET_KEY"`
 	Domain    string `yaml:"domain" env-default:"https://49078864-cdaa-43c7-bff7-9dc64dd6bf93.selstorage.ru"`
+	Insecure  bool   `yaml:"insecure" env-default:"false"`
+	PathStyle bool   `yaml:"path_style" env-default:"false"`
+	// UploadExpiry - время действия подписанных ссылок для прямой загрузки.
+	UploadExpiry time.Duration `yaml:"upload_expiry" env-default:"15m"`
+	// StagingTTL - количество дней, после которого неиспользованные файлы
+	// прямой загрузки удаляются из хранилища.
+	StagingTTL int `yaml:"staging_ttl" env-default:"1"`
+}
+type LocalStorage struct {
+	Dir string `yaml:"dir" env-default:"./media"`
+	URL string `yaml:"url" env-default:"http://localhost/media"`
+}
+type MediaGC struct {
+	// GCInterval - интервал удаления неиспользуемых файлов. Нулевое или
+	// отрицательное значение отключает задачу.
+	GCInterval time.Duration `yaml:"interval" env-default:"24h"`
+	// GCGrace - минимальный возраст файла, который может быть удален.
+	GCGrace time.Duration `yaml:"grace" env-default:"24h"`
+	// GCDryRun - режим, в котором найденные файлы только записываются в лог.
+	GCDryRun bool `yaml:"dry_run" env-default:"false"`
+}
+type Trash struct {
+	// TrashRetention - срок хранения заявок в корзине.
+	TrashRetention time.Duration `yaml:"retention" env-default:"720h"`
+	// TrashInterval - интервал очистки корзины. Отрицательное значение
+	// отключает задачу.
+	TrashInterval time.Duration `yaml:"interval" env-default:"1h"`
+}
+type Lifecycle struct {
+	// ExpireDays - количество дней, после которого непроверенная заявка
+	// отклоняется. Нулевое или отрицательное значение отключает задачу.
+	ExpireDays int `yaml:"expire_unverified_days" env-default:"30"`
+	// ArchiveDays - количество дней после закрытия, через которое заявка
+	// переносится в архив. Нулевое или отрицательное значение отключает
+	// задачу.
+	ArchiveDays int `yaml:"archive_closed_days" env-default:"90"`
+	// LifecycleInterval - интервал запуска задач жизненного цикла заявок.
+	LifecycleInterval time.Duration `yaml:"interval" env-default:"1h"`
+}
+type SLA struct {
+	// SLAOpened и SLAInProgress - допустимое время нахождения заявки
+	// в статусах Opened и InProgress по умолчанию.
+	SLAOpened     time.Duration `yaml:"opened" env-default:"72h"`
+	SLAInProgress time.Duration `yaml:"in_progress" env-default:"336h"`
+	// SLACategories - сроки для отдельных категорий заявок. Нулевой срок
+	// означает срок по умолчанию.
+	SLACategories map[string]SLALimits `yaml:"categories"`
+	// Supervisors - адреса почты для сводки по просроченным заявкам.
+	Supervisors []string `yaml:"supervisors"`
+	// DigestInterval - интервал отправки сводки. Отрицательное значение
+	// отключает отправку.
+	DigestInterval time.Duration `yaml:"digest_interval" env-default:"24h"`
+}
+type SLALimits struct {
+	Opened     time.Duration `yaml:"opened"`
+	InProgress time.Duration `yaml:"in_progress"`
 }
 type SMTP struct {
 	Sender     string `yaml:"sender" env-default:"mail@luk.sf-hackathon.xyz"`
@@ -35,6 +101,24 @@ type SMTP struct {
 	SMTPHost   string `yaml:"smtp_host" env-default:"smtp.mail.selcloud.ru"`
 	SMTPPort   string `yaml:"smtp_port" env-default:"1126"`
 }
+type Tiles struct {
+	// TileCacheSize - максимальное количество векторных тайлов в кэше.
+	// Нулевое значение отключает кэширование.
+	TileCacheSize int `yaml:"cache_size" env-default:"10000"`
+	// TileCacheTTL - время жизни тайла в кэше. Кэш также сбрасывается
+	// при изменении заявок.
+	TileCacheTTL time.Duration `yaml:"cache_ttl" env-default:"10m"`
+}
+type PublicStat struct {
+	// PublicStatTTL - время кэширования публичной статистики. Нулевое
+	// значение отключает кэширование.
+	PublicStatTTL time.Duration `yaml:"cache_ttl" env-default:"5m"`
+}
+type Letters struct {
+	// LetterFont - путь к файлу шрифта TrueType с кириллицей для писем
+	// в формате PDF. Если шрифт не загружен, формирование писем недоступно.
+	LetterFont string `yaml:"font" env:"LETTER_FONT" env-default:"/usr/share/fonts/dejavu/DejaVuSans.ttf"`
+}
 type HTTPServer struct {
 	Address      string        `yaml:"address" env-default:"0.0.0.0:80"`
 	ReadTimeout  time.Duration `yaml:"read_timeout" env-default:"4s"`
@@ -42,6 +126,24 @@ type HTTPServer struct {
 	IdleTimeout  time.Duration `yaml:"idle_timeout" env-default:"60s"`
 }
 
+// Rules возвращает правила сроков обработки заявок по статусам
+// и категориям.
+func (c SLA) Rules() []storage.SLARule {
+	rules := []storage.SLARule{
+		{Status: storage.Opened, Limit: c.SLAOpened},
+		{Status: storage.InProgress, Limit: c.SLAInProgress},
+	}
+	for category, l := range c.SLACategories {
+		if l.Opened > 0 {
+			rules = append(rules, storage.SLARule{Status: storage.Opened, Category: category, Limit: l.Opened})
+		}
+		if l.InProgress > 0 {
+			rules = append(rules, storage.SLARule{Status: storage.InProgress, Category: category, Limit: l.InProgress})
+		}
+	}
+	return rules
+}
+
 // MustLoad - инициализирует данные из конфиг файла. Путь к файлу берет из
 // переменной окружения RS_CONFIG_PATH. Если не удается, то завершает
 // приложение с ошибкой.
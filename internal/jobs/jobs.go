@@ -0,0 +1,246 @@
+// Пакет jobs содержит фоновые задачи обслуживания хранилищ и планировщик
+// для их периодического запуска.
+package jobs
+
+import (
+	"Report-Storage/internal/logger"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// historySize - количество последних запусков задачи, хранящихся в истории.
+const historySize = 20
+
+var (
+	// ErrRunning - ошибка повторного запуска задачи, которая еще выполняется.
+	ErrRunning = errors.New("job is already running")
+	// ErrUnknownJob - ошибка запуска незарегистрированной задачи.
+	ErrUnknownJob = errors.New("unknown job")
+)
+
+// JobFunc - функция задачи. Возвращает результат выполнения, который
+// сохраняется в истории запусков.
+type JobFunc func(ctx context.Context) (any, error)
+
+// Run - структура одного запуска задачи.
+type Run struct {
+	// ID - порядковый номер запуска, уникальный в пределах планировщика.
+	ID       int64     `json:"id"`
+	Job      string    `json:"job"`
+	Manual   bool      `json:"manual"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Result   any       `json:"result,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// JobInfo - структура состояния задачи и истории ее запусков.
+type JobInfo struct {
+	Name     string `json:"name"`
+	Interval string `json:"interval"`
+	Running  bool   `json:"running"`
+	// Current содержит выполняющийся запуск задачи.
+	Current *Run  `json:"current,omitempty"`
+	Runs    []Run `json:"runs"`
+}
+
+// job - зарегистрированная в планировщике задача.
+type job struct {
+	name     string
+	interval time.Duration
+	fn       JobFunc
+	lock     sync.Mutex
+	mu       sync.Mutex
+	running  bool
+	current  *Run
+	runs     []Run
+}
+
+// Scheduler - планировщик периодических задач.
+type Scheduler struct {
+	log  *slog.Logger
+	jobs map[string]*job
+	// order хранит имена задач в порядке регистрации.
+	order []string
+	wg    sync.WaitGroup
+	// seq - номер последнего запуска.
+	seq atomic.Int64
+}
+
+// NewScheduler - конструктор планировщика.
+func NewScheduler(l *slog.Logger) *Scheduler {
+	s := &Scheduler{
+		log:  l.With(slog.String("component", "scheduler")),
+		jobs: make(map[string]*job),
+	}
+	return s
+}
+
+// Add регистрирует задачу name, которая запускается с интервалом interval.
+// Если interval не больше 0, то задачу можно запустить только вручную.
+// Задачи регистрируются до вызова Start.
+func (s *Scheduler) Add(name string, interval time.Duration, fn JobFunc) {
+	if _, ok := s.jobs[name]; !ok {
+		s.order = append(s.order, name)
+	}
+	s.jobs[name] = &job{name: name, interval: interval, fn: fn}
+}
+
+// Start запускает периодическое выполнение задач до отмены контекста.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, name := range s.order {
+		j := s.jobs[name]
+		if j.interval <= 0 {
+			s.log.Info("job disabled", slog.String("job", name))
+			continue
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			ticker := time.NewTicker(j.interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					_, err := s.run(ctx, j, false)
+					if err != nil && !errors.Is(err, ErrRunning) {
+						s.log.Error("job failed", slog.String("job", j.name), logger.Err(err))
+					}
+				}
+			}
+		}()
+	}
+}
+
+// Wait ожидает завершения всех задач после отмены контекста Start.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// Run вручную запускает задачу name и возвращает результат запуска.
+// Если задача не зарегистрирована, то вернет ошибку ErrUnknownJob, если
+// задача уже выполняется, то ErrRunning.
+func (s *Scheduler) Run(ctx context.Context, name string) (Run, error) {
+	j, ok := s.jobs[name]
+	if !ok {
+		return Run{}, ErrUnknownJob
+	}
+	return s.run(ctx, j, true)
+}
+
+// Launch вручную запускает задачу name в отдельной горутине и сразу
+// возвращает начатый запуск, результат которого появится в истории задачи.
+// Запуск не прерывается отменой ctx, но Wait ожидает его завершения. Если
+// задача не зарегистрирована, то вернет ошибку ErrUnknownJob, если задача
+// уже выполняется, то ErrRunning.
+func (s *Scheduler) Launch(ctx context.Context, name string) (Run, error) {
+	return s.LaunchFunc(ctx, name, nil)
+}
+
+// LaunchFunc работает как Launch, но вместо функции задачи выполняет fn,
+// если она не равна nil. Используется для запуска задачи с параметрами,
+// отличными от заданных при регистрации.
+func (s *Scheduler) LaunchFunc(ctx context.Context, name string, fn JobFunc) (Run, error) {
+	j, ok := s.jobs[name]
+	if !ok {
+		return Run{}, ErrUnknownJob
+	}
+	if !j.lock.TryLock() {
+		return Run{}, ErrRunning
+	}
+	if fn == nil {
+		fn = j.fn
+	}
+
+	run := s.begin(j, true)
+	ctx = context.WithoutCancel(ctx)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.finish(ctx, j, fn, run)
+	}()
+	return run, nil
+}
+
+// Jobs возвращает состояние всех задач в порядке регистрации.
+func (s *Scheduler) Jobs() []JobInfo {
+	info := make([]JobInfo, 0, len(s.order))
+	for _, name := range s.order {
+		j := s.jobs[name]
+		j.mu.Lock()
+		runs := make([]Run, len(j.runs))
+		copy(runs, j.runs)
+		var current *Run
+		if j.current != nil {
+			run := *j.current
+			current = &run
+		}
+		info = append(info, JobInfo{
+			Name:     j.name,
+			Interval: j.interval.String(),
+			Running:  j.running,
+			Current:  current,
+			Runs:     runs,
+		})
+		j.mu.Unlock()
+	}
+	return info
+}
+
+// run выполняет задачу и сохраняет запуск в истории. Одновременно может
+// выполняться только один запуск задачи.
+func (s *Scheduler) run(ctx context.Context, j *job, manual bool) (Run, error) {
+	if !j.lock.TryLock() {
+		return Run{}, ErrRunning
+	}
+	return s.finish(ctx, j, j.fn, s.begin(j, manual))
+}
+
+// begin отмечает задачу выполняющейся и возвращает новый запуск. Вызывается
+// после захвата j.lock.
+func (s *Scheduler) begin(j *job, manual bool) Run {
+	run := Run{ID: s.seq.Add(1), Job: j.name, Manual: manual, Started: time.Now()}
+	j.mu.Lock()
+	j.running = true
+	j.current = &run
+	j.mu.Unlock()
+	return run
+}
+
+// finish выполняет fn для начатого запуска run, сохраняет его в истории
+// и освобождает j.lock.
+func (s *Scheduler) finish(ctx context.Context, j *job, fn JobFunc, run Run) (Run, error) {
+	defer j.lock.Unlock()
+
+	res, err := fn(ctx)
+	run.Finished = time.Now()
+	run.Result = res
+	if err != nil {
+		run.Error = err.Error()
+	}
+	s.log.Info("job finished",
+		slog.String("job", j.name),
+		slog.Int64("run", run.ID),
+		slog.Duration("duration", run.Finished.Sub(run.Started)),
+		slog.Bool("failed", err != nil),
+	)
+
+	// Последние запуски хранятся в начале истории.
+	j.mu.Lock()
+	j.running = false
+	j.current = nil
+	j.runs = append([]Run{run}, j.runs...)
+	if len(j.runs) > historySize {
+		j.runs = j.runs[:historySize]
+	}
+	j.mu.Unlock()
+
+	return run, err
+}
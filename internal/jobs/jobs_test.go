@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Run(t *testing.T) {
+	s := NewScheduler(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	release := make(chan struct{})
+	s.Add("ok", 0, func(ctx context.Context) (any, error) {
+		return 1, nil
+	})
+	s.Add("fail", 0, func(ctx context.Context) (any, error) {
+		return nil, errors.New("failed")
+	})
+	s.Add("slow", 0, func(ctx context.Context) (any, error) {
+		<-release
+		return nil, nil
+	})
+
+	tests := []struct {
+		name    string
+		job     string
+		wantErr error
+	}{
+		{
+			name: "OK",
+			job:  "ok",
+		},
+		{
+			name:    "Error Job failed",
+			job:     "fail",
+			wantErr: errors.New("failed"),
+		},
+		{
+			name:    "Error Unknown job",
+			job:     "none",
+			wantErr: ErrUnknownJob,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run, err := s.Run(context.Background(), tt.job)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("Scheduler.Run() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (!run.Manual || run.Job != tt.job) {
+				t.Errorf("Scheduler.Run() = %+v", run)
+			}
+		})
+	}
+
+	// Повторный запуск выполняющейся задачи.
+	done := make(chan struct{})
+	go func() {
+		s.Run(context.Background(), "slow")
+		close(done)
+	}()
+	for !s.Jobs()[2].Running {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := s.Run(context.Background(), "slow"); !errors.Is(err, ErrRunning) {
+		t.Errorf("Scheduler.Run() error = %v, want %v", err, ErrRunning)
+	}
+	close(release)
+	<-done
+
+	// История запусков.
+	jobs := s.Jobs()
+	if len(jobs) != 3 || jobs[0].Name != "ok" || len(jobs[0].Runs) != 1 || jobs[1].Runs[0].Error != "failed" {
+		t.Errorf("Scheduler.Jobs() = %+v", jobs)
+	}
+}
+
+func TestScheduler_Launch(t *testing.T) {
+	s := NewScheduler(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	release := make(chan struct{})
+	s.Add("slow", 0, func(ctx context.Context) (any, error) {
+		<-release
+		return 1, ctx.Err()
+	})
+
+	if _, err := s.Launch(context.Background(), "none"); !errors.Is(err, ErrUnknownJob) {
+		t.Errorf("Scheduler.Launch() error = %v, want %v", err, ErrUnknownJob)
+	}
+
+	// Запуск не ждет завершения задачи и не прерывается отменой контекста.
+	ctx, cancel := context.WithCancel(context.Background())
+	run, err := s.Launch(ctx, "slow")
+	cancel()
+	if err != nil || run.ID == 0 || !run.Manual || !run.Finished.IsZero() {
+		t.Fatalf("Scheduler.Launch() = %+v, %v", run, err)
+	}
+	if cur := s.Jobs()[0].Current; cur == nil || cur.ID != run.ID {
+		t.Errorf("Scheduler.Jobs() current = %+v, want run %d", cur, run.ID)
+	}
+	if _, err := s.Launch(context.Background(), "slow"); !errors.Is(err, ErrRunning) {
+		t.Errorf("Scheduler.Launch() error = %v, want %v", err, ErrRunning)
+	}
+	close(release)
+	s.Wait()
+
+	info := s.Jobs()[0]
+	if info.Running || info.Current != nil || len(info.Runs) != 1 {
+		t.Fatalf("Scheduler.Jobs() = %+v", info)
+	}
+	if got := info.Runs[0]; got.ID != run.ID || got.Error != "" || got.Result != 1 {
+		t.Errorf("Scheduler.Jobs() run = %+v", got)
+	}
+}
+
+func TestScheduler_LaunchFunc(t *testing.T) {
+	s := NewScheduler(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.Add("job", 0, func(ctx context.Context) (any, error) {
+		return "default", nil
+	})
+
+	// Переданная функция выполняется вместо функции задачи, запуск
+	// сохраняется в истории задачи.
+	_, err := s.LaunchFunc(context.Background(), "job", func(ctx context.Context) (any, error) {
+		return "custom", nil
+	})
+	if err != nil {
+		t.Fatalf("Scheduler.LaunchFunc() error = %v", err)
+	}
+	s.Wait()
+	if runs := s.Jobs()[0].Runs; len(runs) != 1 || runs[0].Result != "custom" {
+		t.Errorf("Scheduler.Jobs() runs = %+v", runs)
+	}
+}
+
+func TestScheduler_Start(t *testing.T) {
+	s := NewScheduler(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	calls := make(chan struct{}, 10)
+	s.Add("tick", time.Millisecond, func(ctx context.Context) (any, error) {
+		calls <- struct{}{}
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	<-calls
+	cancel()
+	s.Wait()
+
+	if jobs := s.Jobs(); len(jobs[0].Runs) == 0 || jobs[0].Runs[0].Manual {
+		t.Errorf("Scheduler.Jobs() = %+v, want scheduled run", jobs)
+	}
+}
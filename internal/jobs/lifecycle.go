@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/notifications"
+	"Report-Storage/internal/storage"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// UnverifiedExpirer - интерфейс для отклонения непроверенных заявок.
+type UnverifiedExpirer interface {
+	ExpireUnverified(ctx context.Context, before time.Time) ([]storage.Report, error)
+}
+
+// ClosedArchiver - интерфейс для переноса закрытых заявок в архив.
+type ClosedArchiver interface {
+	ArchiveClosed(ctx context.Context, before time.Time) (int, error)
+}
+
+// ExpireResult - структура результата отклонения непроверенных заявок.
+type ExpireResult struct {
+	// Rejected содержит количество отклоненных заявок.
+	Rejected int `json:"rejected"`
+	// Notified содержит количество отправленных уведомлений.
+	Notified int `json:"notified"`
+}
+
+// ArchiveResult - структура результата переноса заявок в архив.
+type ArchiveResult struct {
+	// Archived содержит количество перенесенных заявок.
+	Archived int `json:"archived"`
+}
+
+// ExpireUnverified возвращает задачу, которая отклоняет заявки, не
+// проверенные в течение after, и уведомляет об этом их авторов.
+func ExpireUnverified(l *slog.Logger, st UnverifiedExpirer, mail *notifications.SMTP, after time.Duration) JobFunc {
+	log := l.With(slog.String("job", "expire_unverified"))
+	return func(ctx context.Context) (any, error) {
+		var res ExpireResult
+		expired, err := st.ExpireUnverified(ctx, time.Now().Add(-after))
+		res.Rejected = len(expired)
+
+		// Уведомления отправляются и для заявок, измененных до ошибки.
+		for _, rep := range expired {
+			if mail == nil || rep.Contacts.Email == "" {
+				continue
+			}
+			if err := notifications.Expired(mail, rep.Contacts.Email); err != nil {
+				log.Error("failed to send notification to email", slog.Int64("number", rep.Number), logger.Err(err))
+				continue
+			}
+			res.Notified++
+		}
+		return res, err
+	}
+}
+
+// ArchiveClosed возвращает задачу, которая переносит в архив заявки,
+// закрытые раньше, чем after назад.
+func ArchiveClosed(st ClosedArchiver, after time.Duration) JobFunc {
+	return func(ctx context.Context) (any, error) {
+		n, err := st.ArchiveClosed(ctx, time.Now().Add(-after))
+		return ArchiveResult{Archived: n}, err
+	}
+}
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// lifecycle - хранилище заявок для тестов задач жизненного цикла.
+type lifecycle struct {
+	before time.Time
+}
+
+func (l *lifecycle) ExpireUnverified(ctx context.Context, before time.Time) ([]storage.Report, error) {
+	l.before = before
+	return []storage.Report{{Number: 1}, {Number: 2}}, nil
+}
+
+func (l *lifecycle) ArchiveClosed(ctx context.Context, before time.Time) (int, error) {
+	l.before = before
+	return 3, nil
+}
+
+func TestExpireUnverified(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	st := &lifecycle{}
+
+	got, err := ExpireUnverified(log, st, nil, time.Hour*24)(context.Background())
+	if err != nil {
+		t.Fatalf("ExpireUnverified() error = %v", err)
+	}
+	if res := got.(ExpireResult); res.Rejected != 2 || res.Notified != 0 {
+		t.Errorf("ExpireUnverified() = %+v, want 2 rejected", res)
+	}
+	if d := time.Since(st.before); d < time.Hour*24 || d > time.Hour*25 {
+		t.Errorf("ExpireUnverified() before = %v, want 24h ago", st.before)
+	}
+}
+
+func TestArchiveClosed(t *testing.T) {
+	st := &lifecycle{}
+
+	got, err := ArchiveClosed(st, time.Hour)(context.Background())
+	if err != nil {
+		t.Fatalf("ArchiveClosed() error = %v", err)
+	}
+	if res := got.(ArchiveResult); res.Archived != 3 {
+		t.Errorf("ArchiveClosed() = %+v, want 3 archived", res)
+	}
+	if d := time.Since(st.before); d < time.Hour || d > time.Hour*2 {
+		t.Errorf("ArchiveClosed() before = %v, want 1h ago", st.before)
+	}
+}
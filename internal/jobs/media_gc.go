@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/s3cloud"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// MediaGCJob - имя задачи удаления неиспользуемых файлов в планировщике.
+const MediaGCJob = "media_gc"
+
+// FileLister - интерфейс хранилища файлов для поиска и удаления
+// неиспользуемых файлов.
+type FileLister interface {
+	List(ctx context.Context) ([]s3cloud.Object, error)
+	Remove(ctx context.Context, url string) error
+}
+
+// MediaReferrer - интерфейс для получения имен файлов, на которые
+// ссылаются заявки.
+type MediaReferrer interface {
+	MediaFiles(ctx context.Context) ([]string, error)
+}
+
+// GCResult - структура результата сборки неиспользуемых файлов.
+type GCResult struct {
+	// DryRun равно true, если файлы не удалялись.
+	DryRun bool `json:"dry_run"`
+	// Checked содержит количество проверенных файлов хранилища.
+	Checked int `json:"checked"`
+	// Orphans содержит имена файлов, на которые не ссылается ни одна заявка.
+	Orphans []string `json:"orphans"`
+	// Removed содержит количество удаленных файлов.
+	Removed int `json:"removed"`
+	// Failed содержит имена файлов, которые не удалось удалить.
+	Failed []string `json:"failed"`
+}
+
+// MediaGC - задача удаления файлов хранилища, на которые не ссылается
+// ни одна заявка. Такие файлы остаются после ошибок загрузки и удаления
+// медиа файлов.
+type MediaGC struct {
+	log   *slog.Logger
+	st    MediaReferrer
+	files FileLister
+	grace time.Duration
+	mu    sync.Mutex
+}
+
+// NewMediaGC - конструктор задачи. Файлы моложе grace не удаляются, так
+// как заявка с ними может быть еще не сохранена в БД.
+func NewMediaGC(l *slog.Logger, st MediaReferrer, files FileLister, grace time.Duration) *MediaGC {
+	gc := &MediaGC{
+		log:   l.With(slog.String("job", "media_gc")),
+		st:    st,
+		files: files,
+		grace: grace,
+	}
+	return gc
+}
+
+// Run находит неиспользуемые файлы и удаляет их. Если dryRun равно true,
+// то файлы только возвращаются в результате. Одновременно может
+// выполняться только один запуск, иначе вернет ошибку ErrRunning.
+func (gc *MediaGC) Run(ctx context.Context, dryRun bool) (GCResult, error) {
+	res := GCResult{DryRun: dryRun, Orphans: []string{}, Failed: []string{}}
+
+	if !gc.mu.TryLock() {
+		return res, ErrRunning
+	}
+	defer gc.mu.Unlock()
+
+	// Файлы получаем до ссылок из БД. Тогда заявка, сохраненная между
+	// запросами, не сделает свои файлы неиспользуемыми.
+	objects, err := gc.files.List(ctx)
+	if err != nil {
+		return res, err
+	}
+	names, err := gc.st.MediaFiles(ctx)
+	if err != nil {
+		return res, err
+	}
+	used := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		used[name] = struct{}{}
+	}
+
+	deadline := time.Now().Add(-gc.grace)
+	for _, obj := range objects {
+		res.Checked++
+		if _, ok := used[obj.Name]; ok || obj.Modified.After(deadline) {
+			continue
+		}
+		res.Orphans = append(res.Orphans, obj.Name)
+	}
+	if dryRun {
+		gc.log.Info("orphaned media found", slog.Int("count", len(res.Orphans)))
+		return res, nil
+	}
+
+	for _, name := range res.Orphans {
+		if err := gc.files.Remove(ctx, name); err != nil {
+			gc.log.Error("cannot remove orphaned file", slog.String("file", name), logger.Err(err))
+			res.Failed = append(res.Failed, name)
+			continue
+		}
+		res.Removed++
+	}
+	gc.log.Info("orphaned media removed", slog.Int("removed", res.Removed), slog.Int("failed", len(res.Failed)))
+	return res, nil
+}
@@ -0,0 +1,94 @@
+package jobs
+
+import (
+	"Report-Storage/internal/s3cloud"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+// files - хранилище файлов для тестов.
+type files struct {
+	objects []s3cloud.Object
+	removed []string
+	fail    string
+}
+
+func (f *files) List(ctx context.Context) ([]s3cloud.Object, error) {
+	return f.objects, nil
+}
+
+func (f *files) Remove(ctx context.Context, url string) error {
+	if url == f.fail {
+		return errors.New("remove failed")
+	}
+	f.removed = append(f.removed, url)
+	return nil
+}
+
+// refs - ссылки заявок на файлы для тестов.
+type refs []string
+
+func (r refs) MediaFiles(ctx context.Context) ([]string, error) {
+	return r, nil
+}
+
+func TestMediaGC_Run(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	old := time.Now().Add(-time.Hour * 48)
+	objects := []s3cloud.Object{
+		{Name: "used.jpg", Modified: old},
+		{Name: "orphan.jpg", Modified: old},
+		{Name: "broken.jpg", Modified: old},
+		{Name: "fresh.jpg", Modified: time.Now()},
+	}
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		wantOrphans []string
+		wantRemoved []string
+		wantFailed  []string
+	}{
+		{
+			name:        "OK Dry run",
+			dryRun:      true,
+			wantOrphans: []string{"orphan.jpg", "broken.jpg"},
+			wantFailed:  []string{},
+		},
+		{
+			name:        "OK Remove",
+			dryRun:      false,
+			wantOrphans: []string{"orphan.jpg", "broken.jpg"},
+			wantRemoved: []string{"orphan.jpg"},
+			wantFailed:  []string{"broken.jpg"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &files{objects: objects, fail: "broken.jpg"}
+			gc := NewMediaGC(log, refs{"used.jpg"}, f, time.Hour*24)
+
+			got, err := gc.Run(context.Background(), tt.dryRun)
+			if err != nil {
+				t.Fatalf("MediaGC.Run() error = %v", err)
+			}
+			if got.Checked != len(objects) {
+				t.Errorf("MediaGC.Run() checked = %d, want %d", got.Checked, len(objects))
+			}
+			if !slices.Equal(got.Orphans, tt.wantOrphans) {
+				t.Errorf("MediaGC.Run() orphans = %v, want %v", got.Orphans, tt.wantOrphans)
+			}
+			if !slices.Equal(f.removed, tt.wantRemoved) || got.Removed != len(tt.wantRemoved) {
+				t.Errorf("MediaGC.Run() removed = %v, want %v", f.removed, tt.wantRemoved)
+			}
+			if !slices.Equal(got.Failed, tt.wantFailed) {
+				t.Errorf("MediaGC.Run() failed = %v, want %v", got.Failed, tt.wantFailed)
+			}
+		})
+	}
+}
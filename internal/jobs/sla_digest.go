@@ -0,0 +1,75 @@
+package jobs
+
+import (
+	"Report-Storage/internal/notifications"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// OverdueFinder - интерфейс для получения просроченных заявок.
+type OverdueFinder interface {
+	Overdue(ctx context.Context, rules []storage.SLARule, now time.Time) ([]storage.Report, error)
+}
+
+// DigestResult - структура результата отправки сводки.
+type DigestResult struct {
+	// Overdue содержит количество просроченных заявок.
+	Overdue int `json:"overdue"`
+	// Sent равно true, если сводка была отправлена.
+	Sent bool `json:"sent"`
+}
+
+// SLADigest возвращает задачу, которая отправляет на почту supervisors
+// сводку по заявкам, просроченным согласно правилам rules. Если
+// просроченных заявок нет, то сводка не отправляется.
+func SLADigest(st OverdueFinder, mail *notifications.SMTP, rules []storage.SLARule, supervisors []string) JobFunc {
+	return func(ctx context.Context) (any, error) {
+		var res DigestResult
+		now := time.Now()
+
+		overdue, err := st.Overdue(ctx, rules, now)
+		if err != nil {
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				return res, nil
+			}
+			return res, err
+		}
+		res.Overdue = len(overdue)
+		if len(supervisors) == 0 || mail == nil {
+			return res, nil
+		}
+
+		err = notifications.Digest(mail, supervisors, digestLines(overdue, now))
+		if err != nil {
+			return res, err
+		}
+		res.Sent = true
+		return res, nil
+	}
+}
+
+// digestLines формирует строки сводки по просроченным заявкам.
+func digestLines(reports []storage.Report, now time.Time) []string {
+	lines := make([]string, 0, len(reports))
+	for _, rep := range reports {
+		since := rep.StatusSince
+		if since.IsZero() {
+			since = rep.Updated
+		}
+		line := fmt.Sprintf("№%d, %s, %s: %s %d ч.",
+			rep.Number,
+			rep.City,
+			rep.Address,
+			storage.StatusName(rep.Status),
+			int(now.Sub(since).Hours()),
+		)
+		if rep.Category != "" {
+			line += fmt.Sprintf(" (%s)", rep.Category)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
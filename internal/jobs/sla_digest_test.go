@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"Report-Storage/internal/storage"
+	"slices"
+	"testing"
+	"time"
+)
+
+func Test_digestLines(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	reports := []storage.Report{
+		{
+			Number:      7,
+			City:        "Москва",
+			Address:     "Адрес 1",
+			Category:    "hatch",
+			Status:      storage.Opened,
+			StatusSince: now.Add(-time.Hour * 30),
+		},
+		{
+			Number:  8,
+			City:    "Москва",
+			Address: "Адрес 2",
+			Status:  storage.InProgress,
+			Updated: now.Add(-time.Hour * 100),
+		},
+	}
+	want := []string{
+		"№7, Москва, Адрес 1: Создана 30 ч. (hatch)",
+		"№8, Москва, Адрес 2: В работе 100 ч.",
+	}
+	if got := digestLines(reports, now); !slices.Equal(got, want) {
+		t.Errorf("digestLines() = %v, want %v", got, want)
+	}
+}
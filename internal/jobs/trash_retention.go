@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"Report-Storage/internal/reports"
+	"Report-Storage/internal/storage"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// TrashPurger - интерфейс для окончательного удаления заявок из корзины.
+type TrashPurger interface {
+	PurgeTrashed(ctx context.Context, before time.Time) ([]storage.Report, error)
+}
+
+// TrashRetention - задача окончательного удаления заявок, которые
+// находятся в корзине дольше срока хранения, вместе с их медиа файлами.
+type TrashRetention struct {
+	log       *slog.Logger
+	st        TrashPurger
+	files     reports.FileSaver
+	retention time.Duration
+}
+
+// NewTrashRetention - конструктор задачи очистки корзины.
+func NewTrashRetention(l *slog.Logger, st TrashPurger, files reports.FileSaver, retention time.Duration) *TrashRetention {
+	tr := &TrashRetention{
+		log:       l.With(slog.String("job", "trash_retention")),
+		st:        st,
+		files:     files,
+		retention: retention,
+	}
+	return tr
+}
+
+// Run удаляет заявки с истекшим сроком хранения в корзине и их файлы.
+func (tr *TrashRetention) Run(ctx context.Context) (reports.PurgeResult, error) {
+	deleted, err := tr.st.PurgeTrashed(ctx, time.Now().Add(-tr.retention))
+	// Файлы заявок, удаленных до ошибки, тоже необходимо удалить.
+	res := reports.Purge(ctx, tr.log, tr.files, deleted)
+	if err != nil {
+		return res, err
+	}
+	if res.Reports > 0 {
+		tr.log.Info("trash purged", slog.Int("reports", res.Reports), slog.Int("files", res.Files), slog.Int("failed", len(res.Failed)))
+	}
+	return res, nil
+}
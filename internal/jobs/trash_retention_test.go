@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"Report-Storage/internal/s3cloud"
+	"Report-Storage/internal/storage"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// trash - корзина заявок для тестов.
+type trash struct {
+	reports []storage.Report
+	before  time.Time
+}
+
+func (t *trash) PurgeTrashed(ctx context.Context, before time.Time) ([]storage.Report, error) {
+	t.before = before
+	return t.reports, nil
+}
+
+func (f *files) Upload(ctx context.Context, input s3cloud.UploadInput) (string, error) {
+	return "", nil
+}
+
+func TestTrashRetention_Run(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	st := &trash{reports: []storage.Report{
+		{Number: 1, Media: []storage.Media{{URL: "https://ya.ru/a.jpg"}, {URL: "https://ya.ru/b.jpg"}}},
+	}}
+	f := &files{}
+	tr := NewTrashRetention(log, st, f, time.Hour*24)
+
+	got, err := tr.Run(context.Background())
+	if err != nil {
+		t.Fatalf("TrashRetention.Run() error = %v", err)
+	}
+	if got.Reports != 1 || got.Files != 2 || len(got.Failed) != 0 {
+		t.Errorf("TrashRetention.Run() = %+v, want 1 report and 2 files", got)
+	}
+	if d := time.Since(st.before); d < time.Hour*24 || d > time.Hour*25 {
+		t.Errorf("TrashRetention.Run() before = %v, want 24h ago", st.before)
+	}
+}
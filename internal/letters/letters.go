@@ -0,0 +1,243 @@
+// Пакет letters формирует официальные письма по заявкам в формате PDF
+// из шаблонов, которые ведут администраторы.
+package letters
+
+import (
+	"Report-Storage/internal/pdf"
+	"Report-Storage/internal/storage"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// Размеры шрифта и отступы письма в пунктах.
+const (
+	titleSize  = 14
+	bodySize   = 11
+	gap        = 12
+	photoLimit = 320
+)
+
+// dateLayout - формат дат в тексте письма.
+const dateLayout = "02.01.2006"
+
+// maxText - максимальный размер заголовка или текста письма в байтах.
+const maxText = 64 << 10
+
+// allowedFuncs - функции, разрешенные в шаблонах. Функции форматирования
+// не разрешены, так как позволяют получить строку произвольной длины.
+var allowedFuncs = map[string]bool{
+	"and": true, "or": true, "not": true, "len": true,
+	"eq": true, "ne": true, "lt": true, "le": true, "gt": true, "ge": true,
+}
+
+// ErrTooLong - ошибка превышения размера текста письма.
+var ErrTooLong = errors.New("letter text is too long")
+
+// Default - шаблон письма, используемый, если шаблон не выбран.
+var Default = storage.Template{
+	Name:  "Обращение по заявке",
+	Title: "Обращение по заявке № {{.Number}}",
+	Body: `Кому: {{if .Organization}}{{.Organization}}{{else}}в организацию, ответственную за содержание территории{{end}}
+Дата: {{.Date}}
+
+{{.Created}} в проект "Осторожно, люк!" поступила заявка № {{.Number}} о проблеме по адресу: {{.City}}, {{.Address}}. Координаты места: широта {{.Lat}}, долгота {{.Lon}}.
+{{if .Description}}
+Описание проблемы: {{.Description}}
+{{end}}
+Текущий статус заявки: {{.Status}}. Просим принять меры по устранению проблемы и сообщить о результатах.{{if .Photos}}
+
+Фотографии с места приложены ниже.{{end}}`,
+}
+
+// Data - данные заявки, доступные в шаблоне письма.
+type Data struct {
+	Number       int64
+	Created      string
+	Date         string
+	City         string
+	Address      string
+	Description  string
+	Category     string
+	Status       string
+	Lat, Lon     string
+	Organization string
+	// Photos содержит количество фотографий, приложенных к письму.
+	Photos int
+}
+
+// NewData возвращает данные для шаблона письма по заявке rep, за которую
+// отвечает организация org, на дату now. Если ответственная организация
+// не назначена, то org - пустая структура.
+func NewData(rep storage.Report, org storage.Organization, now time.Time) Data {
+	return Data{
+		Number:       rep.Number,
+		Created:      rep.Created.Local().Format(dateLayout),
+		Date:         now.Local().Format(dateLayout),
+		City:         rep.City,
+		Address:      rep.Address,
+		Description:  rep.Description,
+		Category:     rep.Category,
+		Status:       storage.StatusName(rep.Status),
+		Lat:          strconv.FormatFloat(rep.Geo.Coordinates.Lat(), 'f', 6, 64),
+		Lon:          strconv.FormatFloat(rep.Geo.Coordinates.Lon(), 'f', 6, 64),
+		Organization: org.Name,
+	}
+}
+
+// Execute подставляет данные в заголовок и текст шаблона.
+func Execute(tpl storage.Template, data Data) (title, body string, err error) {
+	title, err = execute("title", tpl.Title, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err = execute("body", tpl.Body, data)
+	if err != nil {
+		return "", "", err
+	}
+	return title, body, nil
+}
+
+// Check проверяет синтаксис шаблона и использование только существующих
+// полей данных письма. Шаблон выполняется с заполненными полями, чтобы
+// проверить и условные части.
+func Check(tpl storage.Template) error {
+	rep := storage.Report{
+		Number:      1,
+		City:        "Город",
+		Address:     "Адрес",
+		Description: "Описание",
+		Category:    "Категория",
+		Status:      storage.Opened,
+	}
+	data := NewData(rep, storage.Organization{Name: "Организация"}, time.Now())
+	data.Photos = 1
+	_, _, err := Execute(tpl, data)
+	return err
+}
+
+// Render формирует письмо в формате PDF по шаблону tpl и записывает его
+// в w. Фотографии в формате JPEG выводятся после текста письма.
+func Render(w io.Writer, font *pdf.Font, tpl storage.Template, data Data, photos [][]byte) error {
+	data.Photos = len(photos)
+	title, body, err := Execute(tpl, data)
+	if err != nil {
+		return err
+	}
+
+	doc := pdf.New(font)
+	doc.SetTitle(title)
+	doc.Text(title, titleSize, pdf.Center)
+	doc.Space(gap)
+	doc.Text(body, bodySize, pdf.Left)
+	for i, photo := range photos {
+		doc.Space(gap)
+		if err := doc.Image(photo, photoLimit); err != nil {
+			return fmt.Errorf("photo %d: %w", i+1, err)
+		}
+	}
+	_, err = doc.WriteTo(w)
+	return err
+}
+
+// execute выполняет шаблон text в синтаксисе text/template. Шаблоны
+// ведут администраторы, поэтому циклы, вложенные шаблоны и функции,
+// кроме allowedFuncs, запрещены, а размер результата ограничен maxText.
+func execute(name, text string, data Data) (string, error) {
+	t, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	if len(t.Templates()) > 1 {
+		return "", fmt.Errorf("template: %s: nested templates are not allowed", name)
+	}
+	if err := checkNode(t.Tree.Root); err != nil {
+		return "", fmt.Errorf("template: %s: %w", name, err)
+	}
+	w := &limitedBuilder{n: maxText}
+	if err := t.Execute(w, data); err != nil {
+		if errors.Is(err, ErrTooLong) {
+			return "", ErrTooLong
+		}
+		return "", err
+	}
+	return w.sb.String(), nil
+}
+
+// checkNode проверяет, что узел шаблона и вложенные в него узлы не
+// содержат запрещенных конструкций.
+func checkNode(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := checkNode(c); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkNode(n.Pipe)
+	case *parse.IfNode:
+		return checkBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return checkBranch(&n.BranchNode)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Cmds {
+			if err := checkNode(c); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			if err := checkNode(a); err != nil {
+				return err
+			}
+		}
+	case *parse.ChainNode:
+		return checkNode(n.Node)
+	case *parse.IdentifierNode:
+		if !allowedFuncs[n.Ident] {
+			return fmt.Errorf("function %q is not allowed", n.Ident)
+		}
+	case *parse.RangeNode:
+		return errors.New("range is not allowed")
+	case *parse.TemplateNode:
+		return errors.New("nested templates are not allowed")
+	}
+	return nil
+}
+
+// checkBranch проверяет условие и ветви узлов if и with.
+func checkBranch(n *parse.BranchNode) error {
+	if err := checkNode(n.Pipe); err != nil {
+		return err
+	}
+	if err := checkNode(n.List); err != nil {
+		return err
+	}
+	return checkNode(n.ElseList)
+}
+
+// limitedBuilder накапливает строку и возвращает ErrTooLong при попытке
+// записать больше n байт.
+type limitedBuilder struct {
+	sb strings.Builder
+	n  int
+}
+
+func (b *limitedBuilder) Write(p []byte) (int, error) {
+	if b.sb.Len()+len(p) > b.n {
+		return 0, ErrTooLong
+	}
+	return b.sb.Write(p)
+}
@@ -0,0 +1,72 @@
+package letters
+
+import (
+	"Report-Storage/internal/storage"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewData(t *testing.T) {
+	rep := storage.Report{
+		Number:  42,
+		Created: time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local),
+		City:    "Москва",
+		Address: "ул. Тверская, 1",
+		Geo:     storage.Geo{Coordinates: storage.LonLat{37.61, 55.76}},
+		Status:  storage.InProgress,
+	}
+	got := NewData(rep, storage.Organization{Name: "Управа района"}, time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local))
+	want := Data{
+		Number: 42, Created: "01.03.2024", Date: "05.03.2024", City: "Москва", Address: "ул. Тверская, 1",
+		Status: "В работе", Lat: "55.760000", Lon: "37.610000", Organization: "Управа района",
+	}
+	if got != want {
+		t.Errorf("NewData() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	data := Data{Number: 42, City: "Москва", Address: "ул. Тверская, 1", Status: "Создана"}
+	title, body, err := Execute(Default, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "Обращение по заявке № 42" {
+		t.Errorf("Execute() title = %q", title)
+	}
+	for _, want := range []string{"Москва, ул. Тверская, 1", "в организацию, ответственную", "статус заявки: Создана."} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Execute() body does not contain %q", want)
+		}
+	}
+	if strings.Contains(body, "Описание проблемы") || strings.Contains(body, "Фотографии") {
+		t.Errorf("Execute() body contains empty sections: %q", body)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		tpl     storage.Template
+		wantErr bool
+	}{
+		{name: "OK Default", tpl: Default},
+		{name: "Error Syntax", tpl: storage.Template{Title: "{{.Number", Body: "Текст"}, wantErr: true},
+		{name: "Error Unknown field", tpl: storage.Template{Title: "Заявка", Body: "{{.Contacts}}"}, wantErr: true},
+		{name: "Error Unknown field in condition", tpl: storage.Template{Title: "Заявка", Body: "{{if .Description}}{{.Phone}}{{end}}"}, wantErr: true},
+		{name: "OK Comparison", tpl: storage.Template{Title: "Заявка", Body: "{{if and (gt .Photos 0) (ne .City \"\")}}{{.City}}{{end}}"}},
+		{name: "Error Range", tpl: storage.Template{Title: "Заявка", Body: "{{range .City}}{{.}}{{end}}"}, wantErr: true},
+		{name: "Error Define", tpl: storage.Template{Title: "Заявка", Body: `{{define "a"}}{{template "a"}}{{end}}{{template "a"}}`}, wantErr: true},
+		{name: "Error Template in condition", tpl: storage.Template{Title: "Заявка", Body: `{{if .City}}{{template "body"}}{{end}}`}, wantErr: true},
+		{name: "Error Printf", tpl: storage.Template{Title: "Заявка", Body: `{{printf "%0999999999d" 1}}`}, wantErr: true},
+		{name: "Error Too long", tpl: storage.Template{Title: "Заявка", Body: strings.Repeat("{{.City}}", maxText)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Check(tt.tpl); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Пакет localfs реализует хранение медиа файлов заявок в локальной
+// файловой системе. Используется для разработки и запуска без S3.
+package localfs
+
+import (
+	"Report-Storage/internal/s3cloud"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrIncorrectName - ошибка некорректного имени файла.
+var ErrIncorrectName = errors.New("incorrect file name")
+
+// FileStorage - структура локального хранилища файлов.
+type FileStorage struct {
+	dir string
+	url string
+}
+
+// New - конструктор локального хранилища. Файлы сохраняются в каталог
+// dir, ссылки на файлы формируются из адреса url.
+func New(dir, url string) (*FileStorage, error) {
+	const operation = "localfs.New"
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	fs := &FileStorage{
+		dir: dir,
+		url: strings.TrimSuffix(url, "/"),
+	}
+	return fs, nil
+}
+
+// Upload сохраняет файл в каталог хранилища. Возвращает url сохраненного
+// файла, либо ошибку.
+func (fs *FileStorage) Upload(ctx context.Context, input s3cloud.UploadInput) (string, error) {
+	const operation = "localfs.Upload"
+
+	path, err := fs.path(input.Name)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", operation, err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", operation, err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, input.File)
+	if err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("%s: %w", operation, err)
+	}
+
+	url := fmt.Sprintf("%s/%s", fs.url, input.Name)
+	return url, nil
+}
+
+// Remove удаляет файл с переданным url из хранилища. Отсутствие файла
+// не является ошибкой.
+func (fs *FileStorage) Remove(ctx context.Context, url string) error {
+	const operation = "localfs.Remove"
+
+	str := strings.Split(url, "/")
+	path, err := fs.path(str[len(str)-1])
+	if err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	return nil
+}
+
+// List возвращает все файлы каталога хранилища.
+func (fs *FileStorage) List(ctx context.Context) ([]s3cloud.Object, error) {
+	const operation = "localfs.List"
+
+	entries, err := os.ReadDir(fs.dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	objects := make([]s3cloud.Object, 0, len(entries))
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			// Файл мог быть удален после чтения каталога.
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("%s: %w", operation, err)
+		}
+		objects = append(objects, s3cloud.Object{Name: e.Name(), Modified: info.ModTime()})
+	}
+	return objects, nil
+}
+
+// Download считывает файл с именем key из каталога хранилища. Если размер
+// файла больше max, то вернет ошибку s3cloud.ErrTooLarge.
+func (fs *FileStorage) Download(ctx context.Context, key string, max int64) ([]byte, error) {
+	const operation = "localfs.Download"
+
+	path, err := fs.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, max+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if int64(len(data)) > max {
+		return nil, fmt.Errorf("%s: %w", operation, s3cloud.ErrTooLarge)
+	}
+	return data, nil
+}
+
+// Handler возвращает обработчик для раздачи файлов хранилища по пути
+// с префиксом prefix. Просмотр содержимого каталога запрещен.
+func (fs *FileStorage) Handler(prefix string) http.Handler {
+	files := http.StripPrefix(prefix, http.FileServer(http.Dir(fs.dir)))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		files.ServeHTTP(w, r)
+	})
+}
+
+// path возвращает путь к файлу с именем name в каталоге хранилища.
+// Имя не должно содержать разделителей пути.
+func (fs *FileStorage) path(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", ErrIncorrectName
+	}
+	return filepath.Join(fs.dir, name), nil
+}
@@ -0,0 +1,162 @@
+package localfs
+
+import (
+	"Report-Storage/internal/s3cloud"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileStorage_UploadRemove(t *testing.T) {
+	dir := t.TempDir()
+	fs, err := New(dir, "http://localhost/media/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		wantURL  string
+		wantErr  bool
+	}{
+		{
+			name:     "OK",
+			filename: "photo.jpg",
+			wantURL:  "http://localhost/media/photo.jpg",
+			wantErr:  false,
+		},
+		{
+			name:     "Error Path traversal",
+			filename: "../photo.jpg",
+			wantErr:  true,
+		},
+		{
+			name:     "Error Empty name",
+			filename: "",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := s3cloud.UploadInput{
+				File: strings.NewReader("data"),
+				Name: tt.filename,
+				Size: 4,
+			}
+			got, err := fs.Upload(context.Background(), input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FileStorage.Upload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.wantURL {
+				t.Errorf("FileStorage.Upload() = %s, want %s", got, tt.wantURL)
+			}
+
+			if err := fs.Remove(context.Background(), got); err != nil {
+				t.Errorf("FileStorage.Remove() error = %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, tt.filename)); !os.IsNotExist(err) {
+				t.Errorf("FileStorage.Remove() file still exists")
+			}
+			if err := fs.Remove(context.Background(), got); err != nil {
+				t.Errorf("FileStorage.Remove() second call error = %v", err)
+			}
+		})
+	}
+}
+
+func TestFileStorage_Handler(t *testing.T) {
+	dir := t.TempDir()
+	fs, err := New(dir, "http://localhost/media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "photo.jpg"), []byte("data"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(fs.Handler("/media/"))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{
+			name: "OK File",
+			path: "/media/photo.jpg",
+			want: http.StatusOK,
+		},
+		{
+			name: "Error Directory listing",
+			path: "/media/",
+			want: http.StatusNotFound,
+		},
+		{
+			name: "Error Not found",
+			path: "/media/none.jpg",
+			want: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			io.Copy(io.Discard, resp.Body)
+			if resp.StatusCode != tt.want {
+				t.Errorf("FileStorage.Handler() status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStorage_Download(t *testing.T) {
+	dir := t.TempDir()
+	fs, err := New(dir, "http://localhost/media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "photo.jpg"), []byte("data"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		max     int64
+		wantErr error
+	}{
+		{name: "OK", key: "photo.jpg", max: 4},
+		{name: "Error Too large", key: "photo.jpg", max: 3, wantErr: s3cloud.ErrTooLarge},
+		{name: "Error Not found", key: "video.mp4", max: 4, wantErr: os.ErrNotExist},
+		{name: "Error Path traversal", key: "../photo.jpg", max: 4, wantErr: ErrIncorrectName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fs.Download(context.Background(), tt.key, tt.max)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FileStorage.Download() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && string(got) != "data" {
+				t.Errorf("FileStorage.Download() = %q, want %q", got, "data")
+			}
+		})
+	}
+}
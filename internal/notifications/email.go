@@ -3,6 +3,7 @@ package notifications
 import (
 	"fmt"
 	"net/smtp"
+	"strings"
 )
 
 const (
@@ -14,6 +15,24 @@ const (
 	newSubject = "Создана новая заявка"
 	// Тело письма о создании новой заявки.
 	newBody = "Создана новая заявка в проекте \"Осторожно, люк!\".\nЗаявка отобразится на карте после проверки модератором."
+	// Тема письма о закрытии заявки.
+	resolvedSubject = "Заявка закрыта"
+	// Тело письма о закрытии заявки.
+	resolvedBody = "Ваша заявка в проекте \"Осторожно, люк!\" закрыта.\nКомментарий ремонтной бригады: "
+	// Заголовок списка фото после ремонта в письме о закрытии заявки.
+	resolvedMedia = "\n\nФото после ремонта:\n"
+	// Тема письма со сводкой по просроченным заявкам.
+	digestSubject = "Просроченные заявки"
+	// Заголовок письма со сводкой по просроченным заявкам.
+	digestBody = "Заявки в проекте \"Осторожно, люк!\", которые находятся в текущем статусе дольше допустимого срока:\n\n"
+	// Тема письма об автоматическом отклонении заявки.
+	expiredSubject = "Заявка отклонена"
+	// Тело письма об автоматическом отклонении заявки.
+	expiredBody = "Ваша заявка в проекте \"Осторожно, люк!\" отклонена, так как не была проверена модератором в установленный срок.\nЕсли проблема не устранена, пожалуйста, создайте новую заявку."
+	// Тема письма о назначении заявки исполнителю.
+	assignedSubject = "Назначена заявка"
+	// Тело письма о назначении заявки исполнителю.
+	assignedBody = "Вам назначена заявка в проекте \"Осторожно, люк!\".\nНомер заявки: %d\nАдрес: %s"
 )
 
 // SMTP - структура клиента SMTP сервера.
@@ -63,3 +82,65 @@ func NewReport(mail *SMTP, target string) error {
 	err := smtp.SendMail(addr, auth, mail.sender, []string{target}, []byte(msg))
 	return err
 }
+
+// Resolved отправляет уведомление на почту target о закрытии заявки
+// с комментарием comment и ссылками на фото после ремонта media.
+func Resolved(mail *SMTP, target, comment string, media []string) error {
+	auth := smtp.PlainAuth("", mail.login, mail.password, mail.host)
+
+	body := resolvedBody + comment
+	if len(media) > 0 {
+		body += resolvedMedia + strings.Join(media, "\n")
+	}
+	msg := fmt.Sprintf(
+		"To: %s\r\nSubject: %s\r\n\r\n%s\r\n", target, resolvedSubject, body,
+	)
+	addr := fmt.Sprintf("%s:%s", mail.host, mail.port)
+
+	err := smtp.SendMail(addr, auth, mail.sender, []string{target}, []byte(msg))
+	return err
+}
+
+// Expired отправляет уведомление на почту target об автоматическом
+// отклонении заявки, которая не была проверена в срок.
+func Expired(mail *SMTP, target string) error {
+	auth := smtp.PlainAuth("", mail.login, mail.password, mail.host)
+
+	msg := fmt.Sprintf(
+		"To: %s\r\nSubject: %s\r\n\r\n%s\r\n", target, expiredSubject, expiredBody,
+	)
+	addr := fmt.Sprintf("%s:%s", mail.host, mail.port)
+
+	err := smtp.SendMail(addr, auth, mail.sender, []string{target}, []byte(msg))
+	return err
+}
+
+// Digest отправляет на почту targets сводку по просроченным заявкам,
+// где каждая строка lines описывает одну заявку.
+func Digest(mail *SMTP, targets []string, lines []string) error {
+	auth := smtp.PlainAuth("", mail.login, mail.password, mail.host)
+
+	body := digestBody + strings.Join(lines, "\n")
+	msg := fmt.Sprintf(
+		"To: %s\r\nSubject: %s\r\n\r\n%s\r\n", strings.Join(targets, ", "), digestSubject, body,
+	)
+	addr := fmt.Sprintf("%s:%s", mail.host, mail.port)
+
+	err := smtp.SendMail(addr, auth, mail.sender, targets, []byte(msg))
+	return err
+}
+
+// Assigned отправляет уведомление на почту target о назначении заявки
+// с номером num и адресом address.
+func Assigned(mail *SMTP, target string, num int64, address string) error {
+	auth := smtp.PlainAuth("", mail.login, mail.password, mail.host)
+
+	body := fmt.Sprintf(assignedBody, num, address)
+	msg := fmt.Sprintf(
+		"To: %s\r\nSubject: %s\r\n\r\n%s\r\n", target, assignedSubject, body,
+	)
+	addr := fmt.Sprintf("%s:%s", mail.host, mail.port)
+
+	err := smtp.SendMail(addr, auth, mail.sender, []string{target}, []byte(msg))
+	return err
+}
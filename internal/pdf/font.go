@@ -0,0 +1,306 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"unicode/utf16"
+)
+
+var (
+	ErrFontFormat     = errors.New("pdf: unsupported font format")
+	ErrFontCorrupted  = errors.New("pdf: corrupted font")
+	ErrFontRestricted = errors.New("pdf: font license restricts embedding")
+)
+
+// Font - шрифт TrueType, встраиваемый в документ целиком. Стандартные
+// шрифты PDF не содержат кириллицы, поэтому текст выводится только
+// встроенным шрифтом с кодированием глифов Identity-H.
+type Font struct {
+	name       string
+	unitsPerEm float64
+	widths     []uint16
+	glyphs     map[rune]uint16
+	ascent     int16
+	descent    int16
+	capHeight  int16
+	bbox       [4]int16
+
+	// data содержит сжатый файл шрифта для потока FontFile2, size -
+	// его исходный размер.
+	data []byte
+	size int
+}
+
+// LoadFont загружает шрифт TrueType из файла path.
+func LoadFont(path string) (*Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFont(data)
+}
+
+// ParseFont разбирает шрифт TrueType с контурами glyf. Шрифты с контурами
+// CFF и коллекции шрифтов не поддерживаются.
+func ParseFont(data []byte) (*Font, error) {
+	if len(data) < 12 {
+		return nil, ErrFontCorrupted
+	}
+	switch string(data[:4]) {
+	case "\x00\x01\x00\x00", "true":
+	default:
+		return nil, ErrFontFormat
+	}
+
+	// Таблица смещений: тег, контрольная сумма, смещение и длина.
+	tables := map[string][]byte{}
+	n := int(u16(data, 4))
+	if len(data) < 12+16*n {
+		return nil, ErrFontCorrupted
+	}
+	for i := 0; i < n; i++ {
+		rec := data[12+16*i:]
+		off, size := int64(u32(rec, 8)), int64(u32(rec, 12))
+		if off+size > int64(len(data)) {
+			return nil, ErrFontCorrupted
+		}
+		tables[string(rec[:4])] = data[off : off+size]
+	}
+	for _, tag := range []string{"head", "hhea", "maxp", "hmtx", "cmap", "glyf"} {
+		if tables[tag] == nil {
+			return nil, fmt.Errorf("%w: no %s table", ErrFontFormat, tag)
+		}
+	}
+
+	head, hhea, maxp := tables["head"], tables["hhea"], tables["maxp"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return nil, ErrFontCorrupted
+	}
+	f := &Font{
+		name:       "Font",
+		unitsPerEm: float64(u16(head, 18)),
+		ascent:     int16(u16(hhea, 4)),
+		descent:    int16(u16(hhea, 6)),
+	}
+	if f.unitsPerEm == 0 {
+		return nil, ErrFontCorrupted
+	}
+	for i := range f.bbox {
+		f.bbox[i] = int16(u16(head, 36+2*i))
+	}
+	f.capHeight = f.ascent
+
+	// Ширины глифов. Глифы после numberOfHMetrics имеют ширину последнего.
+	numGlyphs := int(u16(maxp, 4))
+	metrics := int(u16(hhea, 34))
+	hmtx := tables["hmtx"]
+	if metrics == 0 || metrics > numGlyphs || len(hmtx) < 4*metrics {
+		return nil, ErrFontCorrupted
+	}
+	f.widths = make([]uint16, numGlyphs)
+	for i := range f.widths {
+		f.widths[i] = u16(hmtx, 4*min(i, metrics-1))
+	}
+
+	if os2 := tables["OS/2"]; len(os2) >= 10 {
+		// Биты 0-3 fsType со значением 2 запрещают встраивание шрифта.
+		if u16(os2, 8)&0x000f == 0x0002 {
+			return nil, ErrFontRestricted
+		}
+		if u16(os2, 0) >= 2 && len(os2) >= 90 {
+			f.capHeight = int16(u16(os2, 88))
+		}
+	}
+	if name := postScriptName(tables["name"]); name != "" {
+		f.name = name
+	}
+
+	var err error
+	f.glyphs, err = parseCmap(tables["cmap"], numGlyphs)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	zw := zlib.NewWriter(buf)
+	zw.Write(data)
+	zw.Close()
+	f.data, f.size = buf.Bytes(), len(data)
+	return f, nil
+}
+
+// Name возвращает PostScript имя шрифта.
+func (f *Font) Name() string {
+	return f.name
+}
+
+// Glyph возвращает номер глифа для символа r. Для символов, отсутствующих
+// в шрифте, возвращает 0 - глиф .notdef.
+func (f *Font) Glyph(r rune) uint16 {
+	return f.glyphs[r]
+}
+
+// Width возвращает ширину строки s в пунктах для размера шрифта size.
+func (f *Font) Width(s string, size float64) float64 {
+	var w float64
+	for _, r := range s {
+		w += float64(f.widths[f.Glyph(r)])
+	}
+	return w * size / f.unitsPerEm
+}
+
+// scale переводит значение в единицах шрифта в тысячные доли кегля,
+// принятые в PDF.
+func (f *Font) scale(v float64) int {
+	return int(v * 1000 / f.unitsPerEm)
+}
+
+// parseCmap строит таблицу соответствия символов Unicode номерам глифов
+// из подтаблиц форматов 4 и 12.
+func parseCmap(cmap []byte, numGlyphs int) (map[rune]uint16, error) {
+	if len(cmap) < 4 {
+		return nil, ErrFontCorrupted
+	}
+
+	// Выбираем подтаблицу Unicode: полную форматов 3.10 или 0.4, либо
+	// для основной плоскости форматов 3.1 или 0.3.
+	var sub []byte
+	best := 0
+	n := int(u16(cmap, 2))
+	if len(cmap) < 4+8*n {
+		return nil, ErrFontCorrupted
+	}
+	for i := 0; i < n; i++ {
+		rec := cmap[4+8*i:]
+		platform, encoding, off := u16(rec, 0), u16(rec, 2), int(u32(rec, 4))
+		rank := 0
+		switch {
+		case platform == 3 && encoding == 10, platform == 0 && encoding == 4:
+			rank = 2
+		case platform == 3 && encoding == 1, platform == 0 && encoding == 3:
+			rank = 1
+		}
+		if rank > best && off+4 <= len(cmap) {
+			sub, best = cmap[off:], rank
+		}
+	}
+	if sub == nil {
+		return nil, fmt.Errorf("%w: no unicode cmap", ErrFontFormat)
+	}
+
+	glyphs := map[rune]uint16{}
+	add := func(r rune, g int) {
+		if g > 0 && g < numGlyphs {
+			glyphs[r] = uint16(g)
+		}
+	}
+
+	switch u16(sub, 0) {
+	case 4:
+		if len(sub) < 14 {
+			return nil, ErrFontCorrupted
+		}
+		segs := int(u16(sub, 6)) / 2
+		if len(sub) < 16+8*segs {
+			return nil, ErrFontCorrupted
+		}
+		ends, starts := 14, 16+2*segs
+		deltas, ranges := starts+2*segs, starts+4*segs
+		for i := 0; i < segs; i++ {
+			start, end := int(u16(sub, starts+2*i)), int(u16(sub, ends+2*i))
+			delta, ro := int(u16(sub, deltas+2*i)), int(u16(sub, ranges+2*i))
+			for c := start; c <= end && c != 0xffff; c++ {
+				if ro == 0 {
+					add(rune(c), (c+delta)&0xffff)
+					continue
+				}
+				// Смещение отсчитывается от позиции самого idRangeOffset.
+				pos := ranges + 2*i + ro + 2*(c-start)
+				if pos+2 > len(sub) {
+					return nil, ErrFontCorrupted
+				}
+				if g := int(u16(sub, pos)); g != 0 {
+					add(rune(c), (g+delta)&0xffff)
+				}
+			}
+		}
+	case 12:
+		if len(sub) < 16 {
+			return nil, ErrFontCorrupted
+		}
+		groups := int(u32(sub, 12))
+		if len(sub) < 16+12*groups {
+			return nil, ErrFontCorrupted
+		}
+		for i := 0; i < groups; i++ {
+			grp := sub[16+12*i:]
+			start, end, g := int(u32(grp, 0)), int(u32(grp, 4)), int(u32(grp, 8))
+			if end > 0x10ffff || end-start >= numGlyphs {
+				return nil, ErrFontCorrupted
+			}
+			for c := start; c <= end; c++ {
+				add(rune(c), g+c-start)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: cmap format %d", ErrFontFormat, u16(sub, 0))
+	}
+	return glyphs, nil
+}
+
+// postScriptName возвращает PostScript имя шрифта из таблицы name или
+// пустую строку. В имени допускаются только печатные символы ASCII без
+// разделителей PDF.
+func postScriptName(name []byte) string {
+	if len(name) < 6 {
+		return ""
+	}
+	n, base := int(u16(name, 2)), int(u16(name, 4))
+	if len(name) < 6+12*n {
+		return ""
+	}
+	for i := 0; i < n; i++ {
+		rec := name[6+12*i:]
+		platform, id := u16(rec, 0), u16(rec, 6)
+		size, off := int(u16(rec, 8)), base+int(u16(rec, 10))
+		if id != 6 || off+size > len(name) {
+			continue
+		}
+		raw := name[off : off+size]
+		var s []rune
+		switch platform {
+		case 1:
+			s = []rune(string(raw))
+		case 0, 3:
+			units := make([]uint16, len(raw)/2)
+			for j := range units {
+				units[j] = u16(raw, 2*j)
+			}
+			s = utf16.Decode(units)
+		default:
+			continue
+		}
+		clean := make([]rune, 0, len(s))
+		for _, r := range s {
+			if r > ' ' && r < 0x7f && !bytes.ContainsRune([]byte("[](){}<>/%"), r) {
+				clean = append(clean, r)
+			}
+		}
+		if len(clean) > 0 {
+			return string(clean)
+		}
+	}
+	return ""
+}
+
+func u16(b []byte, off int) uint16 {
+	return binary.BigEndian.Uint16(b[off:])
+}
+
+func u32(b []byte, off int) uint32 {
+	return binary.BigEndian.Uint32(b[off:])
+}
@@ -0,0 +1,103 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// testFont собирает минимальный шрифт TrueType из трех глифов: .notdef,
+// "A" и "Ж" шириной 500, 600 и 700 единиц при 1000 единицах на кегль.
+// Таблица OS/2 добавляется, если задан fsType.
+func testFont(fsType ...uint16) []byte {
+	be := func(vals ...any) []byte {
+		buf := new(bytes.Buffer)
+		for _, v := range vals {
+			binary.Write(buf, binary.BigEndian, v)
+		}
+		return buf.Bytes()
+	}
+
+	head := make([]byte, 54)
+	copy(head[18:], be(uint16(1000)))
+	copy(head[36:], be(int16(0), int16(-200), int16(700), int16(800)))
+	hhea := make([]byte, 36)
+	copy(hhea[4:], be(int16(800), int16(-200)))
+	copy(hhea[34:], be(uint16(3)))
+	maxp := be(uint32(0x5000), uint16(3))
+	hmtx := be(uint16(500), int16(0), uint16(600), int16(0), uint16(700), int16(0))
+
+	// Формат 4: сегменты "A", "Ж" и завершающий 0xFFFF.
+	sub := be(uint16(4), uint16(48), uint16(0), uint16(6), uint16(4), uint16(1), uint16(2),
+		uint16('A'), uint16('Ж'), uint16(0xffff), uint16(0),
+		uint16('A'), uint16('Ж'), uint16(0xffff),
+		uint16(0x10000+1-'A'), uint16(2-'Ж'+0x10000), uint16(1),
+		uint16(0), uint16(0), uint16(0))
+	cmap := append(be(uint16(0), uint16(1), uint16(3), uint16(1), uint32(12)), sub...)
+
+	tables := []struct {
+		tag  string
+		data []byte
+	}{
+		{"cmap", cmap}, {"glyf", make([]byte, 4)}, {"head", head},
+		{"hhea", hhea}, {"hmtx", hmtx}, {"maxp", maxp},
+	}
+	if len(fsType) > 0 {
+		tables = append(tables, struct {
+			tag  string
+			data []byte
+		}{"OS/2", be(uint16(0), int16(0), uint16(400), uint16(5), fsType[0])})
+	}
+
+	out := be(uint32(0x00010000), uint16(len(tables)), uint16(0), uint16(0), uint16(0))
+	off := len(out) + 16*len(tables)
+	var body []byte
+	for _, t := range tables {
+		out = append(out, t.tag...)
+		out = append(out, be(uint32(0), uint32(off+len(body)), uint32(len(t.data)))...)
+		body = append(body, t.data...)
+	}
+	return append(out, body...)
+}
+
+func TestParseFont(t *testing.T) {
+	f, err := ParseFont(testFont())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r, want := range map[rune]uint16{'A': 1, 'Ж': 2, 'Z': 0} {
+		if got := f.Glyph(r); got != want {
+			t.Errorf("Font.Glyph(%q) = %d, want %d", r, got, want)
+		}
+	}
+	if got := f.Width("AЖZ", 10); got != 18 {
+		t.Errorf("Font.Width() = %v, want 18", got)
+	}
+	if f.Name() != "Font" {
+		t.Errorf("Font.Name() = %q, want default name", f.Name())
+	}
+}
+
+func TestParseFont_Errors(t *testing.T) {
+	font := testFont()
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "CFF", data: append([]byte("OTTO"), font[4:]...), want: ErrFontFormat},
+		{name: "Truncated", data: font[:40], want: ErrFontCorrupted},
+		{name: "Restricted", data: testFont(2), want: ErrFontRestricted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseFont(tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("ParseFont() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if _, err := ParseFont(testFont(8)); err != nil {
+		t.Errorf("ParseFont() editable font error = %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrImageFormat - ошибка неподдерживаемого формата изображения.
+var ErrImageFormat = errors.New("pdf: unsupported image format")
+
+// jpegInfo - параметры изображения JPEG, необходимые для встраивания
+// без перекодирования с фильтром DCTDecode.
+type jpegInfo struct {
+	width, height int
+	components    int
+}
+
+// colorSpace возвращает цветовое пространство PDF для изображения.
+func (j jpegInfo) colorSpace() string {
+	if j.components == 1 {
+		return "/DeviceGray"
+	}
+	return "/DeviceRGB"
+}
+
+// CheckJPEG проверяет, что data - изображение JPEG, которое можно
+// встроить в документ.
+func CheckJPEG(data []byte) error {
+	_, err := parseJPEG(data)
+	return err
+}
+
+// parseJPEG читает размеры и количество цветовых компонент изображения
+// из маркера начала кадра SOF. Поддерживаются базовые и прогрессивные
+// изображения в оттенках серого и YCbCr.
+func parseJPEG(data []byte) (jpegInfo, error) {
+	var info jpegInfo
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return info, ErrImageFormat
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xff {
+			return info, fmt.Errorf("%w: marker expected at %d", ErrImageFormat, i)
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xff:
+			// Байты заполнения перед маркером.
+			i++
+			continue
+		case marker == 0x01 || marker >= 0xd0 && marker <= 0xd8:
+			// Маркеры без сегмента данных.
+			i += 2
+			continue
+		}
+
+		size := int(data[i+2])<<8 | int(data[i+3])
+		if size < 2 || i+2+size > len(data) {
+			return info, fmt.Errorf("%w: truncated segment", ErrImageFormat)
+		}
+		seg := data[i+4 : i+2+size]
+		switch marker {
+		case 0xc0, 0xc1, 0xc2:
+			if len(seg) < 6 || seg[0] != 8 {
+				return info, fmt.Errorf("%w: unsupported frame", ErrImageFormat)
+			}
+			info.height = int(seg[1])<<8 | int(seg[2])
+			info.width = int(seg[3])<<8 | int(seg[4])
+			info.components = int(seg[5])
+			if info.width == 0 || info.height == 0 || info.components != 1 && info.components != 3 {
+				return info, fmt.Errorf("%w: %dx%d with %d components", ErrImageFormat, info.width, info.height, info.components)
+			}
+			return info, nil
+		case 0xc3, 0xc5, 0xc6, 0xc7, 0xc9, 0xca, 0xcb, 0xcd, 0xce, 0xcf:
+			return info, fmt.Errorf("%w: lossless or arithmetic coding", ErrImageFormat)
+		case 0xda, 0xd9:
+			return info, fmt.Errorf("%w: no frame header", ErrImageFormat)
+		}
+		i += 2 + size
+	}
+	return info, fmt.Errorf("%w: no frame header", ErrImageFormat)
+}
@@ -0,0 +1,44 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"testing"
+)
+
+// testJPEG кодирует изображение JPEG размером w на h.
+func testJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := jpeg.Encode(buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func Test_parseJPEG(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    jpegInfo
+		wantErr bool
+	}{
+		{name: "OK Color", data: testJPEG(t, image.NewRGBA(image.Rect(0, 0, 40, 20))), want: jpegInfo{40, 20, 3}},
+		{name: "OK Gray", data: testJPEG(t, image.NewGray(image.Rect(0, 0, 8, 16))), want: jpegInfo{8, 16, 1}},
+		{name: "Error PNG", data: []byte("\x89PNG\r\n\x1a\n"), wantErr: true},
+		{name: "Error Truncated", data: []byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJPEG(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseJPEG() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseJPEG() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+// Пакет pdf формирует простые текстовые документы PDF со встроенным
+// шрифтом TrueType и изображениями JPEG. Текст переносится по словам,
+// страницы добавляются автоматически.
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf16"
+)
+
+// Размеры страницы A4 и поля в пунктах.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+	Margin     = 56.69
+)
+
+// lineSpacing - межстрочный интервал относительно размера шрифта.
+const lineSpacing = 1.4
+
+// Align - выравнивание строк текста.
+type Align int
+
+// Варианты выравнивания текста.
+const (
+	Left Align = iota
+	Center
+)
+
+// picture - изображение JPEG, встраиваемое в документ.
+type picture struct {
+	data []byte
+	info jpegInfo
+}
+
+// Document - документ PDF. Содержимое добавляется сверху вниз, документ
+// записывается целиком методом WriteTo.
+type Document struct {
+	font   *Font
+	title  string
+	pages  []*bytes.Buffer
+	images []picture
+	// used содержит символы, выведенные каждым глифом, для таблицы
+	// ширин и обратного отображения в Unicode.
+	used map[uint16]rune
+	// y - расстояние от верхнего края страницы до следующей строки.
+	y float64
+}
+
+// New создает пустой документ, текст которого выводится шрифтом font.
+func New(font *Font) *Document {
+	return &Document{font: font, used: map[uint16]rune{}}
+}
+
+// SetTitle устанавливает название документа в его свойствах.
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// Text выводит текст шрифтом размера size с переносом по словам.
+// Переводы строки в тексте начинают новый абзац.
+func (d *Document) Text(text string, size float64, align Align) {
+	width := PageWidth - 2*Margin
+	for _, par := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		lines := d.wrap(par, size, width)
+		if len(lines) == 0 {
+			d.Space(size * lineSpacing)
+			continue
+		}
+		for _, line := range lines {
+			d.reserve(size * lineSpacing)
+			x := Margin
+			if align == Center {
+				x += (width - d.font.Width(line, size)) / 2
+			}
+			d.y += size * lineSpacing
+			// Базовая линия строки немного выше ее нижней границы.
+			base := PageHeight - d.y + size*(lineSpacing-1)
+			fmt.Fprintf(d.page(), "BT /F1 %s Tf %s %s Td <%s> Tj ET\n", num(size), num(x), num(base), d.glyphs(line))
+		}
+	}
+}
+
+// Space добавляет вертикальный отступ высотой h пунктов. Отступ
+// не переносится на следующую страницу.
+func (d *Document) Space(h float64) {
+	d.y = min(d.y+h, PageHeight-Margin)
+}
+
+// Image выводит изображение JPEG по ширине области текста, но не выше
+// maxHeight пунктов, сохраняя пропорции. Изображение встраивается без
+// перекодирования.
+func (d *Document) Image(data []byte, maxHeight float64) error {
+	info, err := parseJPEG(data)
+	if err != nil {
+		return err
+	}
+	maxHeight = min(maxHeight, PageHeight-2*Margin)
+	w := PageWidth - 2*Margin
+	h := w * float64(info.height) / float64(info.width)
+	if h > maxHeight {
+		w, h = w*maxHeight/h, maxHeight
+	}
+
+	d.reserve(h)
+	d.images = append(d.images, picture{data: data, info: info})
+	x := Margin + (PageWidth-2*Margin-w)/2
+	d.y += h
+	fmt.Fprintf(d.page(), "q %s 0 0 %s %s %s cm /Im%d Do Q\n", num(w), num(h), num(x), num(PageHeight-d.y), len(d.images))
+	return nil
+}
+
+// reserve начинает новую страницу, если на текущей не осталось места
+// для содержимого высотой h.
+func (d *Document) reserve(h float64) {
+	if len(d.pages) == 0 || d.y+h > PageHeight-Margin {
+		d.pages = append(d.pages, new(bytes.Buffer))
+		d.y = Margin
+	}
+}
+
+// page возвращает содержимое текущей страницы.
+func (d *Document) page() *bytes.Buffer {
+	d.reserve(0)
+	return d.pages[len(d.pages)-1]
+}
+
+// wrap разбивает абзац на строки не шире width. Слово, не помещающееся
+// в строку целиком, разбивается по символам.
+func (d *Document) wrap(par string, size, width float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(par) {
+		next := word
+		if line != "" {
+			next = line + " " + word
+		}
+		if d.font.Width(next, size) <= width {
+			line = next
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = ""
+		for _, r := range word {
+			if line != "" && d.font.Width(line+string(r), size) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			line += string(r)
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// glyphs кодирует строку номерами глифов для шестнадцатеричной строки
+// PDF и запоминает использованные глифы.
+func (d *Document) glyphs(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		g := d.font.Glyph(r)
+		if g != 0 {
+			d.used[g] = r
+		}
+		fmt.Fprintf(&sb, "%04X", g)
+	}
+	return sb.String()
+}
+
+// WriteTo записывает документ в w. Документ без содержимого состоит
+// из одной пустой страницы.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	d.reserve(0)
+	pw := &writer{w: bufio.NewWriter(w)}
+
+	// Номера объектов: каталог, дерево страниц, шрифт и его части,
+	// свойства документа, изображения, затем страницы с содержимым.
+	const (
+		catalog = 1 + iota
+		pages
+		font
+		cidFont
+		descriptor
+		fontFile
+		toUnicode
+		info
+		firstImage
+	)
+	firstPage := firstImage + len(d.images)
+
+	pw.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	pw.object(catalog, "<< /Type /Catalog /Pages %d 0 R >>", pages)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	pw.object(pages, "<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages))
+
+	f := d.font
+	pw.object(font, "<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, cidFont, toUnicode)
+	pw.object(cidFont, "<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /DW %d /W [%s] /CIDToGIDMap /Identity >>",
+		f.name, descriptor, f.scale(float64(f.widths[0])), d.widths())
+	pw.object(descriptor, "<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, f.scale(float64(f.bbox[0])), f.scale(float64(f.bbox[1])), f.scale(float64(f.bbox[2])), f.scale(float64(f.bbox[3])),
+		f.scale(float64(f.ascent)), f.scale(float64(f.descent)), f.scale(float64(f.capHeight)), fontFile)
+	pw.stream(fontFile, fmt.Sprintf("/Filter /FlateDecode /Length1 %d", f.size), f.data)
+	pw.stream(toUnicode, "", d.cmap())
+	pw.object(info, "<< /Title <%s> /Producer (Report-Storage) >>", textString(d.title))
+
+	xobjects := make([]string, len(d.images))
+	for i, img := range d.images {
+		xobjects[i] = fmt.Sprintf("/Im%d %d 0 R", i+1, firstImage+i)
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter /DCTDecode",
+			img.info.width, img.info.height, img.info.colorSpace())
+		pw.stream(firstImage+i, dict, img.data)
+	}
+	resources := fmt.Sprintf("<< /Font << /F1 %d 0 R >> /XObject << %s >> >>", font, strings.Join(xobjects, " "))
+
+	for i, content := range d.pages {
+		page := firstPage + 2*i
+		pw.object(page, "<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>",
+			pages, num(PageWidth), num(PageHeight), resources, page+1)
+		pw.stream(page+1, "/Filter /FlateDecode", deflate(content.Bytes()))
+	}
+
+	// Таблица перекрестных ссылок со смещениями объектов.
+	xref := pw.n
+	pw.printf("xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1)
+	for _, off := range pw.offsets {
+		pw.printf("%010d 00000 n \n", off)
+	}
+	pw.printf("trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(pw.offsets)+1, catalog, info, xref)
+
+	if pw.err == nil {
+		pw.err = pw.w.Flush()
+	}
+	return pw.n, pw.err
+}
+
+// widths возвращает массив W ширин использованных глифов.
+func (d *Document) widths() string {
+	ids := d.usedGlyphs()
+	parts := make([]string, len(ids))
+	for i, g := range ids {
+		parts[i] = fmt.Sprintf("%d [%d]", g, d.font.scale(float64(d.font.widths[g])))
+	}
+	return strings.Join(parts, " ")
+}
+
+// cmap возвращает таблицу ToUnicode, позволяющую копировать и искать
+// текст документа.
+func (d *Document) cmap() []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	ids := d.usedGlyphs()
+	// Блок bfchar содержит не более 100 записей.
+	for len(ids) > 0 {
+		n := min(len(ids), 100)
+		fmt.Fprintf(buf, "%d beginbfchar\n", n)
+		for _, g := range ids[:n] {
+			fmt.Fprintf(buf, "<%04X> <%s>\n", g, utf16Hex(string(d.used[g])))
+		}
+		buf.WriteString("endbfchar\n")
+		ids = ids[n:]
+	}
+	buf.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return buf.Bytes()
+}
+
+// usedGlyphs возвращает упорядоченные номера использованных глифов.
+func (d *Document) usedGlyphs() []uint16 {
+	ids := make([]uint16, 0, len(d.used))
+	for g := range d.used {
+		ids = append(ids, g)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// writer записывает объекты PDF и запоминает их смещения для таблицы
+// перекрестных ссылок. Первая ошибка записи сохраняется, последующие
+// записи пропускаются.
+type writer struct {
+	w       *bufio.Writer
+	n       int64
+	offsets []int64
+	err     error
+}
+
+func (pw *writer) write(b []byte) {
+	if pw.err != nil {
+		return
+	}
+	n, err := pw.w.Write(b)
+	pw.n += int64(n)
+	pw.err = err
+}
+
+func (pw *writer) printf(format string, args ...any) {
+	pw.write([]byte(fmt.Sprintf(format, args...)))
+}
+
+// begin начинает объект с номером id. Объекты записываются по порядку
+// номеров.
+func (pw *writer) begin(id int) {
+	pw.offsets = append(pw.offsets, pw.n)
+	pw.printf("%d 0 obj\n", id)
+}
+
+// object записывает объект-словарь.
+func (pw *writer) object(id int, format string, args ...any) {
+	pw.begin(id)
+	pw.printf(format, args...)
+	pw.printf("\nendobj\n")
+}
+
+// stream записывает поток с дополнительными записями словаря dict.
+func (pw *writer) stream(id int, dict string, data []byte) {
+	pw.begin(id)
+	pw.printf("<< %s /Length %d >>\nstream\n", dict, len(data))
+	pw.write(data)
+	pw.printf("\nendstream\nendobj\n")
+}
+
+// deflate сжимает данные для фильтра FlateDecode.
+func deflate(data []byte) []byte {
+	buf := new(bytes.Buffer)
+	zw := zlib.NewWriter(buf)
+	zw.Write(data)
+	zw.Close()
+	return buf.Bytes()
+}
+
+// num форматирует число для PDF с точностью до сотых без экспоненты.
+func num(v float64) string {
+	s := strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+	if s == "" || s == "-" || s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// textString кодирует строку в UTF-16BE с меткой порядка байтов для
+// шестнадцатеричной текстовой строки PDF.
+func textString(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "FEFF" + utf16Hex(s)
+}
+
+// utf16Hex кодирует строку в UTF-16BE шестнадцатеричными цифрами.
+func utf16Hex(s string) string {
+	var sb strings.Builder
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&sb, "%04X", u)
+	}
+	return sb.String()
+}
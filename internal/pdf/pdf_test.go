@@ -0,0 +1,95 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocument_WriteTo(t *testing.T) {
+	font, err := ParseFont(testFont())
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := New(font)
+	doc.SetTitle("Ж")
+	doc.Text("AЖ", 14, Center)
+	if err := doc.Image(testJPEG(t, image.NewRGBA(image.Rect(0, 0, 40, 20))), 300); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Image([]byte("not a jpeg"), 300); err == nil {
+		t.Errorf("Document.Image() error = nil for incorrect image")
+	}
+
+	buf := new(bytes.Buffer)
+	n, err := doc.WriteTo(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if int(n) != len(out) || !strings.HasPrefix(out, "%PDF-1.4\n") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatalf("Document.WriteTo() incorrect document framing")
+	}
+	for _, want := range []string{"/DCTDecode", "/Width 40 /Height 20", "/Title <FEFF0416>", "<0001> <0041>", "<0002> <0416>", "/W [1 [600] 2 [700]]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Document.WriteTo() does not contain %s", want)
+		}
+	}
+
+	// Каждая запись таблицы перекрестных ссылок указывает на начало
+	// объекта с соответствующим номером.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	if m == nil {
+		t.Fatal("startxref not found")
+	}
+	xref, _ := strconv.Atoi(m[1])
+	lines := strings.Split(out[xref:], "\n")
+	if lines[0] != "xref" {
+		t.Fatalf("startxref points to %q", lines[0])
+	}
+	count, _ := strconv.Atoi(strings.Fields(lines[1])[1])
+	for id := 1; id < count; id++ {
+		off, _ := strconv.Atoi(lines[2+id][:10])
+		if !strings.HasPrefix(out[off:], strconv.Itoa(id)+" 0 obj\n") {
+			t.Errorf("xref entry %d points to %q", id, out[off:off+10])
+		}
+	}
+}
+
+func TestDocument_Text(t *testing.T) {
+	font, err := ParseFont(testFont())
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := New(font)
+
+	// Слова переносятся, строки не выходят за область текста.
+	width := PageWidth - 2*Margin
+	lines := doc.wrap(strings.Repeat("AAAA ", 100)+strings.Repeat("Ж", 100), 12, width)
+	if len(lines) < 2 {
+		t.Fatalf("Document.wrap() = %d lines, want several", len(lines))
+	}
+	for _, l := range lines {
+		if font.Width(l, 12) > width {
+			t.Errorf("Document.wrap() line %q is wider than %v", l, width)
+		}
+	}
+
+	// Текст, не помещающийся на страницу, переносится на следующую.
+	doc.Text(strings.Repeat("A\n", 100), 12, Left)
+	if len(doc.pages) != 3 {
+		t.Errorf("Document.Text() pages = %d, want 3", len(doc.pages))
+	}
+}
+
+func Test_num(t *testing.T) {
+	tests := map[float64]string{0: "0", 1.5: "1.5", 595.28: "595.28", -0.001: "0", 12.004: "12", -3.1: "-3.1"}
+	for v, want := range tests {
+		if got := num(v); got != want {
+			t.Errorf("num(%v) = %s, want %s", v, got, want)
+		}
+	}
+}
@@ -4,25 +4,21 @@ import (
 	"Report-Storage/internal/logger"
 	"Report-Storage/internal/s3cloud"
 	"Report-Storage/internal/storage"
-	"bytes"
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/disintegration/imaging"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
-	"github.com/h2non/filetype/matchers"
 )
 
 const (
 	// jsonInputName - имя поля ввода на строне клиента.
 	jsonInputName string = "json"
-	// maxFile - максимальный размер одного файла.
+	// maxFile - максимальный размер одного фото.
 	maxFile int64 = 5 << 20
 	// maxPic - максимальная длина стороны фото.
 	maxPic int = 1800
@@ -35,14 +31,26 @@ type Request struct {
 	City        string           `json:"city" validate:"required,max=100"`
 	Address     string           `json:"address" validate:"required,max=100"`
 	Description string           `json:"description,omitempty" validate:"max=300"`
+	Category    string           `json:"category,omitempty" validate:"max=50"`
 	Contacts    storage.Contacts `json:"contacts,omitempty" validate:"omitempty"`
-	Geo         storage.Geo      `json:"geo" validate:"required"`
+	Geo         Point            `json:"geo" validate:"required"`
+	// Uploads содержит ключи файлов, загруженных клиентом напрямую
+	// в хранилище по подписанным ссылкам.
+	Uploads []string `json:"uploads,omitempty" validate:"max=5,dive,max=200"`
+}
+
+// Point - точка заявки в запросе. Порядок координат зависит от версии API
+// и передается в Build.
+type Point struct {
+	Type        string     `json:"type,omitempty" validate:"omitempty,max=100"`
+	Coordinates [2]float64 `json:"coordinates" validate:"required,dive,required"`
 }
 
 // ReportAdder - интерфейс для БД в обработчике AddReport.
 type ReportAdder interface {
-	AddReport(context.Context, storage.Report) error
+	AddReport(context.Context, storage.Report) (storage.Report, error)
 	CounterInc(context.Context) (int32, error)
+	OrganizationByID(context.Context, string) (storage.Organization, error)
 }
 
 // FileSaver - интерфейс для объектного хранилища в обработчике AddReport.
@@ -53,15 +61,21 @@ type FileSaver interface {
 
 // Build формирует структуру заявки storage.Report из multipart запроса.
 // Этот запрос должен содержать часть с именем "json", где передается
-// JSON новой заявки, и от 1 до 5 частей с любыми именами, содержащими
-// файл в формате jpeg или png. Любые другие строковые части игнорируются,
-// любые другие файлы вернут ошибку на запрос.
-// Часть json распарсивается в структуру Request, файлы перекодируются в
-// jpeg с заданным качеством и загружаются в объектное хранилище.
-// Функция возвращает структуру заявки и HTTP код как символ ошибки. Если
-// код не равен 200, то при обработке возникли ошибки, и структура заявки
-// будет пуста.
-func Build(l *slog.Logger, s3 FileSaver, r *http.Request) (storage.Report, int) {
+// JSON новой заявки, и до 5 частей с любыми именами, содержащими
+// фото в формате jpeg или png, либо короткое видео в формате mp4 или webm.
+// Вместо частей с файлами в поле uploads JSON можно передать ключи файлов,
+// заранее загруженных напрямую в хранилище, всего файлов от 1 до 5.
+// Любые другие строковые части игнорируются, любые другие файлы вернут
+// ошибку на запрос.
+// Часть json распарсивается в структуру Request, фото перекодируются в
+// jpeg с заданным качеством, для видео извлекается кадр-превью. Все файлы
+// загружаются в объектное хранилище.
+// Координаты точки заявки задаются в порядке order.
+// Функция возвращает структуру заявки, ключи файлов прямой загрузки,
+// которые нужно удалить функцией RemoveStaged после сохранения заявки,
+// и HTTP код как символ ошибки. Если код не равен 200, то при обработке
+// возникли ошибки, и структура заявки будет пуста.
+func Build(l *slog.Logger, s3 FileSaver, r *http.Request, order storage.CoordOrder) (storage.Report, []string, int) {
 	const operation = "reports.Build"
 
 	log := l.With(
@@ -70,8 +84,6 @@ func Build(l *slog.Logger, s3 FileSaver, r *http.Request) (storage.Report, int)
 
 	var req Request
 	var report storage.Report
-	var code int
-	ctx := r.Context()
 
 	// Обработка JSON.
 
@@ -84,7 +96,7 @@ func Build(l *slog.Logger, s3 FileSaver, r *http.Request) (storage.Report, int)
 			err := render.DecodeJSON(strings.NewReader(value), &req)
 			if err != nil {
 				log.Error("cannot decode request json", logger.Err(err))
-				return report, http.StatusBadRequest
+				return report, nil, http.StatusBadRequest
 			}
 		}
 	}
@@ -95,167 +107,117 @@ func Build(l *slog.Logger, s3 FileSaver, r *http.Request) (storage.Report, int)
 	if err != nil {
 		validateErr := err.(validator.ValidationErrors)
 		log.Error("validation failed", logger.Err(validateErr))
-		return report, http.StatusBadRequest
+		return report, nil, http.StatusBadRequest
 	}
 	log.Debug("json input decoded and validated successfully")
 
-	// Обработка файлов.
-
-	// Обрабатываем каждый файл в отдельной горутине. Результаты пишем
-	// в канал urls, ошибки в канал errFiles.
-	var wg sync.WaitGroup
-	urls := make(chan string, len(r.MultipartForm.File))
-	errFiles := make(chan error, len(r.MultipartForm.File))
+	// Проверяем общее количество файлов из запроса и загруженных напрямую.
+	total := FileCount(r.MultipartForm) + len(req.Uploads)
+	if total == 0 || total > storage.MaxMedia {
+		log.Error("incorrect files count", slog.Int("count", total))
+		return report, nil, http.StatusBadRequest
+	}
 
-	for _, body := range r.MultipartForm.File {
-		wg.Add(1)
+	// Обработка файлов.
+	media, code := Upload(l, s3, r)
+	if code != http.StatusOK {
+		return report, nil, code
+	}
 
-		go func() {
-			defer wg.Done()
+	// Обработка файлов, загруженных напрямую в хранилище.
+	if len(req.Uploads) > 0 {
+		stager, ok := s3.(FileStager)
+		if !ok {
+			go RemoveFiles(log, storage.MediaURLs(media), s3)
+			log.Error("file storage does not support direct uploads")
+			return report, nil, http.StatusBadRequest
+		}
+		staged, code := Promote(l, stager, s3, r, req.Uploads)
+		if code != http.StatusOK {
+			go RemoveFiles(log, storage.MediaURLs(media), s3)
+			return report, nil, code
+		}
+		media = append(media, staged...)
+	}
 
-			for _, part := range body {
+	// Формируем все поля структуры заявки кроме ID и Number. Эти поля будут
+	// заполнены значениями на других уровнях.
+	now := time.Now()
+	report.Created = now
+	report.Updated = now
+	report.City = req.City
+	report.Address = req.Address
+	report.Description = req.Description
+	report.Category = req.Category
+	report.Contacts = req.Contacts
+	report.Media = media
+	report.Geo = storage.Geo{Type: "Point", Coordinates: order.LonLat(req.Geo.Coordinates)}
+	report.Status = storage.Unverified
+	report.StatusSince = now
+	report.History = []storage.StatusChange{{Status: storage.Unverified, Changed: now}}
 
-				// Проверяем размер файла, не больше maxFile (5 Мб).
-				if part.Size > maxFile {
-					log.Error(
-						"file size is greater than maxFile value",
-						slog.String("filename", part.Filename),
-						slog.Int64("size", part.Size),
-					)
-					code = http.StatusBadRequest
-					errFiles <- errors.New("file size too large")
-					return
-				}
+	// Возвращаем валидную заявку и код 200.
+	return report, req.Uploads, http.StatusOK
+}
 
-				// Открываем файл и считываем его в буфер.
-				file, err := part.Open()
-				if err != nil {
-					log.Error(
-						"cannot open multipart file data",
-						slog.String("filename", part.Filename),
-						logger.Err(err),
-					)
-					code = http.StatusBadRequest
-					errFiles <- err
-					return
-				}
-				defer file.Close()
+// Upload обрабатывает все файлы из multipart запроса и загружает их
+// в объектное хранилище. Каждый файл обрабатывается в отдельной горутине.
+// Функция возвращает слайс медиа файлов и HTTP код как символ ошибки. Если
+// код не равен 200, то все успешно загруженные файлы будут удалены из
+// хранилища, и слайс будет пуст.
+func Upload(l *slog.Logger, s3 FileSaver, r *http.Request) ([]storage.Media, int) {
+	const operation = "reports.Upload"
 
-				buf := new(bytes.Buffer)
-				_, err = buf.ReadFrom(file)
-				if err != nil {
-					log.Error(
-						"cannot read file data to buffer",
-						slog.String("filename", part.Filename),
-						logger.Err(err),
-					)
-					code = http.StatusBadRequest
-					errFiles <- err
-					return
-				}
+	log := l.With(
+		slog.String("op", operation),
+	)
+	ctx := r.Context()
 
-				// Проверяем, что файл имеет тип jpeg или png.
-				b := buf.Bytes()
-				if !matchers.Jpeg(b) && !matchers.Png(b) {
-					log.Error("unsupported file type", slog.String("filename", part.Filename))
-					code = http.StatusUnsupportedMediaType
-					errFiles <- errors.New("unsupported file type")
-					return
-				}
+	// Обрабатываем каждый файл в отдельной горутине. Результаты пишем
+	// в канал files, коды ошибок в канал errFiles.
+	var wg sync.WaitGroup
+	n := FileCount(r.MultipartForm)
+	files := make(chan storage.Media, n)
+	errFiles := make(chan int, n)
 
-				// Декодируем файл в image.Image и определяем ориентацию фото.
-				var h, w int
-				img, err := imaging.Decode(buf)
-				if err != nil {
-					log.Error(
-						"failed to decode file to image.Image",
-						slog.String("filename", part.Filename),
-						logger.Err(err),
-					)
-					code = http.StatusInternalServerError
-					errFiles <- err
-				}
-				if img.Bounds().Dx() > img.Bounds().Dy() {
-					w = maxPic
-				} else {
-					h = maxPic
-				}
+	for _, body := range r.MultipartForm.File {
+		for _, part := range body {
+			wg.Add(1)
 
-				// Меняем размер фото на максимально допустимый, затем кодируем
-				// фото в JPEG формат с заданным качеством.
-				dstImage := imaging.Resize(img, w, h, imaging.Lanczos)
-				buf = new(bytes.Buffer)
-				opts := imaging.JPEGQuality(jpegQuality)
-				err = imaging.Encode(buf, dstImage, imaging.JPEG, opts)
-				if err != nil {
-					log.Error(
-						"failed to encode image to jpeg",
-						slog.String("filename", part.Filename),
-						logger.Err(err),
-					)
-					code = http.StatusInternalServerError
-					errFiles <- err
-				}
-				fReader := bytes.NewReader(buf.Bytes())
+			go func() {
+				defer wg.Done()
 
-				// Создаем структуру для загрузки файла в S3 хранилище и загружаем ее.
-				// Полученную ссылку на файл отправляем в канал urls.
-				input := s3cloud.UploadInput{
-					File:        fReader,
-					Name:        generateFileNameJPEG(),
-					Size:        fReader.Size(),
-					ContentType: part.Header.Get("Content-Type"),
-				}
-				url, err := s3.Upload(ctx, input)
-				if err != nil {
-					log.Error(
-						"cannot upload file to s3",
-						slog.String("filename", part.Filename),
-						logger.Err(err),
-					)
-					code = http.StatusInternalServerError
-					errFiles <- err
+				file, code := processFile(ctx, log, s3, part)
+				if code != http.StatusOK {
+					errFiles <- code
 					return
 				}
-				urls <- url
-			}
-		}()
+				files <- file
+			}()
+		}
 	}
 
 	// Ждем завершения обработки и загрузки всех файлов.
 	wg.Wait()
 
-	close(urls)
+	close(files)
 	close(errFiles)
 
-	// Вычитываем ссылки из канала в слайс.
-	var media []string
-	for v := range urls {
+	// Вычитываем медиа файлы из канала в слайс.
+	var media []storage.Media
+	for v := range files {
 		media = append(media, v)
 	}
 
 	// Если при обработке файлов возникли ошибки, то асинхронно удаляем
 	// успешно загруженные файлы из S3, так как весь запрос должен завершиться
-	// ошибкой.
+	// ошибкой. Возвращаем код первой ошибки.
 	if len(errFiles) > 0 {
-		go RemoveFiles(log, media, s3)
+		go RemoveFiles(log, storage.MediaURLs(media), s3)
 		log.Error("failed to upload some files")
-		return report, code
+		return nil, <-errFiles
 	}
 
-	// Формируем все поля структуры заявки кроме ID и Number. Эти поля будут
-	// заполнены значениями на других уровнях.
-	report.Created = time.Now()
-	report.Updated = time.Now()
-	report.City = req.City
-	report.Address = req.Address
-	report.Description = req.Description
-	report.Contacts = req.Contacts
-	report.Media = media
-	report.Geo = req.Geo
-	report.Geo.Type = "Point"
-	report.Status = storage.Unverified
-
-	// Возвращаем валидную заявку и код 200.
-	return report, http.StatusOK
+	log.Debug("files uploaded successfully", slog.Int("count", len(media)))
+	return media, http.StatusOK
 }
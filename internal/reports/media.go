@@ -0,0 +1,213 @@
+package reports
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/s3cloud"
+	"Report-Storage/internal/storage"
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"os"
+
+	"github.com/disintegration/imaging"
+	"github.com/h2non/filetype/matchers"
+)
+
+// processFile считывает файл из multipart запроса, обрабатывает его
+// в зависимости от типа и загружает в объектное хранилище. Фото
+// перекодируются в JPEG, для видео проверяется длительность и извлекается
+// кадр-превью. Возвращает медиа файл заявки и HTTP код как символ ошибки.
+func processFile(ctx context.Context, log *slog.Logger, s3 FileSaver, part *multipart.FileHeader) (storage.Media, int) {
+	var media storage.Media
+	log = log.With(slog.String("filename", part.Filename))
+
+	// Проверяем размер файла, не больше maxVideo (20 Мб). Размер фото
+	// проверяется отдельно после определения типа файла.
+	if part.Size > maxVideo {
+		log.Error("file size is greater than maxVideo value", slog.Int64("size", part.Size))
+		return media, http.StatusBadRequest
+	}
+
+	// Открываем файл и считываем его в буфер.
+	file, err := part.Open()
+	if err != nil {
+		log.Error("cannot open multipart file data", logger.Err(err))
+		return media, http.StatusBadRequest
+	}
+	defer file.Close()
+
+	buf := new(bytes.Buffer)
+	_, err = buf.ReadFrom(file)
+	if err != nil {
+		log.Error("cannot read file data to buffer", logger.Err(err))
+		return media, http.StatusBadRequest
+	}
+
+	return processBytes(ctx, log, s3, buf.Bytes())
+}
+
+// processBytes определяет тип файла по содержимому, проверяет его размер,
+// обрабатывает в зависимости от типа и загружает в объектное хранилище.
+// Возвращает медиа файл заявки и HTTP код как символ ошибки.
+func processBytes(ctx context.Context, log *slog.Logger, s3 FileSaver, b []byte) (storage.Media, int) {
+	var media storage.Media
+	size := int64(len(b))
+
+	switch {
+	case matchers.Jpeg(b) || matchers.Png(b):
+		if size > maxFile {
+			log.Error("file size is greater than maxFile value", slog.Int64("size", size))
+			return media, http.StatusBadRequest
+		}
+		return processImage(ctx, log, s3, bytes.NewBuffer(b))
+	case matchers.Mp4(b):
+		if size > maxVideo {
+			log.Error("file size is greater than maxVideo value", slog.Int64("size", size))
+			return media, http.StatusBadRequest
+		}
+		return processVideo(ctx, log, s3, b, ".mp4", "video/mp4")
+	case matchers.Webm(b):
+		if size > maxVideo {
+			log.Error("file size is greater than maxVideo value", slog.Int64("size", size))
+			return media, http.StatusBadRequest
+		}
+		return processVideo(ctx, log, s3, b, ".webm", "video/webm")
+	default:
+		log.Error("unsupported file type")
+		return media, http.StatusUnsupportedMediaType
+	}
+}
+
+// processImage перекодирует фото в JPEG и загружает его в хранилище.
+func processImage(ctx context.Context, log *slog.Logger, s3 FileSaver, buf *bytes.Buffer) (storage.Media, int) {
+	var media storage.Media
+
+	img, err := imaging.Decode(buf)
+	if err != nil {
+		log.Error("failed to decode file to image.Image", logger.Err(err))
+		return media, http.StatusBadRequest
+	}
+
+	url, err := uploadJPEG(ctx, s3, img)
+	if err != nil {
+		log.Error("cannot upload image to s3", logger.Err(err))
+		return media, http.StatusInternalServerError
+	}
+
+	media.URL = url
+	media.Kind = storage.Photo
+	return media, http.StatusOK
+}
+
+// processVideo проверяет длительность видео, загружает его в хранилище
+// и, если возможно, извлекает и загружает кадр-превью.
+func processVideo(ctx context.Context, log *slog.Logger, s3 FileSaver, b []byte, ext, contentType string) (storage.Media, int) {
+	var media storage.Media
+
+	path, err := tempVideo(b, ext)
+	if err != nil {
+		log.Error("cannot save video to temporary file", logger.Err(err))
+		return media, http.StatusInternalServerError
+	}
+	defer os.Remove(path)
+
+	// Длительность берем из метаданных контейнера, при их отсутствии
+	// пробуем определить ее с помощью ffprobe.
+	dur, err := videoDuration(b, ext)
+	if errors.Is(err, errNoDuration) {
+		dur, err = probeDuration(ctx, path)
+	}
+	if errors.Is(err, errLongVideo) {
+		log.Error("video duration is greater than maxDuration value")
+		return media, http.StatusBadRequest
+	}
+	if err != nil {
+		log.Error("cannot determine video duration", logger.Err(err))
+		return media, http.StatusBadRequest
+	}
+	if dur > maxDuration {
+		log.Error("video duration is greater than maxDuration value", slog.Duration("duration", dur))
+		return media, http.StatusBadRequest
+	}
+
+	// Загружаем видео без перекодирования.
+	fReader := bytes.NewReader(b)
+	input := s3cloud.UploadInput{
+		File:        fReader,
+		Name:        generateFileName(ext),
+		Size:        fReader.Size(),
+		ContentType: contentType,
+	}
+	url, err := s3.Upload(ctx, input)
+	if err != nil {
+		log.Error("cannot upload video to s3", logger.Err(err))
+		return media, http.StatusInternalServerError
+	}
+	media.URL = url
+	media.Kind = storage.Video
+
+	// Извлекаем кадр-превью. Отсутствие превью не является ошибкой
+	// запроса, видео остается доступным без него.
+	frame, err := posterFrame(ctx, path)
+	if err != nil {
+		log.Warn("cannot extract video poster frame", logger.Err(err))
+		return media, http.StatusOK
+	}
+	img, err := imaging.Decode(bytes.NewReader(frame))
+	if err != nil {
+		log.Warn("cannot decode video poster frame", logger.Err(err))
+		return media, http.StatusOK
+	}
+	poster, err := uploadJPEG(ctx, s3, img)
+	if err != nil {
+		log.Error("cannot upload video poster to s3", logger.Err(err))
+		go RemoveFiles(log, []string{url}, s3)
+		return storage.Media{}, http.StatusInternalServerError
+	}
+	media.Poster = poster
+
+	return media, http.StatusOK
+}
+
+// uploadJPEG меняет размер изображения на максимально допустимый, кодирует
+// его в JPEG с заданным качеством и загружает в хранилище. Возвращает
+// ссылку на загруженный файл.
+func uploadJPEG(ctx context.Context, s3 FileSaver, img image.Image) (string, error) {
+	// Определяем ориентацию изображения.
+	var h, w int
+	if img.Bounds().Dx() > img.Bounds().Dy() {
+		w = maxPic
+	} else {
+		h = maxPic
+	}
+
+	dstImage := imaging.Resize(img, w, h, imaging.Lanczos)
+	buf := new(bytes.Buffer)
+	opts := imaging.JPEGQuality(jpegQuality)
+	err := imaging.Encode(buf, dstImage, imaging.JPEG, opts)
+	if err != nil {
+		return "", err
+	}
+	fReader := bytes.NewReader(buf.Bytes())
+
+	input := s3cloud.UploadInput{
+		File:        fReader,
+		Name:        generateFileNameJPEG(),
+		Size:        fReader.Size(),
+		ContentType: "image/jpeg",
+	}
+	return s3.Upload(ctx, input)
+}
+
+// FileCount возвращает общее количество файлов в multipart форме.
+func FileCount(form *multipart.Form) int {
+	var n int
+	for _, parts := range form.File {
+		n += len(parts)
+	}
+	return n
+}
@@ -14,8 +14,8 @@ import (
 
 // generateFileName генерирует имя для файла в виде строки из
 // закодированного текущего времени, случайного числа от 1 до 9999
-// и расширения файла.
-func generateFileNameJPEG() string {
+// и расширения файла ext.
+func generateFileName(ext string) string {
 	tm := time.Now()
 	sec := uint64(tm.Unix())
 	nano := uint64(tm.Nanosecond())
@@ -29,11 +29,15 @@ func generateFileNameJPEG() string {
 		return ""
 	}
 	suff := strconv.Itoa(rand.Intn(9999))
-	ext := ".jpg"
 
 	return strings.Join([]string{name, suff, ext}, "")
 }
 
+// generateFileNameJPEG генерирует имя для файла с расширением .jpg.
+func generateFileNameJPEG() string {
+	return generateFileName(".jpg")
+}
+
 // RemoveFiles удаляет все файлы из S3 хранилища по url из переданного слайса.
 func RemoveFiles(log *slog.Logger, urls []string, s3 FileSaver) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*20)
@@ -0,0 +1,76 @@
+package reports
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	// purgeAttempts - количество попыток удаления одного файла.
+	purgeAttempts = 3
+	// PurgeTimeout - таймаут на удаление всех файлов удаленных заявок.
+	PurgeTimeout time.Duration = time.Minute
+)
+
+// purgeDelay - пауза перед повторной попыткой удаления файла, которая
+// увеличивается вдвое с каждой попыткой.
+var purgeDelay = time.Millisecond * 500
+
+// PurgeResult - структура результата удаления заявок вместе с их файлами.
+type PurgeResult struct {
+	// Reports содержит количество удаленных заявок.
+	Reports int `json:"reports"`
+	// Files содержит количество файлов, удаленных из хранилища.
+	Files int `json:"files_removed"`
+	// Failed содержит ссылки на файлы, которые не удалось удалить.
+	Failed []string `json:"files_failed"`
+}
+
+// Purge удаляет из хранилища все файлы переданных удаленных заявок.
+// Удаление каждого файла повторяется до purgeAttempts раз, все удаление
+// ограничено PurgeTimeout. Ссылки на файлы, которые не удалось удалить,
+// в том числе из-за таймаута, возвращаются в результате, позднее их
+// удалит задача сборки неиспользуемых файлов.
+func Purge(ctx context.Context, log *slog.Logger, s3 FileSaver, reports []storage.Report) PurgeResult {
+	res := PurgeResult{Reports: len(reports), Failed: []string{}}
+
+	// Файлы удаляются и после отключения клиента, документы заявок
+	// к этому моменту уже удалены.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), PurgeTimeout)
+	defer cancel()
+
+	for _, rep := range reports {
+		for _, url := range rep.Files() {
+			if err := removeWithRetry(ctx, s3, url); err != nil {
+				log.Error("failed to remove file of deleted report", slog.Int64("number", rep.Number), slog.String("url", url), logger.Err(err))
+				res.Failed = append(res.Failed, url)
+				continue
+			}
+			res.Files++
+		}
+	}
+	return res
+}
+
+// removeWithRetry удаляет файл из хранилища, повторяя попытку при ошибке.
+func removeWithRetry(ctx context.Context, s3 FileSaver, url string) error {
+	var err error
+	delay := purgeDelay
+	for i := 0; i < purgeAttempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+		if err = s3.Remove(ctx, url); err == nil {
+			return nil
+		}
+	}
+	return err
+}
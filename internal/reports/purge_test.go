@@ -0,0 +1,70 @@
+package reports
+
+import (
+	"Report-Storage/internal/s3cloud"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+// flakyFiles - хранилище файлов для тестов, которое возвращает ошибку
+// заданное количество раз для каждого файла.
+type flakyFiles struct {
+	fails   map[string]int
+	removed []string
+}
+
+func (f *flakyFiles) Upload(ctx context.Context, input s3cloud.UploadInput) (string, error) {
+	return "", nil
+}
+
+func (f *flakyFiles) Remove(ctx context.Context, url string) error {
+	if f.fails[url] > 0 {
+		f.fails[url]--
+		return errors.New("remove failed")
+	}
+	f.removed = append(f.removed, url)
+	return nil
+}
+
+func TestPurge(t *testing.T) {
+	purgeDelay = time.Millisecond
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	reps := []storage.Report{
+		{
+			Number: 1,
+			Media: []storage.Media{
+				{URL: "https://ya.ru/a.jpg", Kind: storage.Photo},
+				{URL: "https://ya.ru/b.mp4", Kind: storage.Video, Poster: "https://ya.ru/b.jpg"},
+			},
+		},
+		{
+			Number: 2,
+			Media:  []storage.Media{{URL: "https://ya.ru/c.jpg", Kind: storage.Photo}},
+		},
+	}
+	f := &flakyFiles{fails: map[string]int{
+		"https://ya.ru/a.jpg": 2,
+		"https://ya.ru/c.jpg": purgeAttempts,
+	}}
+
+	got := Purge(context.Background(), log, f, reps)
+	if got.Reports != 2 {
+		t.Errorf("Purge() reports = %d, want 2", got.Reports)
+	}
+	if got.Files != 3 {
+		t.Errorf("Purge() files = %d, want 3", got.Files)
+	}
+	if want := []string{"https://ya.ru/c.jpg"}; !slices.Equal(got.Failed, want) {
+		t.Errorf("Purge() failed = %v, want %v", got.Failed, want)
+	}
+	if want := []string{"https://ya.ru/a.jpg", "https://ya.ru/b.mp4", "https://ya.ru/b.jpg"}; !slices.Equal(f.removed, want) {
+		t.Errorf("Purge() removed = %v, want %v", f.removed, want)
+	}
+}
@@ -0,0 +1,66 @@
+package reports
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// ResolveRequest - структура запроса на закрытие заявки.
+type ResolveRequest struct {
+	Comment string `json:"comment" validate:"required,max=1000"`
+}
+
+// Resolution формирует структуру закрытия заявки storage.Resolution из
+// multipart запроса. Запрос должен содержать часть с именем "json", где
+// передается JSON с комментарием, и от 1 до 5 частей с фото или видео
+// после ремонта. Файлы проходят ту же обработку, что и в функции Build.
+// Функция возвращает структуру закрытия заявки и HTTP код как символ
+// ошибки. Если код не равен 200, то структура будет пуста.
+func Resolution(l *slog.Logger, s3 FileSaver, r *http.Request) (storage.Resolution, int) {
+	const operation = "reports.Resolution"
+
+	log := l.With(
+		slog.String("op", operation),
+	)
+
+	var req ResolveRequest
+	var res storage.Resolution
+
+	// Вычитываем JSON из запроса в структуру ResolveRequest.
+	for _, value := range r.MultipartForm.Value[jsonInputName] {
+		err := render.DecodeJSON(strings.NewReader(value), &req)
+		if err != nil {
+			log.Error("cannot decode request json", logger.Err(err))
+			return res, http.StatusBadRequest
+		}
+	}
+
+	// Валидируем поля запроса.
+	valid := validator.New()
+	err := valid.Struct(req)
+	if err != nil {
+		validateErr := err.(validator.ValidationErrors)
+		log.Error("validation failed", logger.Err(validateErr))
+		return res, http.StatusBadRequest
+	}
+	log.Debug("json input decoded and validated successfully")
+
+	// Обработка файлов.
+	media, code := Upload(l, s3, r)
+	if code != http.StatusOK {
+		return res, code
+	}
+
+	res.Comment = req.Comment
+	res.Media = media
+	res.Resolved = time.Now()
+
+	return res, http.StatusOK
+}
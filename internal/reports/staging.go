@@ -0,0 +1,140 @@
+package reports
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/s3cloud"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileStager - интерфейс для объектного хранилища с поддержкой прямой
+// загрузки файлов клиентом по подписанным ссылкам.
+type FileStager interface {
+	Presign(ctx context.Context, name, contentType string, max int64, expiry time.Duration) (s3cloud.PresignedUpload, error)
+	Download(ctx context.Context, key string, max int64) ([]byte, error)
+	RemoveStaged(ctx context.Context, key string) error
+}
+
+// stagedKey - шаблон ключа файла, загруженного клиентом напрямую.
+var stagedKey = regexp.MustCompile(`^` + s3cloud.StagingPrefix + `[0-9A-Za-z]+\.(jpg|png|mp4|webm)$`)
+
+// stagedExt - расширения файлов прямой загрузки по типу контента.
+var stagedExt = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"video/mp4":  ".mp4",
+	"video/webm": ".webm",
+}
+
+// StagedName генерирует имя файла для прямой загрузки по типу контента
+// и возвращает его вместе с максимальным размером файла этого типа. Если
+// тип контента не поддерживается, то вернет ошибку.
+func StagedName(contentType string) (name string, max int64, err error) {
+	ext, ok := stagedExt[contentType]
+	if !ok {
+		return "", 0, errors.New("unsupported content type")
+	}
+	max = maxFile
+	if strings.HasPrefix(contentType, "video/") {
+		max = maxVideo
+	}
+	return generateFileName(ext), max, nil
+}
+
+// Promote обрабатывает файлы, загруженные клиентом напрямую в хранилище
+// по ключам keys: проверяет их, перекодирует так же, как файлы из multipart
+// запроса, и загружает под постоянными именами. Повторяющиеся ключи вернут
+// код 400. Исходные файлы не удаляются, чтобы при ошибке клиент мог
+// повторить запрос, после сохранения заявки их удаляет RemoveStaged.
+// Функция возвращает слайс медиа файлов и HTTP код как символ ошибки.
+func Promote(l *slog.Logger, stager FileStager, s3 FileSaver, r *http.Request, keys []string) ([]storage.Media, int) {
+	const operation = "reports.Promote"
+
+	log := l.With(
+		slog.String("op", operation),
+	)
+	ctx := r.Context()
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if !stagedKey.MatchString(key) {
+			log.Error("incorrect staged file key", slog.String("key", key))
+			return nil, http.StatusBadRequest
+		}
+		if seen[key] {
+			log.Error("duplicate staged file key", slog.String("key", key))
+			return nil, http.StatusBadRequest
+		}
+		seen[key] = true
+	}
+
+	var wg sync.WaitGroup
+	files := make(chan storage.Media, len(keys))
+	errFiles := make(chan int, len(keys))
+
+	for _, key := range keys {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			log := log.With(slog.String("key", key))
+			b, err := stager.Download(ctx, key, maxVideo)
+			if err != nil {
+				log.Error("cannot download staged file", logger.Err(err))
+				errFiles <- http.StatusBadRequest
+				return
+			}
+
+			file, code := processBytes(ctx, log, s3, b)
+			if code != http.StatusOK {
+				errFiles <- code
+				return
+			}
+			files <- file
+		}()
+	}
+
+	// Ждем завершения обработки всех файлов.
+	wg.Wait()
+
+	close(files)
+	close(errFiles)
+
+	var media []storage.Media
+	for v := range files {
+		media = append(media, v)
+	}
+
+	// Если при обработке файлов возникли ошибки, то асинхронно удаляем
+	// успешно загруженные файлы. Возвращаем код первой ошибки.
+	if len(errFiles) > 0 {
+		go RemoveFiles(log, storage.MediaURLs(media), s3)
+		log.Error("failed to promote some staged files")
+		return nil, <-errFiles
+	}
+
+	log.Debug("staged files promoted successfully", slog.Int("count", len(media)))
+	return media, http.StatusOK
+}
+
+// RemoveStaged удаляет из хранилища файлы прямой загрузки по ключам keys
+// после того, как заявка с ними сохранена. Вызывается асинхронно, файлы,
+// которые удалить не удалось, будут удалены правилом жизненного цикла
+// хранилища.
+func RemoveStaged(log *slog.Logger, stager FileStager, keys []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*20)
+	defer cancel()
+	for _, key := range keys {
+		if err := stager.RemoveStaged(ctx, key); err != nil {
+			log.Warn("cannot remove staged file", slog.String("key", key), logger.Err(err))
+		}
+	}
+}
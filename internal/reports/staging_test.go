@@ -0,0 +1,145 @@
+package reports
+
+import (
+	"Report-Storage/internal/s3cloud"
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStagedName(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		wantMax     int64
+		wantErr     bool
+	}{
+		{
+			name:        "OK JPEG",
+			contentType: "image/jpeg",
+			wantMax:     maxFile,
+			wantErr:     false,
+		},
+		{
+			name:        "OK WebM",
+			wantMax:     maxVideo,
+			contentType: "video/webm",
+			wantErr:     false,
+		},
+		{
+			name:        "Error Unsupported type",
+			contentType: "application/pdf",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, max, err := StagedName(tt.contentType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StagedName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !stagedKey.MatchString("staging/"+got) {
+				t.Errorf("StagedName() = %s does not match staged key pattern", got)
+			}
+			if max != tt.wantMax {
+				t.Errorf("StagedName() max = %d, want %d", max, tt.wantMax)
+			}
+		})
+	}
+}
+
+// fakeStager - хранилище с файлами прямой загрузки для тестов Promote.
+type fakeStager struct {
+	mu      sync.Mutex
+	files   map[string][]byte
+	removed []string
+}
+
+func (f *fakeStager) Presign(context.Context, string, string, int64, time.Duration) (s3cloud.PresignedUpload, error) {
+	return s3cloud.PresignedUpload{}, nil
+}
+
+func (f *fakeStager) Download(_ context.Context, key string, _ int64) ([]byte, error) {
+	b, ok := f.files[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return b, nil
+}
+
+func (f *fakeStager) RemoveStaged(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.removed = append(f.removed, key)
+	return nil
+}
+
+func (f *fakeStager) Upload(_ context.Context, in s3cloud.UploadInput) (string, error) {
+	return "https://s3.local/" + in.Name, nil
+}
+
+func (f *fakeStager) Remove(context.Context, string) error {
+	return nil
+}
+
+func TestPromote(t *testing.T) {
+	var pic bytes.Buffer
+	if err := jpeg.Encode(&pic, image.NewGray(image.Rect(0, 0, 8, 8)), nil); err != nil {
+		t.Fatal(err)
+	}
+	const (
+		ok1     = "staging/abc1.jpg"
+		ok2     = "staging/abc2.jpg"
+		missing = "staging/abc3.jpg"
+	)
+
+	tests := []struct {
+		name     string
+		keys     []string
+		wantCode int
+		wantLen  int
+	}{
+		{
+			name:     "OK",
+			keys:     []string{ok1, ok2},
+			wantCode: http.StatusOK,
+			wantLen:  2,
+		},
+		{
+			name:     "Error Duplicate keys",
+			keys:     []string{ok1, ok1},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "Error One file missing",
+			keys:     []string{ok1, missing},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &fakeStager{files: map[string][]byte{ok1: pic.Bytes(), ok2: pic.Bytes()}}
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			got, code := Promote(slog.Default(), st, st, r, tt.keys)
+			if code != tt.wantCode {
+				t.Errorf("Promote() code = %v, want %v", code, tt.wantCode)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("Promote() len = %v, want %v", len(got), tt.wantLen)
+			}
+			// Исходные файлы остаются в хранилище до сохранения заявки.
+			if len(st.removed) != 0 {
+				t.Errorf("Promote() removed staged files %v", st.removed)
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxVideo - максимальный размер одного видео файла.
+	maxVideo int64 = 20 << 20
+	// maxDuration - максимальная длительность видео.
+	maxDuration time.Duration = time.Second * 30
+	// ffmpegBin и ffprobeBin - имена исполняемых файлов ffmpeg, которые
+	// используются для извлечения кадра-превью и длительности видео.
+	ffmpegBin  string = "ffmpeg"
+	ffprobeBin string = "ffprobe"
+	// tmVideo - таймаут на выполнение одной команды ffmpeg.
+	tmVideo time.Duration = time.Second * 15
+)
+
+var (
+	errNoDuration  = errors.New("video duration not found")
+	errBadFormat   = errors.New("malformed video container")
+	errNoExtractor = errors.New("ffmpeg not found")
+	errLongVideo   = errors.New("video duration is greater than maxDuration")
+)
+
+// videoDuration возвращает длительность видео в формате MP4 или WebM,
+// считанную из метаданных контейнера. Если длительность в метаданных
+// отсутствует, то вернет ошибку errNoDuration.
+func videoDuration(b []byte, ext string) (time.Duration, error) {
+	switch ext {
+	case ".mp4":
+		return mp4Duration(b)
+	case ".webm":
+		return webmDuration(b)
+	default:
+		return 0, fmt.Errorf("unsupported video extension: %s", ext)
+	}
+}
+
+// mp4Duration находит атом moov/mvhd и вычисляет длительность видео
+// по значениям timescale и duration.
+func mp4Duration(b []byte) (time.Duration, error) {
+	moov, err := mp4Box(b, "moov")
+	if err != nil {
+		return 0, err
+	}
+	mvhd, err := mp4Box(moov, "mvhd")
+	if err != nil {
+		return 0, err
+	}
+	if len(mvhd) < 4 {
+		return 0, errBadFormat
+	}
+
+	// Первый байт содержит версию атома, от нее зависит размер полей.
+	var scale, dur uint64
+	switch mvhd[0] {
+	case 0:
+		if len(mvhd) < 20 {
+			return 0, errBadFormat
+		}
+		scale = uint64(binary.BigEndian.Uint32(mvhd[12:16]))
+		dur = uint64(binary.BigEndian.Uint32(mvhd[16:20]))
+	case 1:
+		if len(mvhd) < 32 {
+			return 0, errBadFormat
+		}
+		scale = uint64(binary.BigEndian.Uint32(mvhd[20:24]))
+		dur = binary.BigEndian.Uint64(mvhd[24:32])
+	default:
+		return 0, errBadFormat
+	}
+	if scale == 0 || dur == 0 {
+		return 0, errNoDuration
+	}
+
+	return secondsDuration(float64(dur) / float64(scale))
+}
+
+// mp4Box возвращает содержимое первого атома с именем name на текущем
+// уровне вложенности.
+func mp4Box(b []byte, name string) ([]byte, error) {
+	for len(b) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(b[:4]))
+		typ := string(b[4:8])
+		header := uint64(8)
+
+		switch size {
+		case 0:
+			// Атом продолжается до конца файла.
+			size = uint64(len(b))
+		case 1:
+			// Размер атома записан в следующих 8 байтах.
+			if len(b) < 16 {
+				return nil, errBadFormat
+			}
+			size = binary.BigEndian.Uint64(b[8:16])
+			header = 16
+		}
+		if size < header || size > uint64(len(b)) {
+			return nil, errBadFormat
+		}
+
+		if typ == name {
+			return b[header:size], nil
+		}
+		b = b[size:]
+	}
+	return nil, errNoDuration
+}
+
+// Идентификаторы элементов EBML, необходимые для поиска длительности WebM.
+const (
+	ebmlSegment   uint64 = 0x18538067
+	ebmlInfo      uint64 = 0x1549A966
+	ebmlTimescale uint64 = 0x2AD7B1
+	ebmlDuration  uint64 = 0x4489
+	ebmlCluster   uint64 = 0x1F43B675
+)
+
+// webmDuration находит элементы Segment/Info/Duration и Segment/Info/TimecodeScale
+// и вычисляет длительность видео.
+func webmDuration(b []byte) (time.Duration, error) {
+	for len(b) > 0 {
+		id, size, n, err := ebmlElement(b)
+		if err != nil {
+			return 0, err
+		}
+		b = b[n:]
+
+		switch id {
+		case ebmlSegment:
+			// Спускаемся внутрь сегмента, его размер может быть неизвестен.
+			continue
+		case ebmlCluster:
+			// Блок Info всегда расположен до кластеров с данными.
+			return 0, errNoDuration
+		}
+		if size < 0 || size > int64(len(b)) {
+			return 0, errBadFormat
+		}
+		if id == ebmlInfo {
+			return webmInfoDuration(b[:size])
+		}
+		b = b[size:]
+	}
+	return 0, errNoDuration
+}
+
+// webmInfoDuration вычисляет длительность по содержимому элемента Info.
+func webmInfoDuration(b []byte) (time.Duration, error) {
+	scale := uint64(1000000)
+	var dur float64
+
+	for len(b) > 0 {
+		id, size, n, err := ebmlElement(b)
+		if err != nil {
+			return 0, err
+		}
+		b = b[n:]
+		if size < 0 || size > int64(len(b)) {
+			return 0, errBadFormat
+		}
+		data := b[:size]
+		b = b[size:]
+
+		switch id {
+		case ebmlTimescale:
+			var v uint64
+			for _, c := range data {
+				v = v<<8 | uint64(c)
+			}
+			scale = v
+		case ebmlDuration:
+			switch len(data) {
+			case 4:
+				dur = float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+			case 8:
+				dur = math.Float64frombits(binary.BigEndian.Uint64(data))
+			default:
+				return 0, errBadFormat
+			}
+		}
+	}
+	if dur == 0 || scale == 0 {
+		return 0, errNoDuration
+	}
+	// Длительность указана в единицах TimecodeScale наносекунд.
+	return secondsDuration(dur * float64(scale) / float64(time.Second))
+}
+
+// ebmlElement считывает заголовок элемента EBML. Возвращает идентификатор
+// элемента, размер его данных (-1 если размер неизвестен) и длину заголовка.
+func ebmlElement(b []byte) (uint64, int64, int, error) {
+	id, idLen, err := ebmlVint(b, false)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	size, sizeLen, err := ebmlVint(b[idLen:], true)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Все единичные биты значения означают неизвестный размер.
+	if size == 1<<(7*sizeLen)-1 {
+		return id, -1, idLen + sizeLen, nil
+	}
+	if size > math.MaxInt64 {
+		return 0, 0, 0, errBadFormat
+	}
+	return id, int64(size), idLen + sizeLen, nil
+}
+
+// ebmlVint считывает целое число переменной длины. Если mask равно true,
+// то маркер длины удаляется из значения.
+func ebmlVint(b []byte, mask bool) (uint64, int, error) {
+	if len(b) == 0 || b[0] == 0 {
+		return 0, 0, errBadFormat
+	}
+
+	length := 1
+	for m := byte(0x80); b[0]&m == 0; m >>= 1 {
+		length++
+	}
+	if len(b) < length {
+		return 0, 0, errBadFormat
+	}
+
+	v := uint64(b[0])
+	if mask {
+		v &= uint64(0xFF >> length)
+	}
+	for _, c := range b[1:length] {
+		v = v<<8 | uint64(c)
+	}
+	return v, length, nil
+}
+
+// tempVideo сохраняет видео во временный файл для обработки с помощью
+// ffmpeg. Возвращает путь к файлу, который необходимо удалить после
+// использования.
+func tempVideo(b []byte, ext string) (string, error) {
+	f, err := os.CreateTemp("", "report-*"+ext)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(b); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// probeDuration определяет длительность видео с помощью ffprobe. Используется,
+// если длительность не указана в метаданных контейнера, например, у видео,
+// записанных в браузере.
+func probeDuration(ctx context.Context, path string) (time.Duration, error) {
+	bin, err := exec.LookPath(ffprobeBin)
+	if err != nil {
+		return 0, errNoExtractor
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tmVideo)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, bin,
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		path,
+	)
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, err
+	}
+
+	sec, err := strconv.ParseFloat(strings.TrimSpace(string(out)), 64)
+	if err != nil {
+		return 0, errNoDuration
+	}
+	return secondsDuration(sec)
+}
+
+// secondsDuration переводит длительность в секундах в time.Duration.
+// Значение из метаданных может быть любым, поэтому оно сравнивается
+// с maxDuration до перевода, чтобы избежать переполнения. Для
+// бесконечного, нечислового или неположительного значения вернет ошибку
+// errBadFormat, для слишком длинного видео - errLongVideo.
+func secondsDuration(sec float64) (time.Duration, error) {
+	if math.IsNaN(sec) || math.IsInf(sec, 0) || sec <= 0 {
+		return 0, errBadFormat
+	}
+	if sec > maxDuration.Seconds() {
+		return 0, errLongVideo
+	}
+	return time.Duration(sec * float64(time.Second)), nil
+}
+
+// posterFrame извлекает первый кадр видео с помощью ffmpeg и возвращает
+// его в формате JPEG.
+func posterFrame(ctx context.Context, path string) ([]byte, error) {
+	bin, err := exec.LookPath(ffmpegBin)
+	if err != nil {
+		return nil, errNoExtractor
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tmVideo)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, bin,
+		"-v", "error",
+		"-i", path,
+		"-frames:v", "1",
+		"-f", "image2",
+		"-c:v", "mjpeg",
+		"pipe:1",
+	)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	if out.Len() == 0 {
+		return nil, errors.New("empty poster frame")
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,141 @@
+package reports
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+)
+
+// mp4Atom формирует атом MP4 с заданным именем и содержимым.
+func mp4Atom(name string, data []byte) []byte {
+	b := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint32(b, uint32(8+len(data)))
+	copy(b[4:], name)
+	return append(b, data...)
+}
+
+// testMP4 формирует минимальный MP4 с атомом mvhd версии 0.
+func testMP4(scale, dur uint32) []byte {
+	mvhd := make([]byte, 20)
+	binary.BigEndian.PutUint32(mvhd[12:], scale)
+	binary.BigEndian.PutUint32(mvhd[16:], dur)
+
+	b := mp4Atom("ftyp", []byte("isom\x00\x00\x02\x00"))
+	b = append(b, mp4Atom("free", nil)...)
+	return append(b, mp4Atom("moov", mp4Atom("mvhd", mvhd))...)
+}
+
+// testMP4v1 формирует минимальный MP4 с атомом mvhd версии 1.
+func testMP4v1(scale uint32, dur uint64) []byte {
+	mvhd := make([]byte, 32)
+	mvhd[0] = 1
+	binary.BigEndian.PutUint32(mvhd[20:], scale)
+	binary.BigEndian.PutUint64(mvhd[24:], dur)
+	return mp4Atom("moov", mp4Atom("mvhd", mvhd))
+}
+
+// testWebM формирует минимальный WebM с сегментом неизвестного размера
+// и длительностью dur миллисекунд.
+func testWebM(dur float64) []byte {
+	b := []byte{0x1A, 0x45, 0xDF, 0xA3, 0x84, 'w', 'e', 'b', 'm'}
+	b = append(b, 0x18, 0x53, 0x80, 0x67, 0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF)
+
+	info := []byte{0x2A, 0xD7, 0xB1, 0x83, 0x0F, 0x42, 0x40}
+	d := make([]byte, 8)
+	binary.BigEndian.PutUint64(d, math.Float64bits(dur))
+	info = append(info, 0x44, 0x89, 0x88)
+	info = append(info, d...)
+
+	b = append(b, 0x15, 0x49, 0xA9, 0x66, 0x80|byte(len(info)))
+	return append(b, info...)
+}
+
+func Test_videoDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       []byte
+		ext     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			name: "OK MP4",
+			b:    testMP4(1000, 12500),
+			ext:  ".mp4",
+			want: time.Millisecond * 12500,
+		},
+		{
+			name: "OK WebM",
+			b:    testWebM(8000),
+			ext:  ".webm",
+			want: time.Second * 8,
+		},
+		{
+			name: "OK MP4 version 1",
+			b:    testMP4v1(1000, 5000),
+			ext:  ".mp4",
+			want: time.Second * 5,
+		},
+		{
+			name:    "Error Oversized MP4 duration",
+			b:       testMP4v1(1, math.MaxUint64),
+			ext:     ".mp4",
+			wantErr: true,
+		},
+		{
+			name:    "Error Long MP4",
+			b:       testMP4(1000, 31000),
+			ext:     ".mp4",
+			wantErr: true,
+		},
+		{
+			name:    "Error Infinite WebM duration",
+			b:       testWebM(math.Inf(1)),
+			ext:     ".webm",
+			wantErr: true,
+		},
+		{
+			name:    "Error NaN WebM duration",
+			b:       testWebM(math.NaN()),
+			ext:     ".webm",
+			wantErr: true,
+		},
+		{
+			name:    "Error Negative WebM duration",
+			b:       testWebM(-8000),
+			ext:     ".webm",
+			wantErr: true,
+		},
+		{
+			name:    "Error MP4 without moov",
+			b:       mp4Atom("ftyp", []byte("isom")),
+			ext:     ".mp4",
+			wantErr: true,
+		},
+		{
+			name:    "Error Truncated WebM",
+			b:       testWebM(8000)[:20],
+			ext:     ".webm",
+			wantErr: true,
+		},
+		{
+			name:    "Error Unsupported extension",
+			b:       testMP4(1000, 1000),
+			ext:     ".avi",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := videoDuration(tt.b, tt.ext)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("videoDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("videoDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,16 +1,31 @@
 package s3cloud
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"strings"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
+const (
+	// StagingPrefix - префикс имен файлов, загружаемых клиентом напрямую
+	// в хранилище до создания заявки.
+	StagingPrefix = "staging/"
+	// stagingRule - идентификатор правила жизненного цикла для удаления
+	// неиспользованных файлов с префиксом StagingPrefix.
+	stagingRule = "staging-expiry"
+)
+
+// ErrTooLarge - ошибка превышения допустимого размера файла.
+var ErrTooLarge = errors.New("file size too large")
+
 // FileStorage - структура клиента S3 хранилища.
 type FileStorage struct {
 	storage  *minio.Client
@@ -27,14 +42,44 @@ type UploadInput struct {
 	ContentType string
 }
 
-// New - конструктор клиента S3 хранилища.
-func New(endpoint, bucket, accessKey, secretKey, domain string) *FileStorage {
+// Object - структура файла, хранящегося в хранилище.
+type Object struct {
+	Name     string
+	Modified time.Time
+}
+
+// PresignedUpload - структура подписанной формы для прямой загрузки
+// файла в хранилище. Файл отправляется методом POST на URL в поле file
+// формы multipart/form-data вместе со всеми полями Fields.
+type PresignedUpload struct {
+	Key     string            `json:"key"`
+	URL     string            `json:"url"`
+	Fields  map[string]string `json:"fields"`
+	Expires time.Time         `json:"expires"`
+}
+
+// New - конструктор клиента S3 хранилища. Параметр insecure отключает
+// подключение по HTTPS, pathStyle включает адресацию бакета в пути запроса.
+// Оба параметра необходимы для работы с локальным MinIO.
+func New(endpoint, bucket, accessKey, secretKey, domain string, insecure, pathStyle bool) (*FileStorage, error) {
+	const operation = "s3cloud.New"
+
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("%s: %w", operation, errors.New("empty S3 credentials"))
+	}
+
+	lookup := minio.BucketLookupAuto
+	if pathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
 	s3, err := minio.New(endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
-		Secure: true,
+		Creds:        credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure:       !insecure,
+		BucketLookup: lookup,
 	})
 	if err != nil {
-		log.Fatalf("failed to create S3 storage client: %s", err.Error())
+		return nil, fmt.Errorf("%s: %w", operation, err)
 	}
 
 	fs := &FileStorage{
@@ -43,7 +88,7 @@ func New(endpoint, bucket, accessKey, secretKey, domain string) *FileStorage {
 		bucket:   bucket,
 		domain:   domain,
 	}
-	return fs
+	return fs, nil
 }
 
 // Upload загружает файл в хранилище. Возвращает url загруженного файла,
@@ -79,3 +124,137 @@ func (fs *FileStorage) Remove(ctx context.Context, url string) error {
 	}
 	return nil
 }
+
+// List возвращает все файлы бакета, кроме файлов прямой загрузки
+// с префиксом StagingPrefix, которые удаляются правилом жизненного цикла.
+func (fs *FileStorage) List(ctx context.Context) ([]Object, error) {
+	const operation = "s3cloud.List"
+
+	var objects []Object
+	opts := minio.ListObjectsOptions{Recursive: true}
+	for obj := range fs.storage.ListObjects(ctx, fs.bucket, opts) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("%s: %w", operation, obj.Err)
+		}
+		if strings.HasPrefix(obj.Key, StagingPrefix) {
+			continue
+		}
+		objects = append(objects, Object{Name: obj.Key, Modified: obj.LastModified})
+	}
+	return objects, nil
+}
+
+// Presign формирует подписанную форму для загрузки файла с именем name
+// методом POST напрямую в хранилище. Политика формы разрешает загрузку
+// только под этим именем с префиксом StagingPrefix, с типом контента
+// contentType и размером от 1 до max байт. Форма действительна в течение
+// expiry.
+func (fs *FileStorage) Presign(ctx context.Context, name, contentType string, max int64, expiry time.Duration) (PresignedUpload, error) {
+	const operation = "s3cloud.Presign"
+
+	var upload PresignedUpload
+	key := StagingPrefix + name
+	expires := time.Now().Add(expiry)
+
+	policy := minio.NewPostPolicy()
+	for _, err := range []error{
+		policy.SetBucket(fs.bucket),
+		policy.SetKey(key),
+		policy.SetContentType(contentType),
+		policy.SetContentLengthRange(1, max),
+		policy.SetExpires(expires),
+	} {
+		if err != nil {
+			return upload, fmt.Errorf("%s: %w", operation, err)
+		}
+	}
+
+	u, fields, err := fs.storage.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return upload, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	upload.Key = key
+	upload.URL = u.String()
+	upload.Fields = fields
+	upload.Expires = expires
+	return upload, nil
+}
+
+// Download считывает файл с ключом key из хранилища. Если размер файла
+// больше max, то вернет ошибку ErrTooLarge.
+func (fs *FileStorage) Download(ctx context.Context, key string, max int64) ([]byte, error) {
+	const operation = "s3cloud.Download"
+
+	info, err := fs.storage.StatObject(ctx, fs.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if info.Size > max {
+		return nil, fmt.Errorf("%s: %w", operation, ErrTooLarge)
+	}
+
+	obj, err := fs.storage.GetObject(ctx, fs.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	defer obj.Close()
+
+	// Ограничиваем чтение на случай, если файл был заменен после проверки.
+	buf := new(bytes.Buffer)
+	_, err = buf.ReadFrom(io.LimitReader(obj, max+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if int64(buf.Len()) > max {
+		return nil, fmt.Errorf("%s: %w", operation, ErrTooLarge)
+	}
+	return buf.Bytes(), nil
+}
+
+// RemoveStaged удаляет файл с ключом key из хранилища.
+func (fs *FileStorage) RemoveStaged(ctx context.Context, key string) error {
+	const operation = "s3cloud.RemoveStaged"
+
+	opts := minio.RemoveObjectOptions{GovernanceBypass: true}
+	err := fs.storage.RemoveObject(ctx, fs.bucket, key, opts)
+	if err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	return nil
+}
+
+// ExpireStaging устанавливает правило жизненного цикла бакета, по которому
+// файлы с префиксом StagingPrefix автоматически удаляются через days дней.
+// Остальные правила бакета сохраняются.
+func (fs *FileStorage) ExpireStaging(ctx context.Context, days int) error {
+	const operation = "s3cloud.ExpireStaging"
+
+	cfg, err := fs.storage.GetBucketLifecycle(ctx, fs.bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code != "NoSuchLifecycleConfiguration" {
+			return fmt.Errorf("%s: %w", operation, err)
+		}
+		cfg = lifecycle.NewConfiguration()
+	}
+
+	// Заменяем правило для временных файлов, если оно уже существует.
+	rules := cfg.Rules[:0]
+	for _, rule := range cfg.Rules {
+		if rule.ID != stagingRule {
+			rules = append(rules, rule)
+		}
+	}
+	cfg.Rules = append(rules, lifecycle.Rule{
+		ID:         stagingRule,
+		Status:     "Enabled",
+		RuleFilter: lifecycle.Filter{Prefix: StagingPrefix},
+		Expiration: lifecycle.Expiration{Days: lifecycle.ExpirationDays(days)},
+	})
+
+	err = fs.storage.SetBucketLifecycle(ctx, fs.bucket, cfg)
+	if err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// JurisdictionAdder - интерфейс для добавления территории.
+type JurisdictionAdder interface {
+	AddJurisdiction(ctx context.Context, jur storage.Jurisdiction) (storage.Jurisdiction, error)
+}
+
+// AddJurisdiction обрабатывает запрос на добавление территории. Границы
+// территории передаются в поле area как геометрия GeoJSON типа Polygon
+// или MultiPolygon. Новые заявки в этих границах назначаются организации
+// из поля org. Возвращает созданную территорию.
+func AddJurisdiction(l *slog.Logger, st JurisdictionAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.AddJurisdiction"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to add jurisdiction")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Декодируем тело запроса в структуру.
+		var jur storage.Jurisdiction
+		if err := render.DecodeJSON(r.Body, &jur); err != nil {
+			log.Error("failed to decode JSON", logger.Err(err))
+			http.Error(w, "invalid jurisdiction data", http.StatusBadRequest)
+			return
+		}
+
+		// Валидируем поля запроса.
+		valid := validator.New()
+		err := valid.Struct(jur)
+		if err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("validation failed", logger.Err(validateErr))
+			http.Error(w, "invalid jurisdiction data", http.StatusBadRequest)
+			return
+		}
+		if err := jur.Area.Validate(); err != nil {
+			log.Error("invalid jurisdiction area", logger.Err(err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Запрос в базу данных.
+		jur, err = st.AddJurisdiction(r.Context(), jur)
+		if err != nil {
+			log.Error("cannot add jurisdiction", logger.Err(err))
+			if errors.Is(err, storage.ErrIncorrectArea) {
+				http.Error(w, "invalid jurisdiction area", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		w.WriteHeader(http.StatusCreated)
+		err = json.NewEncoder(w).Encode(jur)
+		if err != nil {
+			log.Error("cannot encode jurisdiction", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("jurisdiction added successfully", slog.String("id", jur.ID.Hex()))
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/reports"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// MediaAdder - интерфейс для добавления медиа файлов в заявку.
+type MediaAdder interface {
+	ReportByNum(ctx context.Context, num int) (storage.Report, error)
+	AddMedia(ctx context.Context, num int, media []storage.Media) (storage.Report, error)
+}
+
+// AddMedia обрабатывает запрос на добавление медиа файлов в существующую
+// заявку. Запрос должен быть multipart формой с файлами, которые проходят
+// ту же обработку, что и при создании заявки. Общее количество файлов в
+// заявке не может превышать storage.MaxMedia.
+func AddMedia(l *slog.Logger, st MediaAdder, s3 reports.FileSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.AddMedia"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to add report media")
+
+		// Получение параметров запроса.
+		num, err := number(r)
+		if err != nil {
+			log.Error("invalid report number", logger.Err(err))
+			http.Error(w, "invalid report number", http.StatusBadRequest)
+			return
+		}
+
+		// Проверка заголовка Content-Type и разбор multipart формы.
+		if err := parseMultipart(w, r); err != nil {
+			log.Error("cannot parse multipart form", logger.Err(err))
+			if errors.Is(err, errNotMultipart) {
+				http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+				return
+			}
+			http.Error(w, "incorrect media data", http.StatusBadRequest)
+			return
+		}
+		defer func() {
+			err := r.MultipartForm.RemoveAll()
+			if err != nil {
+				log.Error("cannot remove temporary multipart form files", logger.Err(err))
+			}
+		}()
+
+		// Предварительная проверка существования заявки и количества файлов,
+		// чтобы не загружать файлы в хранилище напрасно. Окончательная
+		// проверка лимита происходит атомарно в БД.
+		report, err := st.ReportByNum(r.Context(), num)
+		if err != nil {
+			log.Error("cannot find report", logger.Err(err))
+			if errors.Is(err, storage.ErrReportNotFound) {
+				http.Error(w, "report not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		n := reports.FileCount(r.MultipartForm)
+		if n == 0 || len(report.Media)+n > storage.MaxMedia {
+			log.Error("incorrect files count", slog.Int("count", n))
+			http.Error(w, "media files limit exceeded", http.StatusConflict)
+			return
+		}
+
+		// Обработка и загрузка файлов.
+		media, code := reports.Upload(l, s3, r)
+		switch code {
+		case http.StatusBadRequest:
+			http.Error(w, "incorrect media data", http.StatusBadRequest)
+			return
+		case http.StatusInternalServerError:
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		case http.StatusUnsupportedMediaType:
+			http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+			return
+		}
+
+		// Принудительный возврат аллоцированной памяти системе.
+		defer debug.FreeOSMemory()
+
+		// Добавление файлов в заявку. В случае ошибки удаляем загруженные
+		// файлы из S3 хранилища.
+		report, err = st.AddMedia(r.Context(), num, media)
+		if err != nil {
+			go reports.RemoveFiles(l, storage.MediaURLs(media), s3)
+			log.Error("cannot add media to report", logger.Err(err))
+			if errors.Is(err, storage.ErrReportNotFound) {
+				http.Error(w, "report not found", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, storage.ErrMediaLimit) {
+				http.Error(w, "media files limit exceeded", http.StatusConflict)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		w.Header().Set("Content-Type", "application/json")
+		err = encodeReport(w, r, report)
+		if err != nil {
+			log.Error("cannot encode report", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("report media added successfully")
+	}
+}
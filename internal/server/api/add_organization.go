@@ -0,0 +1,69 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// OrganizationAdder - интерфейс для добавления организации.
+type OrganizationAdder interface {
+	AddOrganization(ctx context.Context, org storage.Organization) (storage.Organization, error)
+}
+
+// AddOrganization обрабатывает запрос на добавление организации вместе
+// со списком ее сотрудников. Возвращает созданную организацию.
+func AddOrganization(l *slog.Logger, st OrganizationAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.AddOrganization"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to add organization")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Декодируем тело запроса в структуру.
+		var org storage.Organization
+		if err := render.DecodeJSON(r.Body, &org); err != nil {
+			log.Error("failed to decode JSON", logger.Err(err))
+			http.Error(w, "invalid organization data", http.StatusBadRequest)
+			return
+		}
+
+		// Валидируем поля запроса.
+		valid := validator.New()
+		err := valid.Struct(org)
+		if err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("validation failed", logger.Err(validateErr))
+			http.Error(w, "invalid organization data", http.StatusBadRequest)
+			return
+		}
+
+		// Запрос в базу данных.
+		org, err = st.AddOrganization(r.Context(), org)
+		if err != nil {
+			log.Error("cannot add organization", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		w.WriteHeader(http.StatusCreated)
+		err = json.NewEncoder(w).Encode(org)
+		if err != nil {
+			log.Error("cannot encode organization", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("organization added successfully", slog.String("id", org.ID.Hex()))
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"Report-Storage/internal/logger"
 	"Report-Storage/internal/notifications"
 	"Report-Storage/internal/reports"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"runtime/debug"
 	"strconv"
-	"strings"
 
 	"github.com/go-chi/render"
 )
@@ -28,17 +30,16 @@ func AddReport(l *slog.Logger, st reports.ReportAdder, s3 reports.FileSaver, not
 		log := logger.Handler(l, operation, r)
 		log.Info("request to add new report")
 
-		// Проверка заголовка Content-Type на значение multipart/form-data.
-		ct := strings.ToLower(r.Header.Get("Content-Type"))
-		if !strings.Contains(ct, "multipart/form-data") {
-			log.Error("content-type is not multipart/form-data", slog.String("Content-Type", ct))
-			http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+		// Проверка заголовка Content-Type и разбор multipart формы.
+		if err := parseMultipart(w, r); err != nil {
+			log.Error("cannot parse multipart form", logger.Err(err))
+			if errors.Is(err, errNotMultipart) {
+				http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+				return
+			}
+			http.Error(w, "incorrect report data", http.StatusBadRequest)
 			return
 		}
-
-		// Суммарный размер всех загружаемых файлов не более 30 Мб.
-		r.Body = http.MaxBytesReader(w, r.Body, maxMemory)
-		r.ParseMultipartForm(maxMemory + 512)
 		defer func() {
 			err := r.MultipartForm.RemoveAll()
 			if err != nil {
@@ -52,7 +53,7 @@ func AddReport(l *slog.Logger, st reports.ReportAdder, s3 reports.FileSaver, not
 			http.Error(w, "incorrect report data", http.StatusBadRequest)
 			return
 		}
-		if len(r.MultipartForm.File) == 0 || len(r.MultipartForm.File) > 5 {
+		if n := reports.FileCount(r.MultipartForm); n > storage.MaxMedia {
 			log.Error("incorrect files count")
 			http.Error(w, "incorrect report data", http.StatusBadRequest)
 			return
@@ -60,7 +61,7 @@ func AddReport(l *slog.Logger, st reports.ReportAdder, s3 reports.FileSaver, not
 
 		// Получение сформированной структуры заявки и кода. Если code
 		// не равно 200, то возвращаем ошибку.
-		report, code := reports.Build(l, s3, r)
+		report, staged, code := reports.Build(l, s3, r, coordOrder(r))
 		switch code {
 		case http.StatusBadRequest:
 			http.Error(w, "incorrect report data", http.StatusBadRequest)
@@ -88,24 +89,31 @@ func AddReport(l *slog.Logger, st reports.ReportAdder, s3 reports.FileSaver, not
 		// ошибки удаляем загруженные файлы из S3 хранилища.
 		newNum, err := st.CounterInc(ctx)
 		if err != nil {
-			go reports.RemoveFiles(l, report.Media, s3)
+			go reports.RemoveFiles(l, storage.MediaURLs(report.Media), s3)
 			log.Error("cannot receive new ID", logger.Err(err))
 			http.Error(w, "internal error", http.StatusInternalServerError)
 			return
 		}
 		report.Number = int64(newNum)
 
-		// Добавление сформированной заявки в БД. В случае ошибки удаляем
-		// загруженные файлы из S3 хранилища.
-		err = st.AddReport(ctx, report)
+		// Добавление сформированной заявки в БД. При добавлении заявке
+		// назначаются территория и ответственная организация. В случае
+		// ошибки удаляем загруженные файлы из S3 хранилища.
+		report, err = st.AddReport(ctx, report)
 		if err != nil {
-			go reports.RemoveFiles(l, report.Media, s3)
+			go reports.RemoveFiles(l, storage.MediaURLs(report.Media), s3)
 			log.Error("cannot add report to DB", logger.Err(err))
 			http.Error(w, "internal error", http.StatusInternalServerError)
 			return
 		}
 		log.Debug("new report added successfully")
 
+		// Файлы прямой загрузки удаляются только после сохранения заявки,
+		// чтобы при ошибке клиент мог повторить запрос с теми же ключами.
+		if stager, ok := s3.(reports.FileStager); ok && len(staged) > 0 {
+			go reports.RemoveStaged(log, stager, staged)
+		}
+
 		// Отправка уведомления о создании новой заявки.
 		if report.Contacts.Email != "" {
 			go func() {
@@ -116,6 +124,24 @@ func AddReport(l *slog.Logger, st reports.ReportAdder, s3 reports.FileSaver, not
 			}()
 		}
 
+		// Отправка уведомления организации, ответственной за территорию.
+		if report.AssigneeOrg != nil {
+			go func() {
+				org, err := st.OrganizationByID(context.WithoutCancel(ctx), report.AssigneeOrg.Hex())
+				if err != nil {
+					log.Error("cannot receive organization for notification", logger.Err(err))
+					return
+				}
+				if org.Email == "" {
+					return
+				}
+				err = notifications.Assigned(notify, org.Email, report.Number, report.Address)
+				if err != nil {
+					log.Error("failed to send notification to email", logger.Err(err))
+				}
+			}()
+		}
+
 		// Запись ответа в text/plain и установка кода 201.
 		render.Status(r, http.StatusCreated)
 		render.PlainText(w, r, strconv.Itoa(int(report.Number)))
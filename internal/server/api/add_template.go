@@ -0,0 +1,76 @@
+package api
+
+import (
+	"Report-Storage/internal/letters"
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// TemplateAdder - интерфейс для добавления шаблона письма.
+type TemplateAdder interface {
+	AddTemplate(ctx context.Context, tpl storage.Template) (storage.Template, error)
+}
+
+// AddTemplate обрабатывает запрос на добавление шаблона письма. Шаблон
+// с синтаксической ошибкой или неизвестным полем данных не сохраняется,
+// текст ошибки возвращается с кодом 400. Возвращает созданный шаблон.
+func AddTemplate(l *slog.Logger, st TemplateAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.AddTemplate"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to add letter template")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Декодируем тело запроса в структуру.
+		var tpl storage.Template
+		if err := render.DecodeJSON(r.Body, &tpl); err != nil {
+			log.Error("failed to decode JSON", logger.Err(err))
+			http.Error(w, "invalid template data", http.StatusBadRequest)
+			return
+		}
+
+		// Валидируем поля запроса и сам шаблон.
+		valid := validator.New()
+		err := valid.Struct(tpl)
+		if err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("validation failed", logger.Err(validateErr))
+			http.Error(w, "invalid template data", http.StatusBadRequest)
+			return
+		}
+		if err := letters.Check(tpl); err != nil {
+			log.Error("invalid template", logger.Err(err))
+			http.Error(w, "invalid template: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Запрос в базу данных.
+		tpl, err = st.AddTemplate(r.Context(), tpl)
+		if err != nil {
+			log.Error("cannot add letter template", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		w.WriteHeader(http.StatusCreated)
+		err = json.NewEncoder(w).Encode(tpl)
+		if err != nil {
+			log.Error("cannot encode letter template", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("letter template added successfully", slog.String("id", tpl.ID.Hex()))
+	}
+}
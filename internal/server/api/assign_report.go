@@ -0,0 +1,102 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/notifications"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// ReportAssigner - интерфейс для назначения заявки организации и ее
+// сотруднику.
+type ReportAssigner interface {
+	Assign(ctx context.Context, num int, orgID, user string) (storage.Report, error)
+	OrganizationByID(ctx context.Context, id string) (storage.Organization, error)
+}
+
+// assignment - тело запроса на назначение заявки.
+type assignment struct {
+	Org  string `json:"org"`
+	User string `json:"user"`
+}
+
+// AssignReport обрабатывает запрос на назначение заявки ответственной
+// организации и, если указан, исполнителю из ее сотрудников. Исполнителю,
+// а если он не указан, то организации, отправляется уведомление.
+func AssignReport(l *slog.Logger, st ReportAssigner, notify *notifications.SMTP) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.AssignReport"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to assign report")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		num, err := number(r)
+		if err != nil {
+			log.Error("invalid report number", logger.Err(err))
+			http.Error(w, "invalid report number", http.StatusBadRequest)
+			return
+		}
+		var a assignment
+		if err := render.DecodeJSON(r.Body, &a); err != nil {
+			log.Error("failed to decode JSON", logger.Err(err))
+			http.Error(w, "invalid assignment data", http.StatusBadRequest)
+			return
+		}
+
+		// Запрос в базу данных.
+		report, err := st.Assign(r.Context(), num, a.Org, a.User)
+		if err != nil {
+			log.Error("cannot assign report", logger.Err(err))
+			switch {
+			case errors.Is(err, storage.ErrReportNotFound):
+				http.Error(w, "report not found", http.StatusNotFound)
+			case errors.Is(err, storage.ErrOrgNotFound):
+				http.Error(w, "organization not found", http.StatusNotFound)
+			case errors.Is(err, storage.ErrIncorrectID), errors.Is(err, storage.ErrIncorrectUser):
+				http.Error(w, "invalid assignment data", http.StatusBadRequest)
+			default:
+				http.Error(w, "internal error", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		// Отправка уведомления о назначении заявки.
+		go func() {
+			org, err := st.OrganizationByID(context.WithoutCancel(r.Context()), a.Org)
+			if err != nil {
+				log.Error("cannot receive organization for notification", logger.Err(err))
+				return
+			}
+			target := org.Email
+			if m, ok := org.Member(a.User); ok && m.Email != "" {
+				target = m.Email
+			}
+			if target == "" {
+				return
+			}
+			err = notifications.Assigned(notify, target, report.Number, report.Address)
+			if err != nil {
+				log.Error("failed to send notification to email", logger.Err(err))
+			}
+		}()
+
+		// Кодирование ответа в JSON.
+		err = encodeReport(w, r, report)
+		if err != nil {
+			log.Error("cannot encode report", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("report assigned successfully")
+	}
+}
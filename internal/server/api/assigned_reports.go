@@ -0,0 +1,62 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// AssignedRetriever - интерфейс для получения заявок, назначенных
+// пользователю.
+type AssignedRetriever interface {
+	AssignedReports(ctx context.Context, user string, status []storage.Status) ([]storage.Report, error)
+}
+
+// AssignedReports обрабатывает запрос сотрудника на получение назначенных
+// ему заявок. Пользователь определяется по JWT токену, заявки можно
+// отфильтровать по статусам из query параметра status.
+func AssignedReports(l *slog.Logger, st AssignedRetriever) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.AssignedReports"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive assigned reports")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		user := subject(r)
+		if user == "" {
+			log.Error("empty token subject")
+			http.Error(w, "unknown user", http.StatusForbidden)
+			return
+		}
+		status := splitStatus(r.URL.Query().Get("status"))
+
+		// Запрос в базу данных.
+		reports, err := st.AssignedReports(r.Context(), user, status)
+		if err != nil {
+			log.Error("cannot receive assigned reports", logger.Err(err))
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no reports found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = encodeReports(w, r, reports)
+		if err != nil {
+			log.Error("cannot encode reports to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("assigned reports encoded and sent successfully")
+	}
+}
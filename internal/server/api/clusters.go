@@ -0,0 +1,69 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// ClustersInterface - интерфейс для получения кластеров заявок.
+type ClustersInterface interface {
+	Clusters(ctx context.Context, box storage.BBox, zoom int, status []storage.Status) ([]storage.Cluster, error)
+}
+
+// Clusters обрабатывает запрос на получение кластеров заявок в границах
+// области карты из query параметра bbox для уровня масштаба zoom.
+// Каждый кластер содержит центр масс заявок и их количество по статусам.
+func Clusters(l *slog.Logger, st ClustersInterface) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Clusters"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive report clusters")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		box, err := bbox(r)
+		if err != nil {
+			log.Error("failed to get correct bbox", logger.Err(err))
+			http.Error(w, "invalid bbox parameter", http.StatusBadRequest)
+			return
+		}
+		zoom, err := strconv.Atoi(r.URL.Query().Get("zoom"))
+		if err != nil || zoom < 0 || zoom > storage.MaxZoom {
+			log.Error("failed to get correct zoom", slog.String("zoom", r.URL.Query().Get("zoom")))
+			http.Error(w, "invalid zoom parameter", http.StatusBadRequest)
+			return
+		}
+		status := splitStatus(r.URL.Query().Get("status"))
+
+		// Запрос в базу данных.
+		clusters, err := st.Clusters(r.Context(), box, zoom, status)
+		if err != nil {
+			log.Error("failed to get report clusters", logger.Err(err))
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no reports found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = json.NewEncoder(w).Encode(presentClusters(r, clusters))
+		if err != nil {
+			log.Error("cannot encode clusters to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("report clusters encoded and sent successfully", slog.Int("count", len(clusters)))
+	}
+}
@@ -2,21 +2,25 @@ package api
 
 import (
 	"Report-Storage/internal/logger"
+	"Report-Storage/internal/reports"
 	"Report-Storage/internal/storage"
 	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"log/slog"
 	"net/http"
 )
 
 // RejectRemover - интерфейс для удаления отклоненных заявок.
 type RejectRemover interface {
-	DeleteRejected(ctx context.Context) (int, error)
+	DeleteRejected(ctx context.Context) ([]storage.Report, error)
 }
 
 // DeleteRejected обрабатывает запрос на удаление всех отклоненных заявок.
-func DeleteRejected(l *slog.Logger, st RejectRemover) http.HandlerFunc {
+// Заявки в корзине не удаляются до истечения срока хранения. Медиа файлы
+// удаленных заявок удаляются из хранилища, в ответе возвращается количество
+// удаленных заявок и файлов и ссылки на файлы, которые удалить не удалось.
+func DeleteRejected(l *slog.Logger, st RejectRemover, s3 reports.FileSaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const operation = "server.api.DeleteRejected"
 
@@ -25,29 +29,38 @@ func DeleteRejected(l *slog.Logger, st RejectRemover) http.HandlerFunc {
 		log.Info("request to delete rejected reports")
 
 		// Установка типа контента для ответа.
-		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Type", "application/json")
+
+		// Продление срока записи ответа на время удаления файлов.
+		extendDeadline(w, log, tmPurge)
 
 		// Запрос в базу данных.
-		count, err := st.DeleteRejected(r.Context())
+		deleted, err := st.DeleteRejected(r.Context())
 		if err != nil {
 			log.Error("cannot delete rejected reports", logger.Err(err))
 			if errors.Is(err, storage.ErrReportNotFound) {
 				http.Error(w, "no rejected reports found", http.StatusNotFound)
 				return
 			}
+			// Часть заявок могла быть удалена до ошибки, удаляем их файлы.
+			if len(deleted) > 0 {
+				reports.Purge(r.Context(), log, s3, deleted)
+			}
 			http.Error(w, "internal error", http.StatusInternalServerError)
 			return
 		}
-		log.Debug("rejected reports deleted succesfully", slog.Int("count", count))
+		log.Debug("rejected reports deleted succesfully", slog.Int("count", len(deleted)))
+
+		// Удаление медиа файлов заявок.
+		res := reports.Purge(r.Context(), log, s3, deleted)
 
-		// Запись ответа в text/plain.
-		str := fmt.Sprintf("Deleted rejected reports: %d", count)
-		_, err = w.Write([]byte(str))
+		// Кодирование ответа в JSON.
+		err = json.NewEncoder(w).Encode(res)
 		if err != nil {
-			log.Error("cannot write response", logger.Err(err))
+			log.Error("cannot encode purge result", logger.Err(err))
 			http.Error(w, "internal error", http.StatusInternalServerError)
 			return
 		}
-		log.Debug("response sent successfully")
+		log.Debug("purge result sent successfully", slog.Int("files", res.Files), slog.Int("failed", len(res.Failed)))
 	}
 }
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"Report-Storage/internal/reports"
+	"Report-Storage/internal/s3cloud"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// rejectStub возвращает заранее заданные удаленные заявки.
+type rejectStub struct {
+	deleted []storage.Report
+}
+
+func (s rejectStub) DeleteRejected(context.Context) ([]storage.Report, error) {
+	if len(s.deleted) == 0 {
+		return nil, storage.ErrReportNotFound
+	}
+	return s.deleted, nil
+}
+
+// brokenFiles - хранилище файлов, которое не может удалить файлы из
+// списка broken.
+type brokenFiles struct {
+	broken []string
+}
+
+func (f brokenFiles) Upload(context.Context, s3cloud.UploadInput) (string, error) {
+	return "", nil
+}
+
+func (f brokenFiles) Remove(_ context.Context, url string) error {
+	if slices.Contains(f.broken, url) {
+		return errors.New("remove failed")
+	}
+	return nil
+}
+
+func TestDeleteRejected(t *testing.T) {
+	l := slog.New(slog.NewTextHandler(io.Discard, nil))
+	st := rejectStub{deleted: []storage.Report{
+		{Number: 1, Media: []storage.Media{{URL: "https://ya.ru/a.jpg", Kind: storage.Photo}}},
+		{Number: 2, Media: []storage.Media{
+			{URL: "https://ya.ru/b.mp4", Kind: storage.Video, Poster: "https://ya.ru/b.jpg"},
+		}},
+	}}
+	files := brokenFiles{broken: []string{"https://ya.ru/b.mp4"}}
+
+	rec := httptest.NewRecorder()
+	DeleteRejected(l, st, files)(rec, httptest.NewRequest("DELETE", "/api/reports/rejected", nil))
+	if rec.Code != 200 {
+		t.Fatalf("DeleteRejected() code = %d", rec.Code)
+	}
+
+	// В ответе возвращаются фактически удаленные файлы и ошибки удаления.
+	var got reports.PurgeResult
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Reports != 2 || got.Files != 2 || !slices.Equal(got.Failed, files.broken) {
+		t.Errorf("DeleteRejected() = %+v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	DeleteRejected(l, rejectStub{}, files)(rec, httptest.NewRequest("DELETE", "/api/reports/rejected", nil))
+	if rec.Code != 404 {
+		t.Errorf("DeleteRejected() code = %d, want 404", rec.Code)
+	}
+}
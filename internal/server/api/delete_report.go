@@ -2,20 +2,34 @@ package api
 
 import (
 	"Report-Storage/internal/logger"
+	"Report-Storage/internal/reports"
 	"Report-Storage/internal/storage"
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+// tmPurge - срок записи ответа на удаление заявок вместе с их файлами.
+// Удаление файлов ограничено reports.PurgeTimeout, остальное время
+// отводится на удаление документов и запись ответа.
+const tmPurge = reports.PurgeTimeout + time.Second*10
+
 // ReportRemover - интерфейс для удаления заявки.
 type ReportRemover interface {
-	DeleteByNum(ctx context.Context, num int) error
+	TrashByNum(ctx context.Context, num int, by string) error
+	DeleteByNum(ctx context.Context, num int) (storage.Report, error)
 }
 
 // DeleteReport обрабатывает запрос на удаление заявки по её номеру.
-func DeleteReport(l *slog.Logger, st ReportRemover) http.HandlerFunc {
+// По умолчанию заявка перемещается в корзину, откуда ее можно
+// восстановить. С параметром permanent=true заявка удаляется
+// окончательно вместе с медиа файлами, в ответе возвращается количество
+// удаленных файлов и ссылки на файлы, которые удалить не удалось.
+func DeleteReport(l *slog.Logger, st ReportRemover, s3 reports.FileSaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const operation = "server.api.DeleteReport"
 
@@ -30,9 +44,43 @@ func DeleteReport(l *slog.Logger, st ReportRemover) http.HandlerFunc {
 			http.Error(w, "invalid report number", http.StatusBadRequest)
 			return
 		}
+		var permanent bool
+		if s := r.URL.Query().Get("permanent"); s != "" {
+			permanent, err = strconv.ParseBool(s)
+			if err != nil {
+				log.Error("incorrect permanent param", slog.String("permanent", s))
+				http.Error(w, "incorrect permanent param", http.StatusBadRequest)
+				return
+			}
+		}
+
+		// Перемещение заявки в корзину.
+		if !permanent {
+			err = st.TrashByNum(r.Context(), num, subject(r))
+			if err != nil {
+				log.Error("cannot move report to trash", logger.Err(err))
+				if errors.Is(err, storage.ErrReportNotFound) {
+					http.Error(w, "report not found", http.StatusNotFound)
+					return
+				}
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return
+			}
+
+			// Запись кода ответа.
+			w.WriteHeader(http.StatusNoContent)
+			log.Debug("report moved to trash successfully")
+			return
+		}
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Продление срока записи ответа на время удаления файлов.
+		extendDeadline(w, log, tmPurge)
 
 		// Запрос в базу данных.
-		err = st.DeleteByNum(r.Context(), num)
+		report, err := st.DeleteByNum(r.Context(), num)
 		if err != nil {
 			log.Error("cannot delete report", logger.Err(err))
 			if errors.Is(err, storage.ErrReportNotFound) {
@@ -42,9 +90,18 @@ func DeleteReport(l *slog.Logger, st ReportRemover) http.HandlerFunc {
 			http.Error(w, "internal error", http.StatusInternalServerError)
 			return
 		}
-
-		// Запись кода ответа.
-		w.WriteHeader(http.StatusNoContent)
 		log.Debug("report deleted successfully")
+
+		// Удаление медиа файлов заявки.
+		res := reports.Purge(r.Context(), log, s3, []storage.Report{report})
+
+		// Кодирование ответа в JSON.
+		err = json.NewEncoder(w).Encode(res)
+		if err != nil {
+			log.Error("cannot encode purge result", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("purge result sent successfully", slog.Int("files", res.Files), slog.Int("failed", len(res.Failed)))
 	}
 }
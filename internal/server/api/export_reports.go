@@ -0,0 +1,201 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"Report-Storage/internal/xlsx"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Форматы выгрузки заявок.
+const (
+	formatCSV  = "csv"
+	formatXLSX = "xlsx"
+)
+
+// tmExport - время на запись выгрузки заявок. Выгрузка может длиться
+// дольше таймаута записи ответа сервера, поэтому срок записи продлевается.
+const tmExport = time.Minute * 10
+
+// exportHeader - заголовок таблицы выгрузки заявок. Координаты выгружаются
+// отдельными столбцами и не зависят от версии API.
+var exportHeader = []any{
+	"Номер", "Создана (UTC)", "Статус", "Статус с (UTC)", "Категория",
+	"Город", "Адрес", "Описание", "Широта", "Долгота", "Медиа",
+}
+
+// rowWriter - интерфейс построчной записи таблицы выгрузки.
+type rowWriter interface {
+	Write(row []any) error
+	Close() error
+}
+
+// ReportsExporter - интерфейс для потоковой выгрузки заявок с фильтром.
+type ReportsExporter interface {
+	ExportReports(ctx context.Context, fl storage.Filter, fn func(storage.Report) error) error
+}
+
+// ExportReports обрабатывает запрос на выгрузку заявок в формате CSV
+// или XLSX, заданном query параметром format, по-умолчанию CSV. Заявки
+// выбираются теми же фильтрами, что и в ReportsWithFilters, и записываются
+// в ответ по мере чтения из базы данных. Количество заявок ограничивается
+// только явно заданным query параметром n. Ошибка после начала записи
+// ответа только логируется.
+func ExportReports(l *slog.Logger, st ReportsExporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.ExportReports"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to export reports")
+
+		// Получение параметров запроса.
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = formatCSV
+		}
+		if format != formatCSV && format != formatXLSX {
+			log.Error("incorrect export format", slog.String("format", format))
+			http.Error(w, "format must be csv or xlsx", http.StatusBadRequest)
+			return
+		}
+		filter, err := reportFilter(r)
+		if err == nil {
+			filter.Count, err = limit(r, "n", math.MaxInt)
+		}
+		if err != nil {
+			log.Error("invalid filter parameters", logger.Err(err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Продление срока записи ответа для больших выгрузок.
+		extendDeadline(w, log, tmExport)
+
+		// Запрос в базу данных. Ответ начинается с первой найденной
+		// заявки, чтобы ошибки запроса вернуть кодом статуса.
+		var out rowWriter
+		rows := 0
+		err = st.ExportReports(r.Context(), filter, func(rep storage.Report) error {
+			if out == nil {
+				var err error
+				out, err = newRowWriter(w, format)
+				if err != nil {
+					return err
+				}
+				if err := out.Write(exportHeader); err != nil {
+					return err
+				}
+			}
+			rows++
+			return out.Write(exportRow(rep))
+		})
+		if err != nil {
+			log.Error("failed to export reports", logger.Err(err), slog.Int("rows", rows))
+			if out != nil {
+				return
+			}
+			if districtError(w, err) {
+				return
+			}
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no reports found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		if err := out.Close(); err != nil {
+			log.Error("cannot finish export file", logger.Err(err))
+			return
+		}
+		log.Debug("reports exported successfully", slog.Int("rows", rows))
+	}
+}
+
+// newRowWriter устанавливает заголовки ответа для выгрузки в формате
+// format и возвращает запись таблицы в тело ответа.
+func newRowWriter(w http.ResponseWriter, format string) (rowWriter, error) {
+	name := fmt.Sprintf("reports-%s.%s", time.Now().Format("20060102"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+
+	if format == formatXLSX {
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		return xlsx.NewWriter(w, "Заявки")
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	// Метка порядка байтов нужна Excel, чтобы открыть файл в UTF-8.
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	return &csvWriter{w: csv.NewWriter(w)}, nil
+}
+
+// exportRow возвращает строку таблицы выгрузки для заявки.
+func exportRow(rep storage.Report) []any {
+	var since any
+	if !rep.StatusSince.IsZero() {
+		since = rep.StatusSince
+	}
+	return []any{
+		rep.Number,
+		rep.Created,
+		storage.StatusName(rep.Status),
+		since,
+		rep.Category,
+		rep.City,
+		rep.Address,
+		rep.Description,
+		rep.Geo.Coordinates.Lat(),
+		rep.Geo.Coordinates.Lon(),
+		strings.Join(storage.MediaURLs(rep.Media), " "),
+	}
+}
+
+// csvWriter записывает строки выгрузки в формате CSV.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// Write записывает строку, преобразуя значения в текст. Строки, которые
+// табличный редактор воспримет как формулу, экранируются апострофом.
+func (c *csvWriter) Write(row []any) error {
+	record := make([]string, len(row))
+	for i, v := range row {
+		switch v := v.(type) {
+		case nil:
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		case float64:
+			record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case time.Time:
+			record[i] = v.UTC().Format(time.DateTime)
+		case string:
+			if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+				v = "'" + v
+			}
+			record[i] = v
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return c.w.Write(record)
+}
+
+// Close записывает буферизованные строки в ответ.
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"Report-Storage/internal/storage"
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// exportStub передает в функцию обработки заранее заданные заявки
+// с учетом ограничения количества из фильтра.
+type exportStub struct {
+	reports []storage.Report
+}
+
+func (s exportStub) ExportReports(_ context.Context, fl storage.Filter, fn func(storage.Report) error) error {
+	if len(s.reports) == 0 {
+		return storage.ErrArrayNotFound
+	}
+	reps := s.reports
+	if fl.Count > 0 && fl.Count < len(reps) {
+		reps = reps[:fl.Count]
+	}
+	for _, rep := range reps {
+		if err := fn(rep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportReports(t *testing.T) {
+	st := exportStub{reports: []storage.Report{{
+		Number:      12,
+		Created:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		City:        "Москва",
+		Address:     "ул. Тверская, 1",
+		Description: "=HYPERLINK(\"x\")",
+		Media:       []storage.Media{{URL: "https://s3/a.jpg"}, {URL: "https://s3/b.mp4", Poster: "https://s3/b.jpg"}},
+		Geo:         storage.Geo{Coordinates: storage.LonLat{37.61, 55.76}},
+		Status:      storage.InProgress,
+	}}}
+	l := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := ExportReports(l, st)
+
+	// CSV с меткой порядка байтов и экранированной формулой.
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/api/reports/export", nil))
+	if rec.Code != 200 || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("ExportReports() code = %d, content type = %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	body := strings.TrimPrefix(rec.Body.String(), "\ufeff")
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("ExportReports() rows = %d, want 2", len(records))
+	}
+	want := []string{"12", "2024-03-01 12:00:00", "В работе", "", "", "Москва", "ул. Тверская, 1",
+		"'=HYPERLINK(\"x\")", "55.76", "37.61", "https://s3/a.jpg https://s3/b.mp4 https://s3/b.jpg"}
+	for i := range want {
+		if records[1][i] != want[i] {
+			t.Errorf("ExportReports() column %s = %q, want %q", exportHeader[i], records[1][i], want[i])
+		}
+	}
+
+	// XLSX - корректный zip архив.
+	rec = httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/api/reports/export?format=xlsx", nil))
+	if rec.Code != 200 {
+		t.Fatalf("ExportReports() xlsx code = %d", rec.Code)
+	}
+	if _, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len())); err != nil {
+		t.Errorf("ExportReports() xlsx is not a zip archive: %v", err)
+	}
+
+	// Ошибки до начала записи ответа возвращаются кодом статуса.
+	for url, code := range map[string]int{
+		"/api/reports/export?format=pdf":      400,
+		"/api/reports/export?from=yesterday":  400,
+		"/api/reports/export?poly=55.7,37.6,": 400,
+	} {
+		rec = httptest.NewRecorder()
+		h(rec, httptest.NewRequest("GET", url, nil))
+		if rec.Code != code {
+			t.Errorf("ExportReports(%s) code = %d, want %d", url, rec.Code, code)
+		}
+	}
+	rec = httptest.NewRecorder()
+	ExportReports(l, exportStub{})(rec, httptest.NewRequest("GET", "/api/reports/export", nil))
+	if rec.Code != 404 {
+		t.Errorf("ExportReports() empty code = %d, want 404", rec.Code)
+	}
+}
+
+func TestExportReports_Count(t *testing.T) {
+	var st exportStub
+	for i := 1; i <= 25; i++ {
+		st.reports = append(st.reports, storage.Report{Number: int64(i)})
+	}
+	l := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := ExportReports(l, st)
+
+	tests := []struct {
+		name     string
+		target   string
+		wantCode int
+		wantRows int
+	}{
+		{name: "OK All reports", target: "/api/reports/export", wantCode: 200, wantRows: 25},
+		{name: "OK Explicit count", target: "/api/reports/export?n=5", wantCode: 200, wantRows: 5},
+		{name: "Error Incorrect count", target: "/api/reports/export?n=0", wantCode: 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h(rec, httptest.NewRequest("GET", tt.target, nil))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("ExportReports() code = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantCode != 200 {
+				return
+			}
+			records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(rec.Body.String(), "\ufeff"))).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Первая строка - заголовок таблицы.
+			if len(records)-1 != tt.wantRows {
+				t.Errorf("ExportReports() rows = %d, want %d", len(records)-1, tt.wantRows)
+			}
+		})
+	}
+}
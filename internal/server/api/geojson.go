@@ -0,0 +1,105 @@
+package api
+
+import (
+	"Report-Storage/internal/storage"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// geoJSONType - тип контента GeoJSON по RFC 7946.
+const geoJSONType = "application/geo+json"
+
+// featureCollection - коллекция объектов GeoJSON.
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+// feature - объект GeoJSON с геометрией точки заявки.
+type feature struct {
+	Type       string        `json:"type"`
+	ID         int64         `json:"id"`
+	Geometry   pointGeometry `json:"geometry"`
+	Properties properties    `json:"properties"`
+}
+
+// pointGeometry - геометрия GeoJSON типа Point с координатами в порядке
+// долгота, широта.
+type pointGeometry struct {
+	Type        string         `json:"type"`
+	Coordinates storage.LonLat `json:"coordinates"`
+}
+
+// properties - свойства объекта GeoJSON, все поля заявки, кроме
+// координат. Поле Geo перекрывает одноименное поле заявки и всегда
+// пустое, поэтому координаты не дублируются в свойствах. Distance
+// заполняется только для результатов поиска по удалению от точки.
+type properties struct {
+	storage.Report
+	Geo      *struct{} `json:"geo,omitempty"`
+	Distance *float64  `json:"distance,omitempty"`
+}
+
+// features преобразует заявки в коллекцию объектов GeoJSON.
+func features(reports []storage.Report) featureCollection {
+	fc := featureCollection{Type: "FeatureCollection", Features: make([]feature, 0, len(reports))}
+	for _, rep := range reports {
+		fc.Features = append(fc.Features, feature{
+			Type: "Feature",
+			ID:   rep.Number,
+			Geometry: pointGeometry{
+				Type:        "Point",
+				Coordinates: rep.Geo.Coordinates,
+			},
+			Properties: properties{Report: rep},
+		})
+	}
+	return fc
+}
+
+// wantGeoJSON сообщает, запросил ли клиент ответ в формате GeoJSON
+// query параметром format=geojson или заголовком Accept.
+func wantGeoJSON(r *http.Request) bool {
+	if strings.EqualFold(r.URL.Query().Get("format"), "geojson") {
+		return true
+	}
+	for _, v := range strings.Split(r.Header.Get("Accept"), ",") {
+		t, _, err := mime.ParseMediaType(strings.TrimSpace(v))
+		if err == nil && t == geoJSONType {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeReports кодирует список заявок в ответ в формате JSON или,
+// если клиент запросил, в виде GeoJSON FeatureCollection.
+func encodeReports(w http.ResponseWriter, r *http.Request, reports []storage.Report) error {
+	w.Header().Add("Vary", "Accept")
+	if wantGeoJSON(r) {
+		w.Header().Set("Content-Type", geoJSONType)
+		return json.NewEncoder(w).Encode(features(reports))
+	}
+	return json.NewEncoder(w).Encode(presentAll(r, reports))
+}
+
+// encodeNearReports кодирует список заявок с расстояниями до точки поиска
+// так же, как encodeReports, добавляя к каждой заявке поле distance.
+func encodeNearReports(w http.ResponseWriter, r *http.Request, near []storage.NearReport) error {
+	w.Header().Add("Vary", "Accept")
+	if wantGeoJSON(r) {
+		reports := make([]storage.Report, 0, len(near))
+		for _, n := range near {
+			reports = append(reports, n.Report)
+		}
+		fc := features(reports)
+		for i := range fc.Features {
+			fc.Features[i].Properties.Distance = &near[i].Distance
+		}
+		w.Header().Set("Content-Type", geoJSONType)
+		return json.NewEncoder(w).Encode(fc)
+	}
+	return json.NewEncoder(w).Encode(presentNear(r, near))
+}
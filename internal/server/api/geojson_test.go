@@ -0,0 +1,75 @@
+package api
+
+import (
+	"Report-Storage/internal/storage"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_wantGeoJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+		want   bool
+	}{
+		{name: "OK Default", url: "/api/reports/all", want: false},
+		{name: "OK Format parameter", url: "/api/reports/all?format=geojson", want: true},
+		{name: "OK Accept header", url: "/api/reports/all", accept: "application/json, application/geo+json;q=0.9", want: true},
+		{name: "OK Other format", url: "/api/reports/all?format=json", accept: "application/json", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			if got := wantGeoJSON(r); got != tt.want {
+				t.Errorf("wantGeoJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_encodeReports(t *testing.T) {
+	reports := []storage.Report{{
+		Number: 7,
+		City:   "Москва",
+		Geo:    storage.Geo{Type: "Point", Coordinates: storage.LonLat{37.62, 55.75}},
+	}}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/reports/all?format=geojson", nil)
+	if err := encodeReports(w, r, reports); err != nil {
+		t.Fatal(err)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != geoJSONType {
+		t.Errorf("encodeReports() Content-Type = %v, want %v", ct, geoJSONType)
+	}
+
+	var fc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			ID       int64 `json:"id"`
+			Geometry struct {
+				Coordinates [2]float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.NewDecoder(strings.NewReader(w.Body.String())).Decode(&fc); err != nil {
+		t.Fatal(err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 1 {
+		t.Fatalf("encodeReports() = %v, want FeatureCollection with one feature", w.Body.String())
+	}
+	f := fc.Features[0]
+	if f.ID != 7 || f.Geometry.Coordinates != [2]float64{37.62, 55.75} {
+		t.Errorf("encodeReports() feature = %v, want id 7 and [lon, lat] coordinates", f)
+	}
+	if _, ok := f.Properties["geo"]; ok || f.Properties["city"] != "Москва" {
+		t.Errorf("encodeReports() properties = %v, want report fields without geo", f.Properties)
+	}
+}
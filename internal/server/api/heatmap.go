@@ -0,0 +1,130 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultPrecision - длина геохеша ячеек тепловой карты по умолчанию,
+// ячейка около 1.2 x 0.6 км.
+const defaultPrecision = 6
+
+// cellCollection - коллекция ячеек тепловой карты в формате GeoJSON.
+type cellCollection struct {
+	Type     string        `json:"type"`
+	Features []cellFeature `json:"features"`
+}
+
+// cellFeature - объект GeoJSON ячейки с геометрией ее границ.
+type cellFeature struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	Geometry   polygonGeometry `json:"geometry"`
+	Properties storage.Cell    `json:"properties"`
+}
+
+// polygonGeometry - геометрия GeoJSON типа Polygon.
+type polygonGeometry struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// HeatmapInterface - интерфейс для получения тепловой карты заявок.
+type HeatmapInterface interface {
+	Heatmap(ctx context.Context, from, to time.Time, status []storage.Status, precision int) ([]storage.Cell, error)
+}
+
+// Heatmap обрабатывает запрос на получение плотности заявок по ячейкам
+// геохешей за период из query параметров from и to. Длина геохеша
+// задается параметром precision. Ответ - GeoJSON FeatureCollection
+// с многоугольниками ячеек.
+func Heatmap(l *slog.Logger, st HeatmapInterface) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Heatmap"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive reports heatmap")
+
+		// Получение параметров запроса.
+		from, to, status, precision, err := heatmapParams(r)
+		if err != nil {
+			log.Error("failed to get correct parameters", logger.Err(err))
+			http.Error(w, "invalid parameters", http.StatusBadRequest)
+			return
+		}
+
+		// Запрос в базу данных.
+		cells, err := st.Heatmap(r.Context(), from, to, status, precision)
+		if err != nil {
+			log.Error("failed to get reports heatmap", logger.Err(err))
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no reports found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в GeoJSON.
+		if err := encodeCells(w, cells); err != nil {
+			log.Error("cannot encode cells to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("reports heatmap encoded and sent successfully", slog.Int("cells", len(cells)))
+	}
+}
+
+// heatmapParams получает параметры тепловой карты: период, статусы
+// и длину геохеша ячеек.
+func heatmapParams(r *http.Request) (time.Time, time.Time, []storage.Status, int, error) {
+	from, to, err := period(r)
+	if err != nil {
+		return from, to, nil, 0, err
+	}
+	status := splitStatus(r.URL.Query().Get("status"))
+
+	precision := defaultPrecision
+	if p := r.URL.Query().Get("precision"); p != "" {
+		precision, err = strconv.Atoi(p)
+		if err != nil {
+			return from, to, nil, 0, fmt.Errorf("failed to parse precision: %w", err)
+		}
+		if precision < 1 || precision > storage.MaxGeohashPrecision {
+			return from, to, nil, 0, fmt.Errorf("precision out of range [1, %d]", storage.MaxGeohashPrecision)
+		}
+	}
+	return from, to, status, precision, nil
+}
+
+// encodeCells кодирует ячейки в ответ как GeoJSON FeatureCollection.
+func encodeCells(w http.ResponseWriter, cells []storage.Cell) error {
+	fc := cellCollection{Type: "FeatureCollection", Features: make([]cellFeature, 0, len(cells))}
+	for _, c := range cells {
+		box, err := storage.GeohashBounds(c.Geohash)
+		if err != nil {
+			return err
+		}
+		ring := [][2]float64{
+			box.Min, {box.Max.Lon(), box.Min.Lat()}, box.Max, {box.Min.Lon(), box.Max.Lat()}, box.Min,
+		}
+		fc.Features = append(fc.Features, cellFeature{
+			Type:       "Feature",
+			ID:         c.Geohash,
+			Geometry:   polygonGeometry{Type: storage.Polygon, Coordinates: [][][2]float64{ring}},
+			Properties: c,
+		})
+	}
+
+	w.Header().Set("Content-Type", geoJSONType)
+	return json.NewEncoder(w).Encode(fc)
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"Report-Storage/internal/storage"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_encodeCells(t *testing.T) {
+	cells := []storage.Cell{{Geohash: "ucfv0", Count: 3, Months: 2}}
+
+	w := httptest.NewRecorder()
+	if err := encodeCells(w, cells); err != nil {
+		t.Fatal(err)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != geoJSONType {
+		t.Errorf("encodeCells() Content-Type = %v, want %v", ct, geoJSONType)
+	}
+
+	var fc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			ID       string `json:"id"`
+			Geometry struct {
+				Type        string         `json:"type"`
+				Coordinates [][][2]float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties storage.Cell `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &fc); err != nil {
+		t.Fatal(err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 1 {
+		t.Fatalf("encodeCells() = %s, want FeatureCollection with one feature", w.Body.String())
+	}
+	f := fc.Features[0]
+	if f.ID != "ucfv0" || f.Properties != cells[0] {
+		t.Errorf("encodeCells() feature = %+v, want cell %+v", f, cells[0])
+	}
+	area := storage.Area{Type: storage.MultiPolygon, Coordinates: [][][][2]float64{f.Geometry.Coordinates}}
+	if f.Geometry.Type != storage.Polygon || area.Validate() != nil {
+		t.Errorf("encodeCells() geometry = %+v, want valid Polygon", f.Geometry)
+	}
+}
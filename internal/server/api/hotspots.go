@@ -0,0 +1,81 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultHotspotMonths - минимальное количество месяцев с заявками
+// в ячейке, при котором она считается очагом, по умолчанию.
+const defaultHotspotMonths = 3
+
+// HotspotsInterface - интерфейс для получения очагов заявок.
+type HotspotsInterface interface {
+	Heatmap(ctx context.Context, from, to time.Time, status []storage.Status, precision int) ([]storage.Cell, error)
+}
+
+// Hotspots обрабатывает запрос на получение очагов - ячеек геохешей,
+// в которых заявки появлялись не менее чем в months разных месяцах
+// за период. Параметры from, to, status и precision такие же, как у
+// тепловой карты. Ответ - GeoJSON FeatureCollection.
+func Hotspots(l *slog.Logger, st HotspotsInterface) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Hotspots"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive reports hotspots")
+
+		// Получение параметров запроса.
+		from, to, status, precision, err := heatmapParams(r)
+		if err != nil {
+			log.Error("failed to get correct parameters", logger.Err(err))
+			http.Error(w, "invalid parameters", http.StatusBadRequest)
+			return
+		}
+		months := defaultHotspotMonths
+		if m := r.URL.Query().Get("months"); m != "" {
+			months, err = strconv.Atoi(m)
+			if err != nil || months < 2 {
+				log.Error("failed to get correct months parameter", slog.String("months", m))
+				http.Error(w, "invalid parameters", http.StatusBadRequest)
+				return
+			}
+		}
+
+		// Запрос в базу данных.
+		cells, err := st.Heatmap(r.Context(), from, to, status, precision)
+		if err != nil && !errors.Is(err, storage.ErrArrayNotFound) {
+			log.Error("failed to get reports heatmap", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Отбор ячеек с повторяющимися заявками.
+		var hotspots []storage.Cell
+		for _, c := range cells {
+			if c.Months >= months {
+				hotspots = append(hotspots, c)
+			}
+		}
+		if len(hotspots) == 0 {
+			log.Error("no hotspots found")
+			http.Error(w, "no hotspots found", http.StatusNotFound)
+			return
+		}
+
+		// Кодирование ответа в GeoJSON.
+		if err := encodeCells(w, hotspots); err != nil {
+			log.Error("cannot encode cells to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("reports hotspots encoded and sent successfully", slog.Int("cells", len(hotspots)))
+	}
+}
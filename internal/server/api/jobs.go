@@ -0,0 +1,91 @@
+package api
+
+import (
+	"Report-Storage/internal/jobs"
+	"Report-Storage/internal/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// JobRunner - интерфейс планировщика фоновых задач.
+type JobRunner interface {
+	Jobs() []jobs.JobInfo
+	Launch(ctx context.Context, name string) (jobs.Run, error)
+	LaunchFunc(ctx context.Context, name string, fn jobs.JobFunc) (jobs.Run, error)
+}
+
+// Jobs обрабатывает запрос на получение списка фоновых задач с историей
+// их последних запусков.
+func Jobs(l *slog.Logger, sch JobRunner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Jobs"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive jobs")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Кодирование ответа в JSON.
+		err := json.NewEncoder(w).Encode(sch.Jobs())
+		if err != nil {
+			log.Error("cannot encode jobs", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("jobs sent successfully")
+	}
+}
+
+// RunJob обрабатывает запрос на ручной запуск фоновой задачи по ее имени.
+// Задача выполняется в фоне, в ответе с кодом 202 возвращается начатый
+// запуск, его результат доступен в истории запусков задачи.
+func RunJob(l *slog.Logger, sch JobRunner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.RunJob"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to run job")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		name := chi.URLParam(r, "name")
+
+		// Запуск задачи. Задача не прерывается при отключении клиента.
+		run, err := sch.Launch(r.Context(), name)
+		if err != nil {
+			switch {
+			case errors.Is(err, jobs.ErrUnknownJob):
+				log.Error("unknown job", slog.String("job", name))
+				http.Error(w, "job not found", http.StatusNotFound)
+				return
+			case errors.Is(err, jobs.ErrRunning):
+				log.Error("job is already running", slog.String("job", name))
+				http.Error(w, "job is already running", http.StatusConflict)
+				return
+			}
+			log.Error("cannot launch job", slog.String("job", name), logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		w.WriteHeader(http.StatusAccepted)
+		err = json.NewEncoder(w).Encode(run)
+		if err != nil {
+			log.Error("cannot encode job run", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("job run sent successfully")
+	}
+}
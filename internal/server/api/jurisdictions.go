@@ -0,0 +1,52 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// JurisdictionsRetriever - интерфейс для получения всех территорий.
+type JurisdictionsRetriever interface {
+	Jurisdictions(ctx context.Context) ([]storage.Jurisdiction, error)
+}
+
+// Jurisdictions обрабатывает запрос на получение всех территорий вместе
+// с их границами.
+func Jurisdictions(l *slog.Logger, st JurisdictionsRetriever) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Jurisdictions"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive jurisdictions")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Запрос в базу данных.
+		jurs, err := st.Jurisdictions(r.Context())
+		if err != nil {
+			log.Error("cannot receive jurisdictions", logger.Err(err))
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no jurisdictions found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = json.NewEncoder(w).Encode(jurs)
+		if err != nil {
+			log.Error("cannot encode jurisdictions to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("jurisdictions encoded and sent successfully")
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Координаты хранятся и обрабатываются в порядке GeoJSON: долгота, широта.
+// Первая версия API (/api/...) принимает и возвращает точки заявок в порядке
+// широта, долгота. Все преобразования между этими порядками выполняются
+// только функциями этого файла.
+
+// orderKey - ключ контекста запроса для порядка координат.
+type orderKey struct{}
+
+// V2 - middleware второй версии API. Отмечает запрос как использующий
+// порядок координат GeoJSON во всех точках запроса и ответа.
+func V2(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), orderKey{}, storage.OrderLonLat)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// coordOrder возвращает порядок координат, используемый в запросе.
+// По умолчанию используется порядок первой версии API.
+func coordOrder(r *http.Request) storage.CoordOrder {
+	if o, ok := r.Context().Value(orderKey{}).(storage.CoordOrder); ok {
+		return o
+	}
+	return storage.OrderLatLon
+}
+
+// legacyGeo - точка заявки в представлении первой версии API.
+type legacyGeo struct {
+	Type        string         `json:"type,omitempty" validate:"omitempty,max=100"`
+	Coordinates storage.LatLon `json:"coordinates" validate:"required,dive,required"`
+}
+
+// legacyReport - заявка в представлении первой версии API. Поле Geo
+// перекрывает одноименное поле заявки при кодировании и декодировании.
+type legacyReport struct {
+	storage.Report
+	Geo legacyGeo `json:"geo" validate:"required"`
+}
+
+// present возвращает заявку в представлении, соответствующем версии API
+// запроса.
+func present(r *http.Request, rep storage.Report) any {
+	if coordOrder(r) == storage.OrderLonLat {
+		return rep
+	}
+	return legacyReport{
+		Report: rep,
+		Geo:    legacyGeo{Type: rep.Geo.Type, Coordinates: rep.Geo.Coordinates.LatLon()},
+	}
+}
+
+// presentAll возвращает заявки в представлении, соответствующем версии
+// API запроса.
+func presentAll(r *http.Request, reports []storage.Report) any {
+	if coordOrder(r) == storage.OrderLonLat {
+		return reports
+	}
+	legacy := make([]any, 0, len(reports))
+	for _, rep := range reports {
+		legacy = append(legacy, present(r, rep))
+	}
+	return legacy
+}
+
+// legacyNear - заявка с расстоянием до точки поиска в представлении
+// первой версии API.
+type legacyNear struct {
+	legacyReport
+	Distance float64 `json:"distance"`
+}
+
+// presentNear возвращает заявки с расстояниями в представлении,
+// соответствующем версии API запроса.
+func presentNear(r *http.Request, near []storage.NearReport) any {
+	if coordOrder(r) == storage.OrderLonLat {
+		return near
+	}
+	legacy := make([]legacyNear, 0, len(near))
+	for _, n := range near {
+		legacy = append(legacy, legacyNear{
+			legacyReport: present(r, n.Report).(legacyReport),
+			Distance:     n.Distance,
+		})
+	}
+	return legacy
+}
+
+// legacyCluster - кластер заявок в представлении первой версии API.
+type legacyCluster struct {
+	Center    storage.LatLon    `json:"center"`
+	Statistic storage.Statistic `json:"statistic"`
+}
+
+// presentClusters возвращает кластеры заявок в представлении,
+// соответствующем версии API запроса.
+func presentClusters(r *http.Request, clusters []storage.Cluster) any {
+	if coordOrder(r) == storage.OrderLonLat {
+		return clusters
+	}
+	legacy := make([]legacyCluster, 0, len(clusters))
+	for _, c := range clusters {
+		legacy = append(legacy, legacyCluster{Center: c.Center.LatLon(), Statistic: c.Statistic})
+	}
+	return legacy
+}
+
+// encodeReport кодирует заявку в ответ в представлении, соответствующем
+// версии API запроса.
+func encodeReport(w http.ResponseWriter, r *http.Request, rep storage.Report) error {
+	return json.NewEncoder(w).Encode(present(r, rep))
+}
+
+// decodeReport декодирует заявку из тела запроса в представлении,
+// соответствующем версии API запроса.
+func decodeReport(r *http.Request) (storage.Report, error) {
+	if coordOrder(r) == storage.OrderLonLat {
+		var rep storage.Report
+		err := json.NewDecoder(r.Body).Decode(&rep)
+		return rep, err
+	}
+
+	var legacy legacyReport
+	if err := json.NewDecoder(r.Body).Decode(&legacy); err != nil {
+		return storage.Report{}, err
+	}
+	rep := legacy.Report
+	rep.Geo = storage.Geo{Type: legacy.Geo.Type, Coordinates: legacy.Geo.Coordinates.LonLat()}
+	return rep, nil
+}
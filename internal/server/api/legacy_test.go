@@ -0,0 +1,111 @@
+package api
+
+import (
+	"Report-Storage/internal/storage"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// v2Request возвращает запрос, прошедший через middleware V2.
+func v2Request(req *http.Request) *http.Request {
+	var got *http.Request
+	V2(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { got = r })).ServeHTTP(httptest.NewRecorder(), req)
+	return got
+}
+
+func Test_encodeReport(t *testing.T) {
+	rep := storage.Report{Number: 1, Geo: storage.Geo{Type: "Point", Coordinates: storage.LonLat{37.62, 55.75}}}
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want [2]float64
+	}{
+		{name: "OK Legacy", req: httptest.NewRequest("GET", "/api/reports/1", nil), want: [2]float64{55.75, 37.62}},
+		{name: "OK V2", req: v2Request(httptest.NewRequest("GET", "/api/v2/reports/1", nil)), want: [2]float64{37.62, 55.75}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := encodeReport(w, tt.req, rep); err != nil {
+				t.Fatal(err)
+			}
+			var got struct {
+				Number int64 `json:"number"`
+				Geo    struct {
+					Coordinates [2]float64 `json:"coordinates"`
+				} `json:"geo"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Number != 1 || got.Geo.Coordinates != tt.want {
+				t.Errorf("encodeReport() = %s, want coordinates %v", w.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_decodeReport(t *testing.T) {
+	body := `{"number":1,"city":"Москва","geo":{"type":"Point","coordinates":[55.75,37.62]}}`
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want storage.LonLat
+	}{
+		{name: "OK Legacy", req: httptest.NewRequest("PUT", "/api/reports", strings.NewReader(body)), want: storage.LonLat{37.62, 55.75}},
+		{name: "OK V2", req: v2Request(httptest.NewRequest("PUT", "/api/v2/reports", strings.NewReader(body))), want: storage.LonLat{55.75, 37.62}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeReport(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Number != 1 || got.City != "Москва" || got.Geo.Coordinates != tt.want {
+				t.Errorf("decodeReport() = %v, want coordinates %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_presentNear(t *testing.T) {
+	near := []storage.NearReport{{
+		Report:   storage.Report{Number: 1, Geo: storage.Geo{Type: "Point", Coordinates: storage.LonLat{37.62, 55.75}}},
+		Distance: 125.5,
+	}}
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want [2]float64
+	}{
+		{name: "OK Legacy", req: httptest.NewRequest("GET", "/api/reports/nearest", nil), want: [2]float64{55.75, 37.62}},
+		{name: "OK V2", req: v2Request(httptest.NewRequest("GET", "/api/v2/reports/nearest", nil)), want: [2]float64{37.62, 55.75}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(presentNear(tt.req, near))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []struct {
+				Number   int64   `json:"number"`
+				Distance float64 `json:"distance"`
+				Geo      struct {
+					Coordinates [2]float64 `json:"coordinates"`
+				} `json:"geo"`
+			}
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || got[0].Number != 1 || got[0].Distance != 125.5 || got[0].Geo.Coordinates != tt.want {
+				t.Errorf("presentNear() = %s, want distance 125.5 and coordinates %v", b, tt.want)
+			}
+		})
+	}
+}
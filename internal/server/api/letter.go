@@ -0,0 +1,128 @@
+package api
+
+import (
+	"Report-Storage/internal/letters"
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/pdf"
+	"Report-Storage/internal/storage"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxLetterPhoto - максимальный размер фотографии, встраиваемой в письмо.
+const maxLetterPhoto = 10 << 20
+
+// LetterSource - интерфейс для получения данных письма по заявке.
+type LetterSource interface {
+	ReportByNum(context.Context, int) (storage.Report, error)
+	OrganizationByID(context.Context, string) (storage.Organization, error)
+	TemplateByID(context.Context, string) (storage.Template, error)
+}
+
+// FileDownloader - интерфейс для чтения медиа файлов из хранилища.
+type FileDownloader interface {
+	Download(ctx context.Context, key string, max int64) ([]byte, error)
+}
+
+// Letter обрабатывает запрос на формирование официального письма по заявке
+// в формате PDF. Шаблон задается его ObjectID в query параметре template,
+// без параметра используется шаблон по умолчанию. В письмо встраиваются
+// фотографии заявки, файлы, которые не удалось прочитать, пропускаются.
+func Letter(l *slog.Logger, st LetterSource, files FileDownloader, font *pdf.Font) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Letter"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to generate report letter")
+
+		// Получение параметров запроса.
+		num, err := number(r)
+		if err != nil {
+			log.Error("invalid report number", logger.Err(err))
+			http.Error(w, "invalid report number", http.StatusBadRequest)
+			return
+		}
+
+		// Запрос шаблона в базу данных.
+		tpl := letters.Default
+		if id := r.URL.Query().Get("template"); id != "" {
+			tpl, err = st.TemplateByID(r.Context(), id)
+			if err != nil {
+				log.Error("cannot find letter template", logger.Err(err))
+				switch {
+				case errors.Is(err, storage.ErrIncorrectID):
+					http.Error(w, "invalid template id", http.StatusBadRequest)
+				case errors.Is(err, storage.ErrTemplateNotFound):
+					http.Error(w, "template not found", http.StatusNotFound)
+				default:
+					http.Error(w, "internal error", http.StatusInternalServerError)
+				}
+				return
+			}
+		}
+
+		// Запрос заявки и ответственной организации в базу данных.
+		report, err := st.ReportByNum(r.Context(), num)
+		if err != nil {
+			log.Error("cannot find report", logger.Err(err))
+			if errors.Is(err, storage.ErrReportNotFound) {
+				http.Error(w, "report not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		var org storage.Organization
+		if report.AssigneeOrg != nil {
+			org, err = st.OrganizationByID(r.Context(), report.AssigneeOrg.Hex())
+			if err != nil && !errors.Is(err, storage.ErrOrgNotFound) {
+				log.Error("cannot find assignee organization", logger.Err(err))
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		// Загрузка фотографий заявки из хранилища.
+		var photos [][]byte
+		for _, m := range report.Media {
+			if m.Kind == storage.Video {
+				continue
+			}
+			data, err := files.Download(r.Context(), m.Name(), maxLetterPhoto)
+			if err == nil {
+				err = pdf.CheckJPEG(data)
+			}
+			if err != nil {
+				log.Warn("photo skipped", slog.String("file", m.Name()), logger.Err(err))
+				continue
+			}
+			photos = append(photos, data)
+		}
+
+		// Формирование письма.
+		buf := new(bytes.Buffer)
+		data := letters.NewData(report, org, time.Now())
+		if err := letters.Render(buf, font, tpl, data, photos); err != nil {
+			log.Error("cannot render letter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Запись ответа.
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"letter-%d.pdf\"", num))
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+		if _, err := buf.WriteTo(w); err != nil {
+			log.Error("cannot write letter", logger.Err(err))
+			return
+		}
+		log.Debug("letter sent successfully", slog.Int("photos", len(photos)))
+	}
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"Report-Storage/internal/pdf"
+	"Report-Storage/internal/storage"
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// letterStub возвращает заявку report, шаблон tpl и любую организацию.
+type letterStub struct {
+	report storage.Report
+	tpl    storage.Template
+}
+
+func (s letterStub) ReportByNum(_ context.Context, num int) (storage.Report, error) {
+	if int64(num) != s.report.Number {
+		return storage.Report{}, storage.ErrReportNotFound
+	}
+	return s.report, nil
+}
+
+func (s letterStub) OrganizationByID(_ context.Context, id string) (storage.Organization, error) {
+	return storage.Organization{Name: "Управа района"}, nil
+}
+
+func (s letterStub) TemplateByID(_ context.Context, id string) (storage.Template, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return storage.Template{}, storage.ErrIncorrectID
+	}
+	if id != s.tpl.ID.Hex() {
+		return storage.Template{}, storage.ErrTemplateNotFound
+	}
+	return s.tpl, nil
+}
+
+// filesStub возвращает содержимое файлов по именам.
+type filesStub map[string][]byte
+
+func (f filesStub) Download(_ context.Context, key string, _ int64) ([]byte, error) {
+	data, ok := f[key]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
+
+func TestLetter(t *testing.T) {
+	font, err := pdf.ParseFont(goregular.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	photo := new(bytes.Buffer)
+	if err := jpeg.Encode(photo, image.NewRGBA(image.Rect(0, 0, 64, 48)), nil); err != nil {
+		t.Fatal(err)
+	}
+	org := primitive.NewObjectID()
+	st := letterStub{
+		report: storage.Report{
+			Number:      1,
+			City:        "Москва",
+			Address:     "ул. Тверская, 1",
+			Description: "Открытый люк",
+			Media: []storage.Media{
+				{URL: "https://s3/a.jpg", Kind: storage.Photo},
+				{URL: "https://s3/b.jpg", Kind: storage.Photo},
+				{URL: "https://s3/c.mp4", Kind: storage.Video},
+				{URL: "https://s3/d.jpg", Kind: storage.Photo},
+			},
+			AssigneeOrg: &org,
+		},
+		tpl: storage.Template{ID: primitive.NewObjectID(), Title: "Письмо {{.Number}}", Body: "{{.Organization}}"},
+	}
+	files := filesStub{"a.jpg": photo.Bytes(), "b.jpg": []byte("not a jpeg")}
+	h := Letter(slog.New(slog.NewTextHandler(io.Discard, nil)), st, files, font)
+
+	tests := []struct {
+		name   string
+		num    string
+		query  string
+		want   int
+		images int
+	}{
+		{name: "OK Default template", num: "1", want: 200, images: 1},
+		{name: "OK Template", num: "1", query: "?template=" + st.tpl.ID.Hex(), want: 200, images: 1},
+		{name: "Error Number", num: "abc", want: 400},
+		{name: "Error Template id", num: "1", query: "?template=123", want: 400},
+		{name: "Error Template not found", num: "1", query: "?template=" + primitive.NewObjectID().Hex(), want: 404},
+		{name: "Error Report not found", num: "2", want: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/reports/"+tt.num+"/letter.pdf"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("num", tt.num)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rec := httptest.NewRecorder()
+			h(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("Letter() code = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want != 200 {
+				return
+			}
+			body := rec.Body.String()
+			if rec.Header().Get("Content-Type") != "application/pdf" || !strings.HasPrefix(body, "%PDF-") {
+				t.Errorf("Letter() response is not a PDF document")
+			}
+			if got := strings.Count(body, "/Subtype /Image"); got != tt.images {
+				t.Errorf("Letter() images = %d, want %d", got, tt.images)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"Report-Storage/internal/jobs"
+	"Report-Storage/internal/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// MediaCollector - интерфейс для запуска удаления неиспользуемых файлов.
+type MediaCollector interface {
+	Run(ctx context.Context, dryRun bool) (jobs.GCResult, error)
+}
+
+// MediaGC обрабатывает запрос на удаление файлов хранилища, на которые
+// не ссылается ни одна заявка. Удаление выполняется в фоне как запуск
+// задачи jobs.MediaGCJob планировщика sch, в ответе с кодом 202
+// возвращается начатый запуск, а найденные и удаленные файлы доступны
+// в истории запусков задачи. С параметром dry_run=true файлы не удаляются.
+func MediaGC(l *slog.Logger, gc MediaCollector, sch JobRunner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.MediaGC"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to collect orphaned media")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		var dryRun bool
+		if s := r.URL.Query().Get("dry_run"); s != "" {
+			var err error
+			dryRun, err = strconv.ParseBool(s)
+			if err != nil {
+				log.Error("incorrect dry_run param", slog.String("dry_run", s))
+				http.Error(w, "incorrect dry_run param", http.StatusBadRequest)
+				return
+			}
+		}
+
+		// Запуск задачи. Задача не прерывается при отключении клиента.
+		run, err := sch.LaunchFunc(r.Context(), jobs.MediaGCJob, func(ctx context.Context) (any, error) {
+			return gc.Run(ctx, dryRun)
+		})
+		if err != nil {
+			log.Error("cannot collect orphaned media", logger.Err(err))
+			if errors.Is(err, jobs.ErrRunning) {
+				http.Error(w, "garbage collection is already running", http.StatusConflict)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		w.WriteHeader(http.StatusAccepted)
+		err = json.NewEncoder(w).Encode(run)
+		if err != nil {
+			log.Error("cannot encode garbage collection run", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("garbage collection run sent successfully")
+	}
+}
@@ -1,13 +1,18 @@
 package api
 
 import (
+	"Report-Storage/internal/logger"
 	"Report-Storage/internal/storage"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
 )
 
 // splitStatus преобразует строку с числами из query параметра
@@ -57,44 +62,202 @@ func objectID(r *http.Request) (string, error) {
 	return obj, nil
 }
 
-// point получает значения координат из query параметров x и y,
-// и значение радиуса в метрах из query параметра r. Возвращает
-// структуру точки и радиус.
-func point(r *http.Request) (storage.Geo, int, error) {
+// position получает значения координат точки из query параметров x и y.
+// В первой версии API x - широта, y - долгота, во второй версии наоборот.
+func position(r *http.Request) (storage.Geo, error) {
 	var position storage.Geo
 
 	xParam := r.URL.Query().Get("x")
 	yParam := r.URL.Query().Get("y")
-	rParam := r.URL.Query().Get("r")
 
 	if xParam == "" {
-		return position, 0, fmt.Errorf("empty X parameter")
+		return position, fmt.Errorf("empty X parameter")
 	}
 	if yParam == "" {
-		return position, 0, fmt.Errorf("empty Y parameter")
-	}
-	if rParam == "" {
-		return position, 0, fmt.Errorf("empty R parameter")
+		return position, fmt.Errorf("empty Y parameter")
 	}
 
 	x, err := strconv.ParseFloat(xParam, 64)
 	if err != nil {
-		return position, 0, fmt.Errorf("failed to parse X: %w", err)
+		return position, fmt.Errorf("failed to parse X: %w", err)
 	}
 	y, err := strconv.ParseFloat(yParam, 64)
 	if err != nil {
-		return position, 0, fmt.Errorf("failed to parse Y: %w", err)
+		return position, fmt.Errorf("failed to parse Y: %w", err)
+	}
+
+	position.Type = "Point"
+	position.Coordinates = coordOrder(r).LonLat([2]float64{x, y})
+	return position, nil
+}
+
+// bbox получает область карты из query параметра bbox в формате
+// x1,y1,x2,y2, где x1,y1 - юго-западный угол, x2,y2 - северо-восточный.
+// Порядок координат в паре такой же, как в параметрах x и y.
+func bbox(r *http.Request) (storage.BBox, error) {
+	var box storage.BBox
+
+	param := r.URL.Query().Get("bbox")
+	if param == "" {
+		return box, fmt.Errorf("empty bbox parameter")
+	}
+	parts := strings.Split(param, ",")
+	if len(parts) != 4 {
+		return box, fmt.Errorf("bbox must have 4 values, got %d", len(parts))
+	}
+
+	var v [4]float64
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return box, fmt.Errorf("failed to parse bbox value %d: %w", i, err)
+		}
+		v[i] = f
+	}
+
+	order := coordOrder(r)
+	box.Min = order.LonLat([2]float64{v[0], v[1]})
+	box.Max = order.LonLat([2]float64{v[2], v[3]})
+	return box, box.Validate()
+}
+
+// poly получает многоугольник из query параметра poly в формате
+// x1,y1,x2,y2,... с порядком координат как в параметрах x и y. Кольцо
+// замыкается автоматически, если последняя вершина не совпадает с первой.
+// Пустой параметр возвращается как nil.
+func poly(r *http.Request) (*storage.Area, error) {
+	param := r.URL.Query().Get("poly")
+	if param == "" {
+		return nil, nil
+	}
+	parts := strings.Split(param, ",")
+	if len(parts)%2 != 0 {
+		return nil, fmt.Errorf("poly must have an even number of values, got %d", len(parts))
+	}
+
+	order := coordOrder(r)
+	ring := make([][2]float64, 0, len(parts)/2+1)
+	for i := 0; i < len(parts); i += 2 {
+		var v [2]float64
+		for j := range v {
+			f, err := strconv.ParseFloat(strings.TrimSpace(parts[i+j]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse poly value %d: %w", i+j, err)
+			}
+			v[j] = f
+		}
+		ring = append(ring, order.LonLat(v))
+	}
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		ring = append(ring, ring[0])
+	}
+
+	area := &storage.Area{Type: storage.MultiPolygon, Coordinates: [][][][2]float64{{ring}}}
+	return area, area.Validate()
+}
+
+// reportFilter получает параметры фильтра заявок из query параметров:
+// статусы status, территорию district, город city, интервал времени
+// создания from и to, многоугольник poly, а также количество и порядок
+// сортировки.
+func reportFilter(r *http.Request) (storage.Filter, error) {
+	filter := storage.Filter{
+		Count:    count(r),
+		Sort:     sort(r),
+		Status:   splitStatus(r.URL.Query().Get("status")),
+		District: r.URL.Query().Get("district"),
+		City:     r.URL.Query().Get("city"),
+	}
+
+	var err error
+	filter.From, filter.To, err = period(r)
+	if err != nil {
+		return filter, err
+	}
+	filter.Area, err = poly(r)
+	return filter, err
+}
+
+// dateLayout - формат даты без времени в query параметрах.
+const dateLayout = "2006-01-02"
+
+// period получает интервал времени [from, to) из query параметров from
+// и to. Значения задаются в формате RFC 3339 или датой ГГГГ-ММ-ДД, дата
+// в параметре to включается в интервал целиком. Пустой параметр
+// возвращается нулевым временем и не ограничивает интервал.
+func period(r *http.Request) (time.Time, time.Time, error) {
+	parse := func(name string, day time.Duration) (time.Time, error) {
+		v := r.URL.Query().Get(name)
+		if v == "" {
+			return time.Time{}, nil
+		}
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return t, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		return t.Add(day), nil
+	}
+
+	from, err := parse("from", 0)
+	if err != nil {
+		return from, from, err
+	}
+	to, err := parse("to", time.Hour*24)
+	if err != nil {
+		return from, to, err
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return from, to, fmt.Errorf("from must be before to")
+	}
+	return from, to, nil
+}
+
+// point получает значения координат из query параметров x и y,
+// и значение радиуса в метрах из query параметра r. Возвращает
+// структуру точки и радиус.
+func point(r *http.Request) (storage.Geo, int, error) {
+	position, err := position(r)
+	if err != nil {
+		return position, 0, err
+	}
+
+	rParam := r.URL.Query().Get("r")
+	if rParam == "" {
+		return position, 0, fmt.Errorf("empty R parameter")
 	}
 	radius, err := strconv.Atoi(rParam)
 	if err != nil {
 		return position, 0, fmt.Errorf("failed to parse radius: %w", err)
 	}
+	if radius < 1 {
+		return position, 0, fmt.Errorf("radius less than 1")
+	}
 
-	position.Type = "Point"
-	position.Coordinates = [2]float64{x, y}
 	return position, radius, nil
 }
 
+// limit получает значение из query параметра с именем name. Пустой
+// параметр означает отсутствие ограничения и возвращается как 0.
+// Значение должно быть в пределах от 1 до max.
+func limit(r *http.Request, name string, max int) (int, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	if n < 1 || n > max {
+		return 0, fmt.Errorf("%s parameter out of range [1, %d]", name, max)
+	}
+	return n, nil
+}
+
 // count получает значение из query параметра n и, если оно корректно,
 // возвращает его. Иначе возвращает значение по умолчанию.
 func count(r *http.Request) int {
@@ -151,20 +314,65 @@ func newStatus(r *http.Request) (storage.Status, error) {
 	return status, nil
 }
 
-// statusString возвращает строковое представление статуса заявки.
-func statusString(s storage.Status) string {
-	switch s {
-	case 1:
-		return "Неподтверждена"
-	case 2:
-		return "Создана"
-	case 3:
-		return "В работе"
-	case 4:
-		return "Завершена"
-	case 5:
-		return "Отклонена"
-	default:
+// errNotMultipart - ошибка некорректного заголовка Content-Type.
+var errNotMultipart = errors.New("content-type is not multipart/form-data")
+
+// parseMultipart проверяет заголовок Content-Type запроса на значение
+// multipart/form-data, ограничивает размер тела запроса значением maxMemory
+// и разбирает multipart форму. Если заголовок некорректен, то возвращает
+// ошибку errNotMultipart.
+func parseMultipart(w http.ResponseWriter, r *http.Request) error {
+	ct := strings.ToLower(r.Header.Get("Content-Type"))
+	if !strings.Contains(ct, "multipart/form-data") {
+		return errNotMultipart
+	}
+
+	// Суммарный размер всех загружаемых файлов не более 30 Мб.
+	r.Body = http.MaxBytesReader(w, r.Body, maxMemory)
+	return r.ParseMultipartForm(maxMemory + 512)
+}
+
+// fileName получает значение параметра name из url запроса.
+func fileName(r *http.Request) (string, error) {
+	name := chi.URLParam(r, "name")
+	if name == "" || strings.Contains(name, "/") {
+		return "", fmt.Errorf("incorrect file name parameter")
+	}
+	return name, nil
+}
+
+// subject возвращает идентификатор пользователя из claim "sub" JWT токена
+// запроса. Если токен или claim отсутствуют, то возвращает пустую строку.
+func subject(r *http.Request) string {
+	_, claims, err := jwtauth.FromContext(r.Context())
+	if err != nil {
 		return ""
 	}
+	sub, _ := claims["sub"].(string)
+	return sub
+}
+
+// districtError записывает в ответ ошибку, связанную с параметром
+// территории: 400 для некорректного ObjectID и 404 для несуществующей
+// территории. Возвращает true, если ответ записан.
+func districtError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, storage.ErrIncorrectID):
+		http.Error(w, "invalid district id", http.StatusBadRequest)
+		return true
+	case errors.Is(err, storage.ErrJurisdictionNotFound):
+		http.Error(w, "district not found", http.StatusNotFound)
+		return true
+	}
+	return false
+}
+
+// extendDeadline продлевает срок записи ответа на d от текущего момента
+// для обработчиков, которые выполняются дольше таймаута записи сервера.
+// Ошибка только логируется, так как ответ можно записать и без продления.
+func extendDeadline(w http.ResponseWriter, log *slog.Logger, d time.Duration) {
+	err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(d))
+	if err != nil {
+		log.Warn("cannot extend write deadline", logger.Err(err))
+	}
 }
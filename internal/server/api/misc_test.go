@@ -2,8 +2,11 @@ package api
 
 import (
 	"Report-Storage/internal/storage"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_splitStatus(t *testing.T) {
@@ -51,3 +54,154 @@ func Test_splitStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_limit(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    int
+		wantErr bool
+	}{
+		{name: "OK Empty", url: "/api/reports/radius", want: 0},
+		{name: "OK Value", url: "/api/reports/radius?limit=10", want: 10},
+		{name: "Error Zero", url: "/api/reports/radius?limit=0", wantErr: true},
+		{name: "Error Greater than max", url: "/api/reports/radius?limit=501", wantErr: true},
+		{name: "Error Incorrect", url: "/api/reports/radius?limit=asdf", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := limit(httptest.NewRequest("GET", tt.url, nil), "limit", maxNear)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("limit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("limit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_bbox(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *http.Request
+		want    storage.BBox
+		wantErr bool
+	}{
+		{
+			name: "OK Legacy order",
+			req:  httptest.NewRequest("GET", "/api/reports/clusters?bbox=55.7,37.6,55.8,37.7", nil),
+			want: storage.BBox{Min: storage.LonLat{37.6, 55.7}, Max: storage.LonLat{37.7, 55.8}},
+		},
+		{
+			name: "OK V2 order",
+			req:  v2Request(httptest.NewRequest("GET", "/api/v2/reports/clusters?bbox=37.6,55.7,37.7,55.8", nil)),
+			want: storage.BBox{Min: storage.LonLat{37.6, 55.7}, Max: storage.LonLat{37.7, 55.8}},
+		},
+		{
+			name:    "Error Three values",
+			req:     httptest.NewRequest("GET", "/api/reports/clusters?bbox=55.7,37.6,55.8", nil),
+			wantErr: true,
+		},
+		{
+			name:    "Error Swapped corners",
+			req:     httptest.NewRequest("GET", "/api/reports/clusters?bbox=55.8,37.7,55.7,37.6", nil),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bbox(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bbox() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("bbox() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_poly(t *testing.T) {
+	ring := [][2]float64{{37.6, 55.7}, {37.7, 55.7}, {37.7, 55.8}, {37.6, 55.7}}
+	tests := []struct {
+		name    string
+		req     *http.Request
+		want    [][2]float64
+		wantErr bool
+	}{
+		{name: "OK Empty", req: httptest.NewRequest("GET", "/api/reports/filter", nil)},
+		{
+			name: "OK Legacy order closes ring",
+			req:  httptest.NewRequest("GET", "/api/reports/filter?poly=55.7,37.6,55.7,37.7,55.8,37.7", nil),
+			want: ring,
+		},
+		{
+			name: "OK V2 order",
+			req:  v2Request(httptest.NewRequest("GET", "/api/v2/reports/filter?poly=37.6,55.7,37.7,55.7,37.7,55.8,37.6,55.7", nil)),
+			want: ring,
+		},
+		{
+			name:    "Error Odd values",
+			req:     httptest.NewRequest("GET", "/api/reports/filter?poly=55.7,37.6,55.7", nil),
+			wantErr: true,
+		},
+		{
+			name:    "Error Two vertices",
+			req:     httptest.NewRequest("GET", "/api/reports/filter?poly=55.7,37.6,55.8,37.7", nil),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := poly(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("poly() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("poly() = %v, want nil", got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got.Coordinates, [][][][2]float64{{tt.want}}) {
+				t.Errorf("poly() = %v, want %v", got.Coordinates, tt.want)
+			}
+		})
+	}
+}
+
+func Test_period(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		url      string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{name: "OK Empty", url: "/api/reports/heatmap"},
+		{name: "OK Dates", url: "/api/reports/heatmap?from=2024-03-01&to=2024-03-01", wantFrom: day, wantTo: day.Add(time.Hour * 24)},
+		{name: "OK RFC3339", url: "/api/reports/heatmap?to=2024-03-01T00:00:00Z", wantTo: day},
+		{name: "Error Format", url: "/api/reports/heatmap?from=01.03.2024", wantErr: true},
+		{name: "Error Reversed", url: "/api/reports/heatmap?from=2024-03-02&to=2024-03-01T00:00:00Z", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := period(httptest.NewRequest("GET", tt.url, nil))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("period() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (!from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo)) {
+				t.Errorf("period() = %v, %v, want %v, %v", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
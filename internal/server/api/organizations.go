@@ -0,0 +1,52 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// OrganizationsRetriever - интерфейс для получения всех организаций.
+type OrganizationsRetriever interface {
+	Organizations(ctx context.Context) ([]storage.Organization, error)
+}
+
+// Organizations обрабатывает запрос на получение всех организаций
+// и их сотрудников.
+func Organizations(l *slog.Logger, st OrganizationsRetriever) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Organizations"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive organizations")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Запрос в базу данных.
+		orgs, err := st.Organizations(r.Context())
+		if err != nil {
+			log.Error("cannot receive organizations", logger.Err(err))
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no organizations found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = json.NewEncoder(w).Encode(orgs)
+		if err != nil {
+			log.Error("cannot encode organizations to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("organizations encoded and sent successfully")
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// OverdueFinder - интерфейс для получения просроченных заявок.
+type OverdueFinder interface {
+	Overdue(ctx context.Context, rules []storage.SLARule, now time.Time) ([]storage.Report, error)
+}
+
+// Overdue обрабатывает запрос на получение заявок, которые находятся
+// в текущем статусе дольше сроков, заданных правилами rules.
+func Overdue(l *slog.Logger, st OverdueFinder, rules []storage.SLARule) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Overdue"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive overdue reports")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Запрос в базу данных.
+		reports, err := st.Overdue(r.Context(), rules, time.Now())
+		if err != nil {
+			log.Error("cannot receive overdue reports", logger.Err(err))
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no reports found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = encodeReports(w, r, reports)
+		if err != nil {
+			log.Error("cannot encode reports to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("overdue reports encoded and sent successfully")
+	}
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// leaderboardSize - количество городов в рейтинге публичной статистики.
+const leaderboardSize = 10
+
+// publicStatistic - общедоступная статистика заявок без персональных
+// данных и сведений о работе организаций.
+type publicStatistic struct {
+	// Totals содержит количество заявок по статусам.
+	Totals storage.Statistic `json:"totals"`
+	// FixedThisMonth содержит количество заявок, закрытых с начала
+	// текущего месяца.
+	FixedThisMonth int `json:"fixed_this_month"`
+	// Cities содержит города с наибольшим количеством заявок.
+	Cities []cityStatistic `json:"cities"`
+	// Updated содержит время расчета статистики.
+	Updated time.Time `json:"updated"`
+}
+
+// cityStatistic - строка рейтинга городов.
+type cityStatistic struct {
+	City     string `json:"city"`
+	Reported int    `json:"reported"`
+	Fixed    int    `json:"fixed"`
+}
+
+// tmPublicStat - таймаут на расчет публичной статистики.
+const tmPublicStat = time.Second * 30
+
+// PublicStatisticRetriever - интерфейс для получения публичной статистики.
+type PublicStatisticRetriever interface {
+	DetailedStatistic(ctx context.Context, q storage.StatQuery) (storage.StatReport, error)
+	ClosedCount(ctx context.Context, from time.Time) (int, error)
+}
+
+// PublicStatistic обрабатывает запрос общедоступной статистики: количество
+// заявок по статусам, количество закрытых в текущем месяце заявок и рейтинг
+// городов. Закрытые заявки учитываются и после переноса в архив. Ответ рассчитывается не чаще одного раза за время ttl, в течение
+// которого возвращается сохраненный результат. Расчет не прерывается при
+// отключении клиента, а если он завершился ошибкой, то возвращается
+// последний рассчитанный результат.
+func PublicStatistic(l *slog.Logger, st PublicStatisticRetriever, ttl time.Duration) http.HandlerFunc {
+	cache := &publicCache{st: st, ttl: ttl}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.PublicStatistic"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive public statistics")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение сохраненной или рассчитанной статистики.
+		data, hit := cache.get(r.Context(), log)
+		if data == nil {
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Запись ответа.
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(ttl.Seconds())))
+		if _, err := w.Write(data); err != nil {
+			log.Error("cannot write public statistics", logger.Err(err))
+			return
+		}
+		log.Debug("public statistics sent successfully", slog.Bool("cached", hit))
+	}
+}
+
+// publicCache - сохраненный ответ публичной статистики.
+type publicCache struct {
+	st  PublicStatisticRetriever
+	ttl time.Duration
+
+	// mu защищает data и expires, refresh разрешает только один
+	// одновременный расчет.
+	mu      sync.Mutex
+	refresh sync.Mutex
+	data    []byte
+	expires time.Time
+}
+
+// load возвращает сохраненный ответ и признак того, что он не устарел.
+func (c *publicCache) load() ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data, time.Now().Before(c.expires)
+}
+
+// get возвращает ответ и признак того, что он взят из кэша. Устаревший
+// ответ пересчитывается одним запросом, остальные в это время получают
+// устаревший ответ, а если его нет, то ожидают окончания расчета. Если
+// расчет завершился ошибкой и сохраненного ответа нет, то вернет nil.
+func (c *publicCache) get(ctx context.Context, log *slog.Logger) ([]byte, bool) {
+	data, hit := c.load()
+	if hit {
+		return data, true
+	}
+	if data == nil {
+		c.refresh.Lock()
+	} else if !c.refresh.TryLock() {
+		return data, true
+	}
+	defer c.refresh.Unlock()
+
+	// Ответ мог быть рассчитан другим запросом, пока этот ожидал.
+	data, hit = c.load()
+	if hit {
+		return data, true
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tmPublicStat)
+	defer cancel()
+	stats, err := publicStats(ctx, c.st)
+	if err != nil {
+		log.Error("cannot retrieve public statistics", logger.Err(err), slog.Bool("stale", data != nil))
+		return data, true
+	}
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(stats); err != nil {
+		log.Error("cannot encode public statistics", logger.Err(err))
+		return data, true
+	}
+
+	c.mu.Lock()
+	c.data, c.expires = buf.Bytes(), time.Now().Add(c.ttl)
+	c.mu.Unlock()
+	return buf.Bytes(), false
+}
+
+// publicStats рассчитывает публичную статистику.
+func publicStats(ctx context.Context, st PublicStatisticRetriever) (publicStatistic, error) {
+	now := time.Now()
+	stats := publicStatistic{Updated: now}
+
+	all, err := st.DetailedStatistic(ctx, storage.StatQuery{By: storage.ByCity})
+	if err != nil {
+		return stats, err
+	}
+	stats.Totals = all.Statistic
+	stats.Cities = make([]cityStatistic, 0, leaderboardSize)
+	for _, b := range all.Breakdown {
+		if len(stats.Cities) == leaderboardSize {
+			break
+		}
+		if b.Key == "" {
+			continue
+		}
+		stats.Cities = append(stats.Cities, cityStatistic{City: b.Key, Reported: b.Statistic.Total, Fixed: b.Statistic.Closed})
+	}
+
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	stats.FixedThisMonth, err = st.ClosedCount(ctx, month)
+	if err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// statStub подсчитывает обращения к статистике и возвращает ошибку,
+// если задано поле err.
+type statStub struct {
+	calls int
+	err   error
+}
+
+func (s *statStub) DetailedStatistic(ctx context.Context, q storage.StatQuery) (storage.StatReport, error) {
+	s.calls++
+	if err := ctx.Err(); err != nil {
+		return storage.StatReport{}, err
+	}
+	if s.err != nil {
+		return storage.StatReport{}, s.err
+	}
+	return storage.StatReport{
+		Statistic: storage.Statistic{Total: 5, Closed: 3},
+		Breakdown: []storage.Breakdown{
+			{Key: "Москва", Statistic: storage.Statistic{Total: 4, Closed: 3}},
+			{Key: "", Statistic: storage.Statistic{Total: 1}},
+		},
+	}, nil
+}
+
+func (s *statStub) ClosedCount(context.Context, time.Time) (int, error) {
+	s.calls++
+	return 2, nil
+}
+
+func TestPublicStatistic(t *testing.T) {
+	st := new(statStub)
+	l := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := PublicStatistic(l, st, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		h(rec, httptest.NewRequest("GET", "/api/reports/statistic/public", nil))
+		if rec.Code != 200 {
+			t.Fatalf("PublicStatistic() code = %d", rec.Code)
+		}
+		var got publicStatistic
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("PublicStatistic() error = %v", err)
+		}
+		if got.Totals.Total != 5 || got.FixedThisMonth != 2 {
+			t.Errorf("PublicStatistic() = %+v", got)
+		}
+		if len(got.Cities) != 1 || got.Cities[0] != (cityStatistic{City: "Москва", Reported: 4, Fixed: 3}) {
+			t.Errorf("PublicStatistic() cities = %+v", got.Cities)
+		}
+	}
+	if st.calls != 2 {
+		t.Errorf("PublicStatistic() storage calls = %d, want 2", st.calls)
+	}
+}
+
+func TestPublicStatistic_Stale(t *testing.T) {
+	st := new(statStub)
+	l := slog.New(slog.NewTextHandler(io.Discard, nil))
+	// Нулевой срок хранения: каждый запрос пересчитывает статистику.
+	h := PublicStatistic(l, st, 0)
+
+	// Отключение клиента не прерывает расчет.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/api/reports/statistic/public", nil).WithContext(ctx))
+	if rec.Code != 200 {
+		t.Fatalf("PublicStatistic() canceled request code = %d", rec.Code)
+	}
+
+	// При ошибке расчета возвращается последний результат.
+	st.err = errors.New("database is unavailable")
+	rec = httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/api/reports/statistic/public", nil))
+	if rec.Code != 200 {
+		t.Fatalf("PublicStatistic() stale code = %d", rec.Code)
+	}
+	var got publicStatistic
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("PublicStatistic() error = %v", err)
+	}
+	if got.Totals.Total != 5 {
+		t.Errorf("PublicStatistic() stale = %+v", got)
+	}
+
+	// Без сохраненного результата ошибка возвращается клиенту.
+	h = PublicStatistic(l, st, 0)
+	rec = httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/api/reports/statistic/public", nil))
+	if rec.Code != 500 {
+		t.Errorf("PublicStatistic() code = %d, want 500", rec.Code)
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/reports"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// MediaRemover - интерфейс для удаления медиа файла из заявки.
+type MediaRemover interface {
+	RemoveMedia(ctx context.Context, num int, name string) (storage.Media, int, error)
+	RestoreMedia(ctx context.Context, num int, pos int, media storage.Media) error
+}
+
+// RemoveMedia обрабатывает запрос на удаление медиа файла из заявки по
+// имени файла. Файл удаляется из заявки, затем из объектного хранилища.
+// Если удалить файл из хранилища не удалось, то файл возвращается в
+// заявку на прежнее место, чтобы БД и хранилище оставались согласованными.
+// Удаление из хранилища и возврат не прерываются отключением клиента.
+func RemoveMedia(l *slog.Logger, st MediaRemover, s3 reports.FileSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.RemoveMedia"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to remove report media")
+
+		// Получение параметров запроса.
+		num, err := number(r)
+		if err != nil {
+			log.Error("invalid report number", logger.Err(err))
+			http.Error(w, "invalid report number", http.StatusBadRequest)
+			return
+		}
+		name, err := fileName(r)
+		if err != nil {
+			log.Error("invalid file name", logger.Err(err))
+			http.Error(w, "invalid file name", http.StatusBadRequest)
+			return
+		}
+
+		// Удаление файла из заявки.
+		media, pos, err := st.RemoveMedia(r.Context(), num, name)
+		if err != nil {
+			log.Error("cannot remove media from report", logger.Err(err))
+			if errors.Is(err, storage.ErrReportNotFound) {
+				http.Error(w, "report not found", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, storage.ErrMediaNotFound) {
+				http.Error(w, "media file not found", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, storage.ErrMediaLimit) {
+				http.Error(w, "cannot remove the last media file", http.StatusConflict)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Удаление файлов из хранилища. Сначала удаляем превью, затем сам
+		// файл, чтобы при ошибке в заявку можно было вернуть рабочий файл.
+		// Файл уже удален из заявки, поэтому отмена запроса не должна
+		// оставлять БД и хранилище несогласованными.
+		ctx := context.WithoutCancel(r.Context())
+		if media.Poster != "" {
+			if err := s3.Remove(ctx, media.Poster); err != nil {
+				log.Error("cannot remove poster from s3", logger.Err(err))
+				rollbackMedia(ctx, log, st, num, pos, media)
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return
+			}
+		}
+		if err := s3.Remove(ctx, media.URL); err != nil {
+			log.Error("cannot remove file from s3", logger.Err(err))
+			media.Poster = ""
+			rollbackMedia(ctx, log, st, num, pos, media)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Запись кода ответа.
+		w.WriteHeader(http.StatusNoContent)
+		log.Debug("report media removed successfully")
+	}
+}
+
+// rollbackMedia возвращает медиа файл в заявку на позицию pos после
+// неудачного удаления из хранилища.
+func rollbackMedia(ctx context.Context, log *slog.Logger, st MediaRemover, num, pos int, media storage.Media) {
+	err := st.RestoreMedia(ctx, num, pos, media)
+	if err != nil {
+		log.Error("cannot return media to report", slog.String("url", media.URL), logger.Err(err))
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// mediaOrder - структура тела запроса с новым порядком медиа файлов.
+type mediaOrder struct {
+	Order []string `json:"order"`
+}
+
+// MediaReorderer - интерфейс для изменения порядка медиа файлов заявки.
+type MediaReorderer interface {
+	ReorderMedia(ctx context.Context, num int, names []string) (storage.Report, error)
+}
+
+// ReorderMedia обрабатывает запрос на изменение порядка медиа файлов
+// заявки. Тело запроса должно содержать имена всех файлов заявки в
+// новом порядке.
+func ReorderMedia(l *slog.Logger, st MediaReorderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.ReorderMedia"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to reorder report media")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		num, err := number(r)
+		if err != nil {
+			log.Error("invalid report number", logger.Err(err))
+			http.Error(w, "invalid report number", http.StatusBadRequest)
+			return
+		}
+
+		// Декодирование JSON из тела запроса.
+		var input mediaOrder
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			log.Error("cannot decode json to media order struct", logger.Err(err))
+			http.Error(w, "invalid request JSON", http.StatusBadRequest)
+			return
+		}
+
+		// Запрос в базу данных.
+		report, err := st.ReorderMedia(r.Context(), num, input.Order)
+		if err != nil {
+			log.Error("cannot reorder report media", logger.Err(err))
+			if errors.Is(err, storage.ErrReportNotFound) {
+				http.Error(w, "report not found", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, storage.ErrIncorrectMedia) {
+				http.Error(w, "incorrect media list", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = encodeReport(w, r, report)
+		if err != nil {
+			log.Error("cannot encode report", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("report media reordered successfully")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"Report-Storage/internal/logger"
 	"Report-Storage/internal/storage"
 	"context"
-	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -48,7 +47,7 @@ func ReportByNum(l *slog.Logger, st ReportGetter) http.HandlerFunc {
 		}
 
 		// Кодирование ответа в JSON.
-		err = json.NewEncoder(w).Encode(report)
+		err = encodeReport(w, r, report)
 		if err != nil {
 			log.Error("cannot encode report", logger.Err(err))
 			http.Error(w, "internal error", http.StatusInternalServerError)
@@ -4,7 +4,6 @@ import (
 	"Report-Storage/internal/logger"
 	"Report-Storage/internal/storage"
 	"context"
-	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -47,7 +46,7 @@ func Reports(l *slog.Logger, st Reporter) http.HandlerFunc {
 		}
 
 		// Кодирование ответа в JSON.
-		err = json.NewEncoder(w).Encode(reports)
+		err = encodeReports(w, r, reports)
 		if err != nil {
 			log.Error("cannot encode reports to ResponseWriter", logger.Err(err))
 			http.Error(w, "internal error", http.StatusInternalServerError)
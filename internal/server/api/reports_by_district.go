@@ -0,0 +1,63 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// ReportsByDistrictRetriever - интерфейс для получения заявок в границах
+// территории.
+type ReportsByDistrictRetriever interface {
+	ReportsByDistrict(ctx context.Context, id string, status []storage.Status) ([]storage.Report, error)
+}
+
+// ReportsByDistrict обрабатывает запрос на получение заявок в границах
+// территории по ее ObjectID.
+func ReportsByDistrict(l *slog.Logger, st ReportsByDistrictRetriever) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.ReportsByDistrict"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive reports by district")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		id, err := objectID(r)
+		if err != nil {
+			log.Error("invalid district id", logger.Err(err))
+			http.Error(w, "invalid district id", http.StatusBadRequest)
+			return
+		}
+		status := splitStatus(r.URL.Query().Get("status"))
+
+		// Запрос в базу данных.
+		reports, err := st.ReportsByDistrict(r.Context(), id, status)
+		if err != nil {
+			log.Error("failed to get reports by district", logger.Err(err))
+			if districtError(w, err) {
+				return
+			}
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no reports found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		if err := encodeReports(w, r, reports); err != nil {
+			log.Error("cannot encode reports to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("reports by district encoded and sent successfully")
+	}
+}
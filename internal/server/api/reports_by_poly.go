@@ -6,24 +6,30 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 )
 
-// input - структура тела запроса с координатами вершин
-// многоугольника.
+// polygon - структура тела запроса прежнего формата с координатами
+// вершин многоугольника в порядке широта, долгота. Если задано поле
+// Type, то тело запроса является геометрией GeoJSON.
 type polygon struct {
+	Type string       `json:"type"`
 	Quad [][2]float64 `json:"quad"`
 }
 
 // ReportsByPolyInterface - интерфейс для получения заявок
 // в границах многоугольника.
 type ReportsByPolyInterface interface {
-	ReportsByPoly(ctx context.Context, poly [][2]float64, status []storage.Status) ([]storage.Report, error)
+	ReportsByPoly(ctx context.Context, area storage.Area, status []storage.Status) ([]storage.Report, error)
 }
 
 // ReportsByPoly обрабатывает запросы для получения заявок
-// в границах многоугольника.
+// в границах многоугольника. Тело запроса - геометрия GeoJSON типа
+// Polygon или MultiPolygon, либо объект с полем quad прежнего формата.
+// Некорректная геометрия возвращает код 400 с описанием ошибки.
 func ReportsByPoly(l *slog.Logger, st ReportsByPolyInterface) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const operation = "server.api.ReportsByPoly"
@@ -36,17 +42,17 @@ func ReportsByPoly(l *slog.Logger, st ReportsByPolyInterface) http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 
 		// Декодирование JSON из тела запроса.
-		var input polygon
-		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			log.Error("cannot decode json to polygon struct", logger.Err(err))
-			http.Error(w, "invalid request JSON", http.StatusBadRequest)
+		area, err := decodeArea(r)
+		if err != nil {
+			log.Error("cannot decode json to area", logger.Err(err))
+			http.Error(w, "invalid request JSON: "+err.Error(), http.StatusBadRequest)
 			return
 		}
 
-		// Проверка количества вершин многоугольника.
-		if len(input.Quad) < 3 {
-			log.Error("vertex count less than 3")
-			http.Error(w, "invalid request JSON", http.StatusBadRequest)
+		// Проверка геометрии до запроса в базу данных.
+		if err := area.Validate(); err != nil {
+			log.Error("invalid polygon", logger.Err(err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 
@@ -55,7 +61,7 @@ func ReportsByPoly(l *slog.Logger, st ReportsByPolyInterface) http.HandlerFunc {
 		status := splitStatus(statusParam)
 
 		// Запрос в базу данных.
-		reports, err := st.ReportsByPoly(r.Context(), input.Quad, status)
+		reports, err := st.ReportsByPoly(r.Context(), area, status)
 		if err != nil {
 			log.Error("failed to get reports by polygon", logger.Err(err))
 			if errors.Is(err, storage.ErrArrayNotFound) {
@@ -67,7 +73,7 @@ func ReportsByPoly(l *slog.Logger, st ReportsByPolyInterface) http.HandlerFunc {
 		}
 
 		// Кодирование ответа в JSON.
-		if err := json.NewEncoder(w).Encode(reports); err != nil {
+		if err := encodeReports(w, r, reports); err != nil {
 			log.Error("cannot encode reports to ResponseWriter", logger.Err(err))
 			http.Error(w, "internal error", http.StatusInternalServerError)
 			return
@@ -75,3 +81,31 @@ func ReportsByPoly(l *slog.Logger, st ReportsByPolyInterface) http.HandlerFunc {
 		log.Debug("reports by polygon encoded and sent successfully")
 	}
 }
+
+// decodeArea декодирует тело запроса в область. Поддерживаются геометрия
+// GeoJSON и, только в первой версии API, объект с полем quad прежнего
+// формата.
+func decodeArea(r *http.Request) (storage.Area, error) {
+	var area storage.Area
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return area, err
+	}
+	var input polygon
+	if err := json.Unmarshal(b, &input); err != nil {
+		return area, err
+	}
+	if input.Type == "" {
+		if coordOrder(r) != storage.OrderLatLon {
+			return area, fmt.Errorf("quad format is not supported, use GeoJSON geometry")
+		}
+		if len(input.Quad) < 3 {
+			return area, fmt.Errorf("vertex count less than 3")
+		}
+		return storage.QuadArea(input.Quad), nil
+	}
+
+	err = json.Unmarshal(b, &area)
+	return area, err
+}
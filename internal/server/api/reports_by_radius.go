@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -14,11 +13,16 @@ import (
 // ReportsByRadiusInterface - интерфейс для получения заявок
 // в радиусе от точки.
 type ReportsByRadiusInterface interface {
-	ReportsByRadius(ctx context.Context, r int, p storage.Geo, status []storage.Status) ([]storage.Report, error)
+	ReportsByRadius(ctx context.Context, r int, p storage.Geo, limit int, status []storage.Status) ([]storage.NearReport, error)
 }
 
+// maxNear - максимальное количество заявок в ответе на запрос
+// ближайших к точке заявок.
+const maxNear = 500
+
 // ReportsByRadius обрабатывает запрос на получение заявок
-// в радиусе от точки.
+// в радиусе от точки. Заявки возвращаются в порядке удаления
+// от точки вместе с расстоянием до нее в метрах.
 func ReportsByRadius(l *slog.Logger, st ReportsByRadiusInterface) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const operation = "server.api.ReportsInRadius"
@@ -37,11 +41,17 @@ func ReportsByRadius(l *slog.Logger, st ReportsByRadiusInterface) http.HandlerFu
 			http.Error(w, "invalid parameters", http.StatusBadRequest)
 			return
 		}
+		n, err := limit(r, "limit", maxNear)
+		if err != nil {
+			log.Error("failed to get correct limit", logger.Err(err))
+			http.Error(w, "invalid parameters", http.StatusBadRequest)
+			return
+		}
 		s := r.URL.Query().Get("status")
 		status := splitStatus(s)
 
 		// Запрос в базу данных.
-		reports, err := st.ReportsByRadius(r.Context(), radius, position, status)
+		reports, err := st.ReportsByRadius(r.Context(), radius, position, n, status)
 		if err != nil {
 			log.Error("failed to get reports by radius", logger.Err(err))
 			if errors.Is(err, storage.ErrArrayNotFound) {
@@ -53,7 +63,7 @@ func ReportsByRadius(l *slog.Logger, st ReportsByRadiusInterface) http.HandlerFu
 		}
 
 		// Кодирование ответа в JSON.
-		err = json.NewEncoder(w).Encode(reports)
+		err = encodeNearReports(w, r, reports)
 		if err != nil {
 			log.Error("cannot encode reports to ResponseWriter", logger.Err(err))
 			http.Error(w, "internal error", http.StatusInternalServerError)
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// routeRequest - тело запроса заявок вдоль маршрута. Width - полная
+// ширина коридора в метрах.
+type routeRequest struct {
+	Route storage.Route `json:"route"`
+	Width float64       `json:"width" validate:"required,gt=0,max=2000"`
+}
+
+// ReportsByRouteInterface - интерфейс для получения заявок
+// вдоль маршрута.
+type ReportsByRouteInterface interface {
+	ReportsByRoute(ctx context.Context, route storage.Route, width float64, status []storage.Status) ([]storage.Report, error)
+}
+
+// ReportsByRoute обрабатывает запрос на получение заявок в коридоре
+// вдоль маршрута. Маршрут передается в поле route как геометрия GeoJSON
+// типа LineString, ширина коридора в метрах - в поле width. Заявки
+// возвращаются в порядке их положения вдоль маршрута.
+func ReportsByRoute(l *slog.Logger, st ReportsByRouteInterface) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.ReportsByRoute"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive reports by route")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Декодирование JSON из тела запроса.
+		var req routeRequest
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("cannot decode json to route", logger.Err(err))
+			http.Error(w, "invalid request JSON", http.StatusBadRequest)
+			return
+		}
+
+		// Проверка ширины коридора и геометрии маршрута.
+		if err := validator.New().Struct(req); err != nil {
+			log.Error("validation failed", logger.Err(err))
+			http.Error(w, "invalid corridor width", http.StatusBadRequest)
+			return
+		}
+		if err := req.Route.Validate(); err != nil {
+			log.Error("invalid route", logger.Err(err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Получение статусов.
+		statusParam := r.URL.Query().Get("status")
+		status := splitStatus(statusParam)
+
+		// Запрос в базу данных.
+		reports, err := st.ReportsByRoute(r.Context(), req.Route, req.Width, status)
+		if err != nil {
+			log.Error("failed to get reports by route", logger.Err(err))
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no reports found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		if err := encodeReports(w, r, reports); err != nil {
+			log.Error("cannot encode reports to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("reports by route encoded and sent successfully")
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+)
+
+// ReportsNearestInterface - интерфейс для получения ближайших
+// к точке заявок.
+type ReportsNearestInterface interface {
+	ReportsByRadius(ctx context.Context, r int, p storage.Geo, limit int, status []storage.Status) ([]storage.NearReport, error)
+}
+
+// ReportsNearest обрабатывает запрос на получение k ближайших к точке
+// заявок без ограничения по радиусу. Заявки возвращаются в порядке
+// удаления от точки вместе с расстоянием до нее в метрах.
+func ReportsNearest(l *slog.Logger, st ReportsNearestInterface) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.ReportsNearest"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive nearest reports")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		position, err := position(r)
+		if err != nil {
+			log.Error("failed to get correct parameters", logger.Err(err))
+			http.Error(w, "invalid parameters", http.StatusBadRequest)
+			return
+		}
+		k, err := limit(r, "k", maxNear)
+		if err != nil || k == 0 {
+			log.Error("failed to get correct k parameter", logger.Err(err))
+			http.Error(w, "invalid parameters", http.StatusBadRequest)
+			return
+		}
+		s := r.URL.Query().Get("status")
+		status := splitStatus(s)
+
+		// Запрос в базу данных.
+		reports, err := st.ReportsByRadius(r.Context(), 0, position, k, status)
+		if err != nil {
+			log.Error("failed to get nearest reports", logger.Err(err))
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no reports found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = encodeNearReports(w, r, reports)
+		if err != nil {
+			log.Error("cannot encode reports to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("nearest reports encoded and sent successfully")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"Report-Storage/internal/logger"
 	"Report-Storage/internal/storage"
 	"context"
-	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -29,18 +28,20 @@ func ReportsWithFilters(l *slog.Logger, st ReportsFilterer) http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 
 		// Получение параметров запроса.
-		s := r.URL.Query().Get("status")
-		status := splitStatus(s)
-		filter := storage.Filter{
-			Count:  count(r),
-			Sort:   sort(r),
-			Status: status,
+		filter, err := reportFilter(r)
+		if err != nil {
+			log.Error("invalid filter parameters", logger.Err(err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 
 		// Запрос в базу данных.
 		reports, err := st.ReportsWithFilter(r.Context(), filter)
 		if err != nil {
 			log.Error("failed to get reports with filter", logger.Err(err))
+			if districtError(w, err) {
+				return
+			}
 			if errors.Is(err, storage.ErrArrayNotFound) {
 				http.Error(w, "no reports found", http.StatusNotFound)
 				return
@@ -50,7 +51,7 @@ func ReportsWithFilters(l *slog.Logger, st ReportsFilterer) http.HandlerFunc {
 		}
 
 		// Кодирование ответа в JSON.
-		err = json.NewEncoder(w).Encode(reports)
+		err = encodeReports(w, r, reports)
 		if err != nil {
 			log.Error("cannot encode reports to ResponseWriter", logger.Err(err))
 			http.Error(w, "internal error", http.StatusInternalServerError)
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/notifications"
+	"Report-Storage/internal/reports"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// ReportResolver - интерфейс для закрытия заявки.
+type ReportResolver interface {
+	Resolve(ctx context.Context, num int, res storage.Resolution) (storage.Report, error)
+}
+
+// ResolveReport обрабатывает запрос ремонтной бригады на закрытие заявки.
+// Запрос должен быть multipart формой с частью "json", содержащей
+// комментарий, и файлами после ремонта. Статус заявки меняется на Closed,
+// отправителю заявки отправляется уведомление со ссылками на фото.
+func ResolveReport(l *slog.Logger, st ReportResolver, s3 reports.FileSaver, notify *notifications.SMTP) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.ResolveReport"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to resolve report")
+
+		// Получение параметров запроса.
+		num, err := number(r)
+		if err != nil {
+			log.Error("invalid report number", logger.Err(err))
+			http.Error(w, "invalid report number", http.StatusBadRequest)
+			return
+		}
+
+		// Проверка заголовка Content-Type и разбор multipart формы.
+		if err := parseMultipart(w, r); err != nil {
+			log.Error("cannot parse multipart form", logger.Err(err))
+			if errors.Is(err, errNotMultipart) {
+				http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+				return
+			}
+			http.Error(w, "incorrect resolution data", http.StatusBadRequest)
+			return
+		}
+		defer func() {
+			err := r.MultipartForm.RemoveAll()
+			if err != nil {
+				log.Error("cannot remove temporary multipart form files", logger.Err(err))
+			}
+		}()
+
+		// Проверка количества файлов.
+		if n := reports.FileCount(r.MultipartForm); n == 0 || n > storage.MaxMedia {
+			log.Error("incorrect files count")
+			http.Error(w, "incorrect resolution data", http.StatusBadRequest)
+			return
+		}
+
+		// Получение сформированной структуры закрытия заявки и кода.
+		res, code := reports.Resolution(l, s3, r)
+		switch code {
+		case http.StatusBadRequest:
+			http.Error(w, "incorrect resolution data", http.StatusBadRequest)
+			return
+		case http.StatusInternalServerError:
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		case http.StatusUnsupportedMediaType:
+			http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+			return
+		}
+		res.By = subject(r)
+
+		// Принудительный возврат аллоцированной памяти системе.
+		defer debug.FreeOSMemory()
+
+		// Запрос в базу данных. В случае ошибки удаляем загруженные файлы
+		// из S3 хранилища.
+		report, err := st.Resolve(r.Context(), num, res)
+		if err != nil {
+			go reports.RemoveFiles(l, storage.MediaURLs(res.Media), s3)
+			log.Error("cannot resolve report", logger.Err(err))
+			if errors.Is(err, storage.ErrReportNotFound) {
+				http.Error(w, "report not found", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, storage.ErrIncorrectStatus) {
+				http.Error(w, "report is already closed or rejected", http.StatusConflict)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Отправка уведомления о закрытии заявки с фото после ремонта.
+		if report.Contacts.Email != "" {
+			go func() {
+				err := notifications.Resolved(notify, report.Contacts.Email, res.Comment, storage.MediaURLs(res.Media))
+				if err != nil {
+					log.Error("failed to send notification to email", logger.Err(err))
+				}
+			}()
+		}
+
+		// Кодирование ответа в JSON.
+		w.Header().Set("Content-Type", "application/json")
+		err = encodeReport(w, r, report)
+		if err != nil {
+			log.Error("cannot encode report", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("report resolved successfully")
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// ReportRestorer - интерфейс для восстановления заявки из корзины.
+type ReportRestorer interface {
+	RestoreByNum(ctx context.Context, num int) (storage.Report, error)
+}
+
+// RestoreReport обрабатывает запрос на восстановление заявки из корзины
+// по ее номеру. Возвращает восстановленную заявку.
+func RestoreReport(l *slog.Logger, st ReportRestorer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.RestoreReport"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to restore report")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		num, err := number(r)
+		if err != nil {
+			log.Error("invalid report number", logger.Err(err))
+			http.Error(w, "invalid report number", http.StatusBadRequest)
+			return
+		}
+
+		// Запрос в базу данных.
+		report, err := st.RestoreByNum(r.Context(), num)
+		if err != nil {
+			log.Error("cannot restore report", logger.Err(err))
+			if errors.Is(err, storage.ErrReportNotFound) {
+				http.Error(w, "report not found in trash", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = encodeReport(w, r, report)
+		if err != nil {
+			log.Error("cannot encode report", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("report restored successfully")
+	}
+}
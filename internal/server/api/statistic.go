@@ -5,6 +5,7 @@ import (
 	"Report-Storage/internal/storage"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"log/slog"
@@ -12,10 +13,14 @@ import (
 
 // StatisticRetriever - интерфейс для получения статистики заявок.
 type StatisticRetriever interface {
-	Statistic(ctx context.Context) (storage.Statistic, error)
+	DetailedStatistic(ctx context.Context, q storage.StatQuery) (storage.StatReport, error)
 }
 
-// Statistic обрабатывает запрос на получение статистики по всем заявкам.
+// Statistic обрабатывает запрос на получение статистики по всем заявкам
+// или по заявкам в границах территории из query параметра district.
+// Период задается параметрами from и to, интервал временного ряда -
+// параметром interval (day, week, month), разрез - параметром by
+// (city, district, category).
 func Statistic(l *slog.Logger, st StatisticRetriever) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const operation = "server.api.GetStatistic"
@@ -27,10 +32,21 @@ func Statistic(l *slog.Logger, st StatisticRetriever) http.HandlerFunc {
 		// Установка типа контента для ответа.
 		w.Header().Set("Content-Type", "application/json")
 
+		// Получение параметров запроса.
+		q, err := statQuery(r)
+		if err != nil {
+			log.Error("failed to get correct parameters", logger.Err(err))
+			http.Error(w, "invalid parameters", http.StatusBadRequest)
+			return
+		}
+
 		// Запрос в базу данных.
-		stats, err := st.Statistic(r.Context())
+		stats, err := st.DetailedStatistic(r.Context(), q)
 		if err != nil {
 			log.Error("cannot retrieve statistics", logger.Err(err))
+			if districtError(w, err) {
+				return
+			}
 			http.Error(w, "internal error", http.StatusInternalServerError)
 			return
 		}
@@ -44,3 +60,31 @@ func Statistic(l *slog.Logger, st StatisticRetriever) http.HandlerFunc {
 		log.Debug("statistics sent successfully")
 	}
 }
+
+// statQuery получает параметры подробной статистики из query
+// параметров запроса.
+func statQuery(r *http.Request) (storage.StatQuery, error) {
+	var q storage.StatQuery
+	var err error
+
+	q.District = r.URL.Query().Get("district")
+	q.From, q.To, err = period(r)
+	if err != nil {
+		return q, err
+	}
+
+	q.Interval = r.URL.Query().Get("interval")
+	switch q.Interval {
+	case "", storage.Day, storage.Week, storage.Month:
+	default:
+		return q, fmt.Errorf("unsupported interval %q", q.Interval)
+	}
+
+	q.By = r.URL.Query().Get("by")
+	switch q.By {
+	case "", storage.ByCity, storage.ByDistrict, storage.ByCategory:
+	default:
+		return q, fmt.Errorf("unsupported breakdown %q", q.By)
+	}
+	return q, nil
+}
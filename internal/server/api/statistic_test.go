@@ -0,0 +1,38 @@
+package api
+
+import (
+	"Report-Storage/internal/storage"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_statQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    storage.StatQuery
+		wantErr bool
+	}{
+		{name: "OK Empty", url: "/api/reports/statistic", want: storage.StatQuery{}},
+		{
+			name: "OK Interval and breakdown",
+			url:  "/api/reports/statistic?district=abc&interval=week&by=category",
+			want: storage.StatQuery{District: "abc", Interval: storage.Week, By: storage.ByCategory},
+		},
+		{name: "Error Interval", url: "/api/reports/statistic?interval=year", wantErr: true},
+		{name: "Error Breakdown", url: "/api/reports/statistic?by=status", wantErr: true},
+		{name: "Error Period", url: "/api/reports/statistic?from=yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := statQuery(httptest.NewRequest("GET", tt.url, nil))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("statQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("statQuery() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// TemplatesRetriever - интерфейс для получения всех шаблонов писем.
+type TemplatesRetriever interface {
+	Templates(ctx context.Context) ([]storage.Template, error)
+}
+
+// Templates обрабатывает запрос на получение всех шаблонов писем.
+func Templates(l *slog.Logger, st TemplatesRetriever) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Templates"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive letter templates")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Запрос в базу данных.
+		tpls, err := st.Templates(r.Context())
+		if err != nil {
+			log.Error("cannot receive letter templates", logger.Err(err))
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no templates found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = json.NewEncoder(w).Encode(tpls)
+		if err != nil {
+			log.Error("cannot encode letter templates to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("letter templates encoded and sent successfully")
+	}
+}
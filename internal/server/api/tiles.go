@@ -0,0 +1,95 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"Report-Storage/internal/tiles"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// mvtType - тип контента векторного тайла.
+const mvtType = "application/vnd.mapbox-vector-tile"
+
+// Тайлы мелкого масштаба покрывают большую часть всех заявок, поэтому
+// до уровня sampleZoom в них выводится случайная выборка не более
+// maxTileReports заявок.
+const (
+	sampleZoom     = 6
+	maxTileReports = 5000
+)
+
+// TileSource - интерфейс для получения заявок в границах тайла.
+type TileSource interface {
+	ReportsByBBox(ctx context.Context, box storage.BBox, status []storage.Status, limit int) ([]storage.Report, error)
+}
+
+// Tile обрабатывает запрос векторного тайла заявок в формате Mapbox Vector
+// Tile по адресу z/x/y. Готовые тайлы хранятся в кэше cache, тайл без
+// заявок возвращается с пустым телом.
+func Tile(l *slog.Logger, st TileSource, cache *tiles.Cache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Tile"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive vector tile")
+
+		// Получение параметров запроса.
+		t, err := tile(r)
+		if err != nil {
+			log.Error("failed to get correct tile address", logger.Err(err))
+			http.Error(w, "invalid tile address", http.StatusBadRequest)
+			return
+		}
+
+		// Поиск тайла в кэше.
+		data, gen, ok := cache.Get(t)
+		if !ok {
+			// Запрос в базу данных.
+			limit := 0
+			if t.Z < sampleZoom {
+				limit = maxTileReports
+			}
+			reports, err := st.ReportsByBBox(r.Context(), t.Bounds(), nil, limit)
+			if err != nil && !errors.Is(err, storage.ErrArrayNotFound) {
+				log.Error("failed to get reports for tile", logger.Err(err))
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return
+			}
+			data = tiles.Reports(t, reports)
+			cache.Put(t, gen, data)
+		}
+
+		// Запись тайла в ответ.
+		w.Header().Set("Content-Type", mvtType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		if _, err := w.Write(data); err != nil {
+			log.Error("cannot write tile to ResponseWriter", logger.Err(err))
+			return
+		}
+		log.Debug("vector tile sent successfully", slog.Bool("cached", ok), slog.Int("size", len(data)))
+	}
+}
+
+// tile получает адрес тайла из параметров z, x и y url запроса.
+func tile(r *http.Request) (tiles.Tile, error) {
+	var t tiles.Tile
+	var err error
+
+	if t.Z, err = strconv.Atoi(chi.URLParam(r, "z")); err != nil {
+		return t, err
+	}
+	if t.X, err = strconv.Atoi(chi.URLParam(r, "x")); err != nil {
+		return t, err
+	}
+	if t.Y, err = strconv.Atoi(chi.URLParam(r, "y")); err != nil {
+		return t, err
+	}
+	return t, t.Validate()
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// TrashRetriever - интерфейс для получения заявок из корзины.
+type TrashRetriever interface {
+	Trashed(ctx context.Context) ([]storage.Report, error)
+}
+
+// Trash обрабатывает запрос на получение всех заявок из корзины.
+func Trash(l *slog.Logger, st TrashRetriever) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Trash"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to receive trashed reports")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Запрос в базу данных.
+		reports, err := st.Trashed(r.Context())
+		if err != nil {
+			log.Error("cannot receive trashed reports", logger.Err(err))
+			if errors.Is(err, storage.ErrArrayNotFound) {
+				http.Error(w, "no reports found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = encodeReports(w, r, reports)
+		if err != nil {
+			log.Error("cannot encode reports to ResponseWriter", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("trashed reports encoded and sent successfully")
+	}
+}
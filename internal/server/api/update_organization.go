@@ -0,0 +1,89 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OrganizationUpdater - интерфейс для обновления организации.
+type OrganizationUpdater interface {
+	UpdateOrganization(ctx context.Context, org storage.Organization) error
+}
+
+// UpdateOrganization обрабатывает запрос на обновление организации по ее
+// ObjectID. Список сотрудников заменяется списком из запроса.
+func UpdateOrganization(l *slog.Logger, st OrganizationUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.UpdateOrganization"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to update organization")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		id, err := objectID(r)
+		if err != nil {
+			log.Error("invalid organization id", logger.Err(err))
+			http.Error(w, "invalid organization id", http.StatusBadRequest)
+			return
+		}
+		obj, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			log.Error("invalid organization id", logger.Err(err))
+			http.Error(w, "invalid organization id", http.StatusBadRequest)
+			return
+		}
+
+		// Декодируем тело запроса в структуру.
+		var org storage.Organization
+		if err := render.DecodeJSON(r.Body, &org); err != nil {
+			log.Error("failed to decode JSON", logger.Err(err))
+			http.Error(w, "invalid organization data", http.StatusBadRequest)
+			return
+		}
+
+		// Валидируем поля запроса.
+		valid := validator.New()
+		err = valid.Struct(org)
+		if err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("validation failed", logger.Err(validateErr))
+			http.Error(w, "invalid organization data", http.StatusBadRequest)
+			return
+		}
+		org.ID = obj
+
+		// Запрос в базу данных.
+		err = st.UpdateOrganization(r.Context(), org)
+		if err != nil {
+			log.Error("cannot update organization", logger.Err(err))
+			if errors.Is(err, storage.ErrOrgNotFound) {
+				http.Error(w, "organization not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = json.NewEncoder(w).Encode(org)
+		if err != nil {
+			log.Error("cannot encode organization", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("organization updated successfully")
+	}
+}
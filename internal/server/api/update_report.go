@@ -6,23 +6,21 @@ import (
 	"Report-Storage/internal/reports"
 	"Report-Storage/internal/storage"
 	"context"
-	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
-	"time"
 
-	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
 )
 
 // ReportUpdater - интерфейс для обновления всех полей заявки.
 type ReportUpdater interface {
-	UpdateReport(ctx context.Context, rep storage.Report) (storage.Report, error)
+	UpdateReport(ctx context.Context, rep storage.Report) (origin, updated storage.Report, err error)
 }
 
 // UpdateReport обрабатывает запрос на обновление заявки по
-// уникальному номеру.
+// уникальному номеру. Возвращает заявку в том виде, в котором она
+// сохранена, вместе с полями, которые изменяет только сервер.
 func UpdateReport(l *slog.Logger, st ReportUpdater, s3 reports.FileSaver, notify *notifications.SMTP) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const operation = "server.api.UpdateReport"
@@ -35,8 +33,8 @@ func UpdateReport(l *slog.Logger, st ReportUpdater, s3 reports.FileSaver, notify
 		w.Header().Set("Content-Type", "application/json")
 
 		// Декодируем тело запроса в структуру.
-		var report storage.Report
-		if err := render.DecodeJSON(r.Body, &report); err != nil {
+		report, err := decodeReport(r)
+		if err != nil {
 			log.Error("failed to decode JSON", logger.Err(err))
 			http.Error(w, "invalid report data", http.StatusBadRequest)
 			return
@@ -44,21 +42,21 @@ func UpdateReport(l *slog.Logger, st ReportUpdater, s3 reports.FileSaver, notify
 
 		// Валидируем поля запроса.
 		valid := validator.New()
-		err := valid.Struct(report)
+		err = valid.Struct(report)
 		if err != nil {
 			validateErr := err.(validator.ValidationErrors)
 			log.Error("validation failed", logger.Err(validateErr))
 			http.Error(w, "invalid report data", http.StatusBadRequest)
 			return
 		}
-		report.Updated = time.Now()
 		report.Geo.Type = "Point"
 		log.Debug("json input decoded and validated successfully")
 
 		// Запрос в базу данных.
-		// Метод UpdateReport возвращает заявку ДО ее изменения. Это необходимо
-		// для сравнения некоторых полей и удаления неиспользуемых файлов.
-		origin, err := st.UpdateReport(r.Context(), report)
+		// Метод UpdateReport возвращает и заявку ДО ее изменения. Это
+		// необходимо для сравнения некоторых полей и удаления неиспользуемых
+		// файлов.
+		origin, report, err := st.UpdateReport(r.Context(), report)
 		if err != nil {
 			log.Error("failed to update report", logger.Err(err))
 			if errors.Is(err, storage.ErrReportNotFound) {
@@ -76,8 +74,10 @@ func UpdateReport(l *slog.Logger, st ReportUpdater, s3 reports.FileSaver, notify
 		// Проверка медиа файлов.
 		// Если в измененной заявке меньше медиа файлов, чем до изменения,
 		// то находим разницу и удаляем неиспользуемые файлы из S3 хранилища.
-		if len(origin.Media) > len(report.Media) {
-			diff := reports.SliceDiff(origin.Media, report.Media)
+		originURLs := origin.Files()
+		reportURLs := report.Files()
+		if len(originURLs) > len(reportURLs) {
+			diff := reports.SliceDiff(originURLs, reportURLs)
 			if len(diff) > 0 {
 				log.Debug("removing media files")
 				go reports.RemoveFiles(log, diff, s3)
@@ -87,7 +87,7 @@ func UpdateReport(l *slog.Logger, st ReportUpdater, s3 reports.FileSaver, notify
 		// Проверка изменения статуса и отправка уведомления об этом.
 		if origin.Status != report.Status && report.Contacts.Email != "" {
 			go func() {
-				err := notifications.StatusChanged(notify, report.Contacts.Email, statusString(report.Status))
+				err := notifications.StatusChanged(notify, report.Contacts.Email, storage.StatusName(report.Status))
 				if err != nil {
 					log.Error("failed to send notification to email", logger.Err(err))
 				}
@@ -95,7 +95,7 @@ func UpdateReport(l *slog.Logger, st ReportUpdater, s3 reports.FileSaver, notify
 		}
 
 		// Кодирование ответа в JSON.
-		err = json.NewEncoder(w).Encode(report)
+		err = encodeReport(w, r, report)
 		if err != nil {
 			log.Error("cannot encode report", logger.Err(err))
 			http.Error(w, "internal error", http.StatusInternalServerError)
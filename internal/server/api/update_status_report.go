@@ -5,7 +5,6 @@ import (
 	"Report-Storage/internal/notifications"
 	"Report-Storage/internal/storage"
 	"context"
-	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -57,7 +56,7 @@ func UpdateStatusReport(l *slog.Logger, st ReportStatusUpdater, notify *notifica
 		// Отправка уведомления об изменении статуса заявки.
 		if report.Contacts.Email != "" {
 			go func() {
-				err := notifications.StatusChanged(notify, report.Contacts.Email, statusString(report.Status))
+				err := notifications.StatusChanged(notify, report.Contacts.Email, storage.StatusName(report.Status))
 				if err != nil {
 					log.Error("failed to send notification to email", logger.Err(err))
 				}
@@ -65,7 +64,7 @@ func UpdateStatusReport(l *slog.Logger, st ReportStatusUpdater, notify *notifica
 		}
 
 		// Кодирование ответа в JSON.
-		err = json.NewEncoder(w).Encode(report)
+		err = encodeReport(w, r, report)
 		if err != nil {
 			log.Error("cannot encode report", logger.Err(err))
 			http.Error(w, "internal error", http.StatusInternalServerError)
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"Report-Storage/internal/letters"
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TemplateUpdater - интерфейс для обновления шаблона письма.
+type TemplateUpdater interface {
+	UpdateTemplate(ctx context.Context, tpl storage.Template) (storage.Template, error)
+}
+
+// UpdateTemplate обрабатывает запрос на замену шаблона письма по его
+// ObjectID. Шаблон проверяется так же, как при добавлении.
+func UpdateTemplate(l *slog.Logger, st TemplateUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.UpdateTemplate"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to update letter template")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Получение параметров запроса.
+		id, err := objectID(r)
+		if err != nil {
+			log.Error("invalid template id", logger.Err(err))
+			http.Error(w, "invalid template id", http.StatusBadRequest)
+			return
+		}
+		obj, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			log.Error("invalid template id", logger.Err(err))
+			http.Error(w, "invalid template id", http.StatusBadRequest)
+			return
+		}
+
+		// Декодируем тело запроса в структуру.
+		var tpl storage.Template
+		if err := render.DecodeJSON(r.Body, &tpl); err != nil {
+			log.Error("failed to decode JSON", logger.Err(err))
+			http.Error(w, "invalid template data", http.StatusBadRequest)
+			return
+		}
+
+		// Валидируем поля запроса и сам шаблон.
+		valid := validator.New()
+		err = valid.Struct(tpl)
+		if err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("validation failed", logger.Err(validateErr))
+			http.Error(w, "invalid template data", http.StatusBadRequest)
+			return
+		}
+		if err := letters.Check(tpl); err != nil {
+			log.Error("invalid template", logger.Err(err))
+			http.Error(w, "invalid template: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		tpl.ID = obj
+
+		// Запрос в базу данных.
+		tpl, err = st.UpdateTemplate(r.Context(), tpl)
+		if err != nil {
+			log.Error("cannot update letter template", logger.Err(err))
+			if errors.Is(err, storage.ErrTemplateNotFound) {
+				http.Error(w, "template not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		// Кодирование ответа в JSON.
+		err = json.NewEncoder(w).Encode(tpl)
+		if err != nil {
+			log.Error("cannot encode letter template", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("letter template updated successfully")
+	}
+}
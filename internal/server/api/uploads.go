@@ -0,0 +1,85 @@
+package api
+
+import (
+	"Report-Storage/internal/logger"
+	"Report-Storage/internal/reports"
+	"Report-Storage/internal/s3cloud"
+	"Report-Storage/internal/storage"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// uploadRequest - структура тела запроса подписанных ссылок для прямой
+// загрузки файлов.
+type uploadRequest struct {
+	Files []struct {
+		ContentType string `json:"content_type"`
+	} `json:"files"`
+}
+
+// Presigner - интерфейс для получения подписанных ссылок на загрузку.
+type Presigner interface {
+	Presign(ctx context.Context, name, contentType string, max int64, expiry time.Duration) (s3cloud.PresignedUpload, error)
+}
+
+// Uploads обрабатывает запрос на получение подписанных форм для прямой
+// загрузки файлов в хранилище методом POST. Хранилище принимает файл
+// только заданного типа и не больше допустимого для него размера.
+// Полученные ключи файлов передаются в поле uploads при создании заявки.
+// Формы действительны в течение expiry.
+func Uploads(l *slog.Logger, s3 Presigner, expiry time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const operation = "server.api.Uploads"
+
+		// Настройка логирования.
+		log := logger.Handler(l, operation, r)
+		log.Info("request to presign direct uploads")
+
+		// Установка типа контента для ответа.
+		w.Header().Set("Content-Type", "application/json")
+
+		// Декодирование JSON из тела запроса.
+		var input uploadRequest
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			log.Error("cannot decode json to upload request struct", logger.Err(err))
+			http.Error(w, "invalid request JSON", http.StatusBadRequest)
+			return
+		}
+		if len(input.Files) == 0 || len(input.Files) > storage.MaxMedia {
+			log.Error("incorrect files count", slog.Int("count", len(input.Files)))
+			http.Error(w, "incorrect files count", http.StatusBadRequest)
+			return
+		}
+
+		// Формирование подписанных ссылок.
+		uploads := make([]s3cloud.PresignedUpload, 0, len(input.Files))
+		for _, f := range input.Files {
+			name, max, err := reports.StagedName(f.ContentType)
+			if err != nil {
+				log.Error("unsupported content type", slog.String("content_type", f.ContentType))
+				http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+				return
+			}
+
+			upload, err := s3.Presign(r.Context(), name, f.ContentType, max, expiry)
+			if err != nil {
+				log.Error("cannot presign upload", logger.Err(err))
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return
+			}
+			uploads = append(uploads, upload)
+		}
+
+		// Кодирование ответа в JSON.
+		err := json.NewEncoder(w).Encode(uploads)
+		if err != nil {
+			log.Error("cannot encode uploads", logger.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		log.Debug("presigned uploads sent successfully")
+	}
+}
@@ -3,9 +3,11 @@ package server
 import (
 	"Report-Storage/internal/config"
 	"Report-Storage/internal/notifications"
-	"Report-Storage/internal/s3cloud"
+	"Report-Storage/internal/pdf"
+	"Report-Storage/internal/reports"
 	"Report-Storage/internal/server/api"
 	"Report-Storage/internal/storage/mongodb"
+	"Report-Storage/internal/tiles"
 	"context"
 	"errors"
 	"log"
@@ -21,10 +23,12 @@ import (
 
 // Server - структура сервера.
 type Server struct {
-	srv  *http.Server
-	mux  *chi.Mux
-	jwt  *jwtauth.JWTAuth
-	mail *notifications.SMTP
+	srv   *http.Server
+	mux   *chi.Mux
+	jwt   *jwtauth.JWTAuth
+	mail  *notifications.SMTP
+	cfg   *config.Config
+	tiles *tiles.Cache
 }
 
 // New - конструктор сервера.
@@ -42,9 +46,11 @@ func New(cfg *config.Config) *Server {
 			WriteTimeout: cfg.WriteTimeout,
 			IdleTimeout:  cfg.IdleTimeout,
 		},
-		mux:  r,
-		jwt:  j,
-		mail: m,
+		mux:   r,
+		jwt:   j,
+		mail:  m,
+		cfg:   cfg,
+		tiles: tiles.NewCache(cfg.TileCacheSize, cfg.TileCacheTTL),
 	}
 	return server
 }
@@ -61,32 +67,113 @@ func (s *Server) Start() {
 	}()
 }
 
-// API инициализирует все обработчики API.
-func (s *Server) API(log *slog.Logger, st *mongodb.Storage, s3 *s3cloud.FileStorage) {
+// API инициализирует все обработчики API. Первая версия API доступна
+// по пути /api и использует порядок координат широта, долгота, вторая
+// версия доступна по пути /api/v2 и использует порядок GeoJSON.
+// Векторные тайлы заявок и публичная статистика не зависят от версии API,
+// поэтому статистика рассчитывается и кэшируется одним обработчиком для
+// обеих версий. Если шрифт писем font не загружен, то формирование писем
+// по заявкам недоступно.
+func (s *Server) API(log *slog.Logger, st *mongodb.Storage, s3 reports.FileSaver, font *pdf.Font) {
+	s.mux.Group(func(r chi.Router) {
+		s.routes(r, "/api", log, st, s3, font)
+	})
+	s.mux.Group(func(r chi.Router) {
+		r.Use(api.V2)
+		s.routes(r, "/api/v2", log, st, s3, font)
+	})
+	s.mux.Get("/tiles/{z}/{x}/{y}.mvt", api.Tile(log, st, s.tiles)) // векторные тайлы заявок для карты
+
+	// Общедоступная статистика без персональных данных.
+	public := api.PublicStatistic(log, st, s.cfg.PublicStatTTL)
+	s.mux.Get("/api/reports/statistic/public", public)
+	s.mux.Get("/api/v2/reports/statistic/public", public)
+}
+
+// TileCache возвращает кэш векторных тайлов для сброса при изменении
+// заявок вне HTTP запросов.
+func (s *Server) TileCache() *tiles.Cache {
+	return s.tiles
+}
+
+// routes регистрирует обработчики API с префиксом пути prefix. Прямая
+// загрузка файлов доступна только для хранилищ, поддерживающих
+// подписанные ссылки, письма - для хранилищ с чтением файлов.
+func (s *Server) routes(r chi.Router, prefix string, log *slog.Logger, st *mongodb.Storage, s3 reports.FileSaver, font *pdf.Font) {
 	// Создание заявки.
-	s.mux.Post("/api/reports/new", api.AddReport(log, st, s3, s.mail))
+	r.With(s.tiles.Invalidator).Post(prefix+"/reports/new", api.AddReport(log, st, s3, s.mail))
+	if p, ok := s3.(api.Presigner); ok {
+		r.Post(prefix+"/uploads", api.Uploads(log, p, s.cfg.UploadExpiry)) // подписанные ссылки для прямой загрузки файлов заявки
+	}
 
 	// Безопасные методы.
-	s.mux.Post("/api/reports/quad", api.ReportsByPoly(log, st))       // получение заявок в границах многоугольника
-	s.mux.Get("/api/reports/all", api.Reports(log, st))               // получение всех заявок
-	s.mux.Get("/api/reports/{num}", api.ReportByNum(log, st))         // получение заявки по ее уникальному номеру
-	s.mux.Get("/api/reports/filter", api.ReportsWithFilters(log, st)) // получение N заявок с фильтрами
-	s.mux.Get("/api/reports/id/{id}", api.ReportByID(log, st))        // получение заявки по ObjectID
-	s.mux.Get("/api/reports/radius", api.ReportsByRadius(log, st))    // получение всех заявок в радиусе от заданной точки
+	r.Post(prefix+"/reports/quad", api.ReportsByPoly(log, st))             // получение заявок в границах многоугольника
+	r.Post(prefix+"/reports/route", api.ReportsByRoute(log, st))           // получение заявок в коридоре вдоль маршрута
+	r.Get(prefix+"/reports/all", api.Reports(log, st))                     // получение всех заявок
+	r.Get(prefix+"/reports/{num}", api.ReportByNum(log, st))               // получение заявки по ее уникальному номеру
+	r.Get(prefix+"/reports/filter", api.ReportsWithFilters(log, st))       // получение N заявок с фильтрами
+	r.Get(prefix+"/reports/id/{id}", api.ReportByID(log, st))              // получение заявки по ObjectID
+	r.Get(prefix+"/reports/radius", api.ReportsByRadius(log, st))          // получение всех заявок в радиусе от заданной точки
+	r.Get(prefix+"/reports/nearest", api.ReportsNearest(log, st))          // получение k ближайших к заданной точке заявок
+	r.Get(prefix+"/reports/clusters", api.Clusters(log, st))               // получение кластеров заявок для уровня масштаба карты
+	r.Get(prefix+"/reports/district/{id}", api.ReportsByDistrict(log, st)) // получение всех заявок в границах территории
 
 	// Методы с проверкой прав.
+	r.Group(func(r chi.Router) {
+		r.Use(jwtauth.Verifier(s.jwt))
+		r.Use(jwtauth.Authenticator(s.jwt))
+		r.Use(s.tiles.Invalidator)
+
+		r.Put(prefix+"/reports", api.UpdateReport(log, st, s3, s.mail))                  // обновление всех полей заявки
+		r.Patch(prefix+"/reports/status/{num}", api.UpdateStatusReport(log, st, s.mail)) // обновление статуса заявки по ее номеру
+		r.Delete(prefix+"/reports/{num}", api.DeleteReport(log, st, s3))                 // перемещение заявки в корзину или окончательное удаление
+		r.Delete(prefix+"/reports/rejected", api.DeleteRejected(log, st, s3))            // удаление всех заявок со статусом "Отклонена"
+		r.Get(prefix+"/reports/export", api.ExportReports(log, st))                      // выгрузка заявок с фильтрами в CSV или XLSX
+		r.Get(prefix+"/reports/statistic", api.Statistic(log, st))                       // получение статистики по всем заявкам
+		r.Get(prefix+"/reports/heatmap", api.Heatmap(log, st))                           // плотность заявок по ячейкам геохешей
+		r.Get(prefix+"/reports/hotspots", api.Hotspots(log, st))                         // ячейки с повторяющимися по месяцам заявками
+		r.Post(prefix+"/reports/{num}/media", api.AddMedia(log, st, s3))                 // добавление медиа файлов в заявку
+		r.Put(prefix+"/reports/{num}/media", api.ReorderMedia(log, st))                  // изменение порядка медиа файлов заявки
+		r.Delete(prefix+"/reports/{num}/media/{name}", api.RemoveMedia(log, st, s3))     // удаление медиа файла заявки
+		r.Post(prefix+"/reports/{num}/resolve", api.ResolveReport(log, st, s3, s.mail))  // закрытие заявки с фото после ремонта
+		r.Get(prefix+"/reports/trash", api.Trash(log, st))                               // получение заявок из корзины
+		r.Get(prefix+"/reports/overdue", api.Overdue(log, st, s.cfg.Rules()))            // получение заявок с превышенным сроком обработки
+		r.Post(prefix+"/reports/{num}/restore", api.RestoreReport(log, st))              // восстановление заявки из корзины
+		r.Post(prefix+"/reports/{num}/assign", api.AssignReport(log, st, s.mail))        // назначение заявки организации и исполнителю
+		r.Get(prefix+"/reports/assigned", api.AssignedReports(log, st))                  // получение заявок, назначенных пользователю
+		r.Get(prefix+"/organizations", api.Organizations(log, st))                       // получение всех организаций
+		r.Post(prefix+"/organizations", api.AddOrganization(log, st))                    // добавление организации
+		r.Put(prefix+"/organizations/{id}", api.UpdateOrganization(log, st))             // обновление организации и ее сотрудников
+		r.Get(prefix+"/jurisdictions", api.Jurisdictions(log, st))                       // получение всех территорий
+		r.Post(prefix+"/jurisdictions", api.AddJurisdiction(log, st))                    // добавление территории с границами в формате GeoJSON
+		r.Get(prefix+"/templates", api.Templates(log, st))                               // получение всех шаблонов писем
+		r.Post(prefix+"/templates", api.AddTemplate(log, st))                            // добавление шаблона письма
+		r.Put(prefix+"/templates/{id}", api.UpdateTemplate(log, st))                     // обновление шаблона письма
+		if d, ok := s3.(api.FileDownloader); ok && font != nil {
+			r.Get(prefix+"/reports/{num}/letter.pdf", api.Letter(log, st, d, font)) // официальное письмо по заявке в формате PDF
+		}
+	})
+}
+
+// Admin инициализирует обработчики запуска задач обслуживания.
+func (s *Server) Admin(log *slog.Logger, gc api.MediaCollector, sch api.JobRunner) {
 	s.mux.Group(func(r chi.Router) {
 		r.Use(jwtauth.Verifier(s.jwt))
 		r.Use(jwtauth.Authenticator(s.jwt))
+		r.Use(s.tiles.Invalidator)
 
-		r.Put("/api/reports", api.UpdateReport(log, st, s3, s.mail))                  // обновление всех полей заявки
-		r.Patch("/api/reports/status/{num}", api.UpdateStatusReport(log, st, s.mail)) // обновление статуса заявки по ее номеру
-		r.Delete("/api/reports/{num}", api.DeleteReport(log, st))                     // удаление заявки по ее номеру
-		r.Delete("/api/reports/rejected", api.DeleteRejected(log, st))                // удаление всех заявок со статусом "Отклонена"
-		r.Get("/api/reports/statistic", api.Statistic(log, st))                       // получение статистики по всем заявкам
+		r.Post("/api/admin/media/gc", api.MediaGC(log, gc, sch))   // удаление файлов, на которые не ссылается ни одна заявка
+		r.Get("/api/admin/jobs", api.Jobs(log, sch))               // получение фоновых задач и истории их запусков
+		r.Post("/api/admin/jobs/{name}/run", api.RunJob(log, sch)) // ручной запуск фоновой задачи
 	})
 }
 
+// Media подключает раздачу медиа файлов из локального хранилища по пути
+// /media/.
+func (s *Server) Media(h http.Handler) {
+	s.mux.Handle("/media/*", h)
+}
+
 // Middleware инициализирует все обработчики middleware.
 func (s *Server) Middleware() {
 	s.mux.Use(middleware.RequestID)
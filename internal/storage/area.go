@@ -0,0 +1,241 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Типы геометрии GeoJSON, допустимые для областей.
+const (
+	Polygon      = "Polygon"
+	MultiPolygon = "MultiPolygon"
+)
+
+// Area - многоугольник или несколько многоугольников в формате GeoJSON.
+// Координаты точек задаются в порядке GeoJSON: долгота, широта. Полигон
+// хранится как MultiPolygon из одного многоугольника, что позволяет
+// работать с обоими типами одинаково.
+type Area struct {
+	Type string `json:"type" bson:"type"`
+	// Coordinates содержит многоугольники, каждый из которых состоит
+	// из внешнего кольца и, возможно, колец-отверстий.
+	Coordinates [][][][2]float64 `json:"coordinates" bson:"coordinates"`
+}
+
+// UnmarshalJSON декодирует геометрию GeoJSON типа Polygon или MultiPolygon
+// и приводит ее к типу MultiPolygon.
+func (a *Area) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	switch raw.Type {
+	case Polygon:
+		var poly [][][2]float64
+		if err := json.Unmarshal(raw.Coordinates, &poly); err != nil {
+			return fmt.Errorf("incorrect Polygon coordinates: %w", err)
+		}
+		a.Coordinates = [][][][2]float64{poly}
+	case MultiPolygon:
+		if err := json.Unmarshal(raw.Coordinates, &a.Coordinates); err != nil {
+			return fmt.Errorf("incorrect MultiPolygon coordinates: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported geometry type: %q", raw.Type)
+	}
+	a.Type = MultiPolygon
+	return nil
+}
+
+// MaxAreaVertices - максимальное общее количество вершин во всех кольцах
+// области.
+const MaxAreaVertices = 5000
+
+// Validate проверяет геометрию области: наличие многоугольников, замкнутость
+// колец, количество вершин, диапазоны координат и отсутствие самопересечений.
+// Возвращает ошибку ErrIncorrectArea с описанием первой найденной проблемы.
+func (a Area) Validate() error {
+	if a.Type != MultiPolygon {
+		return fmt.Errorf("%w: unsupported geometry type %q", ErrIncorrectArea, a.Type)
+	}
+	if len(a.Coordinates) == 0 {
+		return fmt.Errorf("%w: no polygons", ErrIncorrectArea)
+	}
+
+	var total int
+	for p, poly := range a.Coordinates {
+		if len(poly) == 0 {
+			return fmt.Errorf("%w: polygon %d: no rings", ErrIncorrectArea, p)
+		}
+		for r, ring := range poly {
+			total += len(ring)
+			if total > MaxAreaVertices {
+				return fmt.Errorf("%w: more than %d vertices", ErrIncorrectArea, MaxAreaVertices)
+			}
+			if err := validRing(ring); err != nil {
+				return fmt.Errorf("%w: polygon %d, ring %d: %s", ErrIncorrectArea, p, r, err)
+			}
+		}
+		if err := crossing(poly); err != nil {
+			return fmt.Errorf("%w: polygon %d: %s", ErrIncorrectArea, p, err)
+		}
+	}
+	return nil
+}
+
+// validRing проверяет количество вершин кольца, диапазоны координат,
+// повторяющиеся соседние вершины, замкнутость кольца и ненулевую площадь.
+func validRing(ring [][2]float64) error {
+	if len(ring) < 4 {
+		return fmt.Errorf("ring must have at least 4 positions, got %d", len(ring))
+	}
+	for i, pt := range ring {
+		if err := validPosition(i, pt); err != nil {
+			return err
+		}
+		if i > 0 && pt == ring[i-1] {
+			return fmt.Errorf("position %d: duplicate vertex", i)
+		}
+	}
+	if ring[0] != ring[len(ring)-1] {
+		return fmt.Errorf("ring is not closed, first and last positions differ")
+	}
+	if ringArea(ring) == 0 {
+		return fmt.Errorf("ring has zero area")
+	}
+	return nil
+}
+
+// ringArea возвращает удвоенную ориентированную площадь замкнутого кольца
+// по формуле шнурования.
+func ringArea(ring [][2]float64) float64 {
+	var sum float64
+	for i := 0; i < len(ring)-1; i++ {
+		sum += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return sum
+}
+
+// validPosition проверяет диапазоны координат i-й точки в порядке
+// долгота, широта.
+func validPosition(i int, pt [2]float64) error {
+	lon, lat := pt[0], pt[1]
+	if math.IsNaN(lon) || lon < -180 || lon > 180 {
+		return fmt.Errorf("position %d: longitude %v out of range [-180, 180]", i, lon)
+	}
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return fmt.Errorf("position %d: latitude %v out of range [-90, 90]", i, lat)
+	}
+	return nil
+}
+
+// crossing проверяет, что отрезки колец многоугольника не пересекаются
+// друг с другом, кроме соседних отрезков одного кольца в общей вершине.
+// Соседние отрезки, которые лежат на одной прямой и накладываются друг
+// на друга, считаются пересекающимися.
+func crossing(poly [][][2]float64) error {
+	type segment struct {
+		ring, idx int
+		a, b      [2]float64
+	}
+
+	var segs []segment
+	for r, ring := range poly {
+		for i := 0; i < len(ring)-1; i++ {
+			segs = append(segs, segment{ring: r, idx: i, a: ring[i], b: ring[i+1]})
+		}
+	}
+
+	for i := range segs {
+		for j := i + 1; j < len(segs); j++ {
+			s, t := segs[i], segs[j]
+			if s.ring == t.ring {
+				n := len(poly[s.ring]) - 1
+				// Соседние отрезки кольца, включая первый и последний,
+				// имеют общую вершину.
+				adjacent := false
+				switch {
+				case t.idx == s.idx+1:
+					adjacent = true
+					if overlaps(s.b, s.a, t.b) {
+						return fmt.Errorf("ring %d has overlapping edges %d and %d", s.ring, s.idx, t.idx)
+					}
+				case s.idx == 0 && t.idx == n-1:
+					adjacent = true
+					if overlaps(s.a, s.b, t.a) {
+						return fmt.Errorf("ring %d has overlapping edges %d and %d", s.ring, s.idx, t.idx)
+					}
+				}
+				if adjacent {
+					continue
+				}
+			}
+			if intersects(s.a, s.b, t.a, t.b) {
+				if s.ring == t.ring {
+					return fmt.Errorf("ring %d is self-intersecting at edges %d and %d", s.ring, s.idx, t.idx)
+				}
+				return fmt.Errorf("rings %d and %d intersect", s.ring, t.ring)
+			}
+		}
+	}
+	return nil
+}
+
+// intersects сообщает, имеют ли отрезки p1p2 и q1q2 общие точки.
+func intersects(p1, p2, q1, q2 [2]float64) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(q1, q2, p1)) ||
+		(d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) ||
+		(d4 == 0 && onSegment(p1, p2, q2))
+}
+
+// overlaps сообщает, накладываются ли отрезки с общей вершиной o и
+// концами p и q, то есть лежат ли они на одной прямой по одну сторону
+// от o.
+func overlaps(o, p, q [2]float64) bool {
+	if orientation(o, p, q) != 0 {
+		return false
+	}
+	return (p[0]-o[0])*(q[0]-o[0])+(p[1]-o[1])*(q[1]-o[1]) > 0
+}
+
+// orientation возвращает знак векторного произведения (b-a)x(c-a):
+// положительный при повороте против часовой стрелки, отрицательный
+// при повороте по часовой стрелке и ноль для точек на одной прямой.
+func orientation(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// onSegment сообщает, лежит ли точка c, находящаяся на прямой ab,
+// в пределах отрезка ab.
+func onSegment(a, b, c [2]float64) bool {
+	return math.Min(a[0], b[0]) <= c[0] && c[0] <= math.Max(a[0], b[0]) &&
+		math.Min(a[1], b[1]) <= c[1] && c[1] <= math.Max(a[1], b[1])
+}
+
+// QuadArea преобразует список вершин многоугольника в формате прежней
+// версии API, где точки заданы в порядке широта, долгота, а кольцо
+// не замкнуто, в область из одного многоугольника.
+func QuadArea(quad [][2]float64) Area {
+	ring := make([][2]float64, 0, len(quad)+1)
+	for _, pt := range quad {
+		ring = append(ring, LatLon(pt).LonLat())
+	}
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		ring = append(ring, ring[0])
+	}
+	return Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{ring}}}
+}
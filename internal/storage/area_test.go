@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestArea_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "OK Polygon",
+			data: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+			want: 1,
+		},
+		{
+			name: "OK MultiPolygon",
+			data: `{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]],[[[2,2],[3,2],[3,3],[2,2]]]]}`,
+			want: 2,
+		},
+		{
+			name:    "Error Point",
+			data:    `{"type":"Point","coordinates":[0,0]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Error Polygon with MultiPolygon coordinates",
+			data:    `{"type":"Polygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]]]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a Area
+			err := json.Unmarshal([]byte(tt.data), &a)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Area.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if a.Type != MultiPolygon || len(a.Coordinates) != tt.want {
+				t.Errorf("Area.UnmarshalJSON() = %v, want %d polygons", a, tt.want)
+			}
+		})
+	}
+}
+
+func TestArea_Validate(t *testing.T) {
+	square := [][2]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	hole := [][2]float64{{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}}
+	many := make([][2]float64, MaxAreaVertices+1)
+
+	tests := []struct {
+		name    string
+		area    Area
+		wantErr bool
+	}{
+		{
+			name: "OK Polygon with hole",
+			area: Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{square, hole}}},
+		},
+		{
+			name: "OK Two polygons",
+			area: Area{Type: MultiPolygon, Coordinates: [][][][2]float64{
+				{square},
+				{{{20, 20}, {30, 20}, {30, 30}, {20, 20}}},
+			}},
+		},
+		{
+			name: "OK Collinear vertex",
+			area: Area{Type: MultiPolygon, Coordinates: [][][][2]float64{
+				{{{0, 0}, {5, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+			}},
+		},
+		{
+			name:    "Error Wrong type",
+			area:    Area{Type: Polygon, Coordinates: [][][][2]float64{{square}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error No polygons",
+			area:    Area{Type: MultiPolygon},
+			wantErr: true,
+		},
+		{
+			name:    "Error Open ring",
+			area:    Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{square[:4]}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error Too few positions",
+			area:    Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{{{0, 0}, {1, 1}, {0, 0}}}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error Latitude out of range",
+			area:    Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{{{0, 0}, {10, 0}, {10, 95}, {0, 0}}}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error Duplicate vertex",
+			area:    Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{{{0, 0}, {10, 0}, {10, 0}, {10, 10}, {0, 0}}}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error Self-intersection",
+			area:    Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{{{0, 0}, {10, 10}, {10, 0}, {0, 10}, {0, 0}}}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error Zero area",
+			area:    Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{{{0, 0}, {1, 1}, {2, 2}, {0, 0}}}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error Overlapping adjacent edges",
+			area:    Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{{{0, 0}, {10, 0}, {10, 10}, {10, 5}, {0, 0}}}}},
+			wantErr: true,
+		},
+		{
+			name: "Error Collinear overlap between rings",
+			area: Area{Type: MultiPolygon, Coordinates: [][][][2]float64{
+				{square, {{0, 2}, {0, 4}, {2, 4}, {0, 2}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "Error Hole crosses shell",
+			area: Area{Type: MultiPolygon, Coordinates: [][][][2]float64{
+				{square, {{5, 5}, {15, 5}, {15, 8}, {5, 8}, {5, 5}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "Error Too many vertices",
+			area:    Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{many}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.area.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Area.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrIncorrectArea) {
+				t.Errorf("Area.Validate() error = %v, want ErrIncorrectArea", err)
+			}
+		})
+	}
+}
+
+func TestQuadArea(t *testing.T) {
+	got := QuadArea([][2]float64{{55.1, 37.1}, {55.2, 37.1}, {55.2, 37.2}})
+	want := [][2]float64{{37.1, 55.1}, {37.1, 55.2}, {37.2, 55.2}, {37.1, 55.1}}
+	if got.Type != MultiPolygon || !reflect.DeepEqual(got.Coordinates, [][][][2]float64{{want}}) {
+		t.Errorf("QuadArea() = %v, want %v", got.Coordinates, want)
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("QuadArea() result is invalid: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxZoom - максимальный уровень масштаба карты для кластеризации.
+const MaxZoom = 22
+
+// MaxMercatorLat - предельная широта проекции Web Mercator, за ее
+// пределами карта не отображается.
+const MaxMercatorLat = 85.051129
+
+// clustersPerTile - количество ячеек сетки кластеров по ширине одного
+// тайла карты.
+const clustersPerTile = 4
+
+// parallelStep - наибольший шаг в градусах долготы между вершинами на
+// северной и южной сторонах прямоугольников области.
+const parallelStep = 1.0
+
+// BBox - прямоугольная область карты, заданная юго-западным и
+// северо-восточным углами.
+type BBox struct {
+	Min, Max LonLat
+}
+
+// Cluster - группа заявок в ячейке сетки карты.
+type Cluster struct {
+	// Center содержит центр масс заявок кластера.
+	Center LonLat `json:"center"`
+	// Statistic содержит количество заявок кластера, всего и по статусам.
+	Statistic Statistic `json:"statistic"`
+}
+
+// Validate проверяет диапазоны координат углов и их взаимное
+// расположение. Области, пересекающие антимеридиан, не поддерживаются.
+func (b BBox) Validate() error {
+	if err := validPosition(0, b.Min); err != nil {
+		return fmt.Errorf("%w: %s", ErrIncorrectArea, err)
+	}
+	if err := validPosition(1, b.Max); err != nil {
+		return fmt.Errorf("%w: %s", ErrIncorrectArea, err)
+	}
+	if b.Min.Lon() >= b.Max.Lon() || b.Min.Lat() >= b.Max.Lat() {
+		return fmt.Errorf("%w: bbox min corner must be south-west of max corner", ErrIncorrectArea)
+	}
+	return nil
+}
+
+// Areas возвращает область в виде прямоугольников шириной не более
+// 90 градусов, широта ограничивается пределами Web Mercator. Разбиение
+// нужно, так как MongoDB не принимает многоугольники больше полусферы.
+// Стороны многоугольников на сфере - дуги больших кругов, а не параллели,
+// поэтому на северной и южной сторонах добавляются вершины с шагом не
+// более parallelStep градусов долготы.
+func (b BBox) Areas() []Area {
+	south := math.Max(b.Min.Lat(), -MaxMercatorLat)
+	north := math.Min(b.Max.Lat(), MaxMercatorLat)
+
+	var areas []Area
+	for west := b.Min.Lon(); west < b.Max.Lon(); west += 90 {
+		east := math.Min(west+90, b.Max.Lon())
+		n := int(math.Ceil((east - west) / parallelStep))
+		ring := make([][2]float64, 0, 2*n+3)
+		for i := 0; i < n; i++ {
+			ring = append(ring, [2]float64{west + (east-west)*float64(i)/float64(n), south})
+		}
+		ring = append(ring, [2]float64{east, south})
+		for i := 0; i < n; i++ {
+			ring = append(ring, [2]float64{east - (east-west)*float64(i)/float64(n), north})
+		}
+		ring = append(ring, [2]float64{west, north}, [2]float64{west, south})
+		areas = append(areas, Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{ring}}})
+	}
+	return areas
+}
+
+// ClusterCell возвращает размер ячейки сетки кластеров в градусах для
+// уровня масштаба карты zoom.
+func ClusterCell(zoom int) float64 {
+	return 360 / (math.Exp2(float64(zoom)) * clustersPerTile)
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBBox_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		box     BBox
+		wantErr bool
+	}{
+		{name: "OK", box: BBox{Min: LonLat{37.6, 55.7}, Max: LonLat{37.7, 55.8}}},
+		{name: "OK World", box: BBox{Min: LonLat{-180, -90}, Max: LonLat{180, 90}}},
+		{name: "Error Swapped corners", box: BBox{Min: LonLat{37.7, 55.8}, Max: LonLat{37.6, 55.7}}, wantErr: true},
+		{name: "Error Longitude out of range", box: BBox{Min: LonLat{37.6, 55.7}, Max: LonLat{190, 55.8}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.box.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("BBox.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBBox_Areas(t *testing.T) {
+	tests := []struct {
+		name string
+		box  BBox
+		want int
+	}{
+		{name: "City", box: BBox{Min: LonLat{37.6, 55.7}, Max: LonLat{37.7, 55.8}}, want: 1},
+		{name: "World", box: BBox{Min: LonLat{-180, -90}, Max: LonLat{180, 90}}, want: 4},
+		{name: "Wider than 90 degrees", box: BBox{Min: LonLat{0, 0}, Max: LonLat{120, 10}}, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			areas := tt.box.Areas()
+			if len(areas) != tt.want {
+				t.Fatalf("BBox.Areas() len = %v, want %v", len(areas), tt.want)
+			}
+			for i, a := range areas {
+				if err := a.Validate(); err != nil {
+					t.Errorf("BBox.Areas() area %d: %v", i, err)
+				}
+				// Соседние вершины на параллелях отстоят не более чем
+				// на parallelStep градусов долготы.
+				ring := a.Coordinates[0][0]
+				for j := 1; j < len(ring); j++ {
+					if ring[j][1] == ring[j-1][1] && math.Abs(ring[j][0]-ring[j-1][0]) > parallelStep {
+						t.Errorf("BBox.Areas() area %d: edge %v-%v is longer than %v", i, ring[j-1], ring[j], parallelStep)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestClusterCell(t *testing.T) {
+	if got := ClusterCell(0); got != 90 {
+		t.Errorf("ClusterCell(0) = %v, want 90", got)
+	}
+	if got := ClusterCell(10); got != 360.0/4096 {
+		t.Errorf("ClusterCell(10) = %v, want %v", got, 360.0/4096)
+	}
+}
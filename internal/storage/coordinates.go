@@ -0,0 +1,48 @@
+package storage
+
+// LonLat - координаты точки в порядке GeoJSON: первый элемент - долгота,
+// второй элемент - широта. В этом порядке координаты хранятся в БД
+// и передаются в API второй версии.
+type LonLat [2]float64
+
+// Lon возвращает долготу.
+func (c LonLat) Lon() float64 { return c[0] }
+
+// Lat возвращает широту.
+func (c LonLat) Lat() float64 { return c[1] }
+
+// LatLon возвращает координаты в порядке первой версии API.
+func (c LonLat) LatLon() LatLon { return LatLon{c[1], c[0]} }
+
+// LatLon - координаты точки в порядке первой версии API: первый элемент -
+// широта, второй элемент - долгота.
+type LatLon [2]float64
+
+// Lat возвращает широту.
+func (c LatLon) Lat() float64 { return c[0] }
+
+// Lon возвращает долготу.
+func (c LatLon) Lon() float64 { return c[1] }
+
+// LonLat возвращает координаты в порядке GeoJSON.
+func (c LatLon) LonLat() LonLat { return LonLat{c[1], c[0]} }
+
+// CoordOrder - порядок координат точки в запросах и ответах API.
+type CoordOrder int
+
+// Константы порядка координат.
+const (
+	// OrderLatLon - порядок первой версии API: широта, долгота.
+	OrderLatLon CoordOrder = iota
+	// OrderLonLat - порядок GeoJSON: долгота, широта.
+	OrderLonLat
+)
+
+// LonLat преобразует пару координат, заданную в порядке o, в координаты
+// в порядке GeoJSON.
+func (o CoordOrder) LonLat(c [2]float64) LonLat {
+	if o == OrderLatLon {
+		return LatLon(c).LonLat()
+	}
+	return LonLat(c)
+}
@@ -0,0 +1,26 @@
+package storage
+
+import "testing"
+
+func TestCoordOrder_LonLat(t *testing.T) {
+	tests := []struct {
+		name  string
+		order CoordOrder
+		c     [2]float64
+		want  LonLat
+	}{
+		{name: "OK LatLon", order: OrderLatLon, c: [2]float64{55.75, 37.62}, want: LonLat{37.62, 55.75}},
+		{name: "OK LonLat", order: OrderLonLat, c: [2]float64{37.62, 55.75}, want: LonLat{37.62, 55.75}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.order.LonLat(tt.c)
+			if got != tt.want {
+				t.Errorf("CoordOrder.LonLat() = %v, want %v", got, tt.want)
+			}
+			if got.Lon() != 37.62 || got.Lat() != 55.75 || got.LatLon().LonLat() != got {
+				t.Errorf("CoordOrder.LonLat() = %v, inconsistent accessors", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// geohashAlphabet - алфавит base32, используемый в геохешах.
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxGeohashPrecision - максимальная длина геохеша ячеек тепловой карты.
+const MaxGeohashPrecision = 9
+
+// Cell - ячейка сетки геохешей с количеством заявок в ней.
+type Cell struct {
+	// Geohash содержит геохеш ячейки.
+	Geohash string `json:"geohash"`
+	// Count содержит количество заявок в ячейке.
+	Count int `json:"count"`
+	// Months содержит количество разных календарных месяцев, в которые
+	// в ячейке создавались заявки.
+	Months int `json:"months"`
+}
+
+// Geohash возвращает геохеш точки p длиной precision символов.
+func Geohash(p LonLat, precision int) string {
+	lon := [2]float64{-180, 180}
+	lat := [2]float64{-90, 90}
+
+	var b strings.Builder
+	var bits, ch int
+	even := true
+	for b.Len() < precision {
+		// Четные биты делят интервал долготы, нечетные - широты.
+		rng, v := &lat, p.Lat()
+		if even {
+			rng, v = &lon, p.Lon()
+		}
+		mid := (rng[0] + rng[1]) / 2
+		ch <<= 1
+		if v >= mid {
+			ch |= 1
+			rng[0] = mid
+		} else {
+			rng[1] = mid
+		}
+		even = !even
+
+		bits++
+		if bits == 5 {
+			b.WriteByte(geohashAlphabet[ch])
+			bits, ch = 0, 0
+		}
+	}
+	return b.String()
+}
+
+// GeohashBounds возвращает границы ячейки геохеша hash.
+func GeohashBounds(hash string) (BBox, error) {
+	lon := [2]float64{-180, 180}
+	lat := [2]float64{-90, 90}
+
+	even := true
+	for i := 0; i < len(hash); i++ {
+		ch := strings.IndexByte(geohashAlphabet, hash[i])
+		if ch < 0 {
+			return BBox{}, fmt.Errorf("incorrect geohash character %q", hash[i])
+		}
+		for bit := 4; bit >= 0; bit-- {
+			rng := &lat
+			if even {
+				rng = &lon
+			}
+			mid := (rng[0] + rng[1]) / 2
+			if ch>>bit&1 == 1 {
+				rng[0] = mid
+			} else {
+				rng[1] = mid
+			}
+			even = !even
+		}
+	}
+	return BBox{Min: LonLat{lon[0], lat[0]}, Max: LonLat{lon[1], lat[1]}}, nil
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"testing"
+)
+
+func TestGeohash(t *testing.T) {
+	tests := []struct {
+		name      string
+		p         LonLat
+		precision int
+		want      string
+	}{
+		{name: "Jutland", p: LonLat{10.40744, 57.64911}, precision: 11, want: "u4pruydqqvj"},
+		{name: "Wikipedia example", p: LonLat{-5.6, 42.6}, precision: 5, want: "ezs42"},
+		{name: "Zero precision", p: LonLat{0, 0}, precision: 0, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Geohash(tt.p, tt.precision); got != tt.want {
+				t.Errorf("Geohash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeohashBounds(t *testing.T) {
+	p := LonLat{37.6173, 55.7558}
+	box, err := GeohashBounds(Geohash(p, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Lon() < box.Min.Lon() || p.Lon() > box.Max.Lon() || p.Lat() < box.Min.Lat() || p.Lat() > box.Max.Lat() {
+		t.Errorf("GeohashBounds() = %v, want box containing %v", box, p)
+	}
+	if err := box.Validate(); err != nil {
+		t.Errorf("GeohashBounds() invalid box: %v", err)
+	}
+
+	if _, err := GeohashBounds("ucfa"); err == nil {
+		t.Error("GeohashBounds() error = nil, want error for character a")
+	}
+}
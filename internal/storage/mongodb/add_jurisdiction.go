@@ -0,0 +1,35 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// codeGeoKeys - код ошибки MongoDB, возникающей при вставке документа
+// с геометрией, которую невозможно проиндексировать.
+const codeGeoKeys = 16755
+
+// AddJurisdiction добавляет новую территорию в БД. Возвращает территорию
+// с установленным ObjectID. Если геометрия территории некорректна, то
+// вернет ошибку ErrIncorrectArea.
+func (s *Storage) AddJurisdiction(ctx context.Context, jur storage.Jurisdiction) (storage.Jurisdiction, error) {
+	const operation = "storage.mongodb.AddJurisdiction"
+
+	jur.ID = primitive.NewObjectID()
+
+	collection := s.db.Database(dbName).Collection(colJur)
+	_, err := collection.InsertOne(ctx, jur)
+	if err != nil {
+		var we mongo.WriteException
+		if errors.As(err, &we) && we.HasErrorCode(codeGeoKeys) {
+			return jur, fmt.Errorf("%s: %w: %s", operation, storage.ErrIncorrectArea, err)
+		}
+		return jur, fmt.Errorf("%s: %w", operation, err)
+	}
+	return jur, nil
+}
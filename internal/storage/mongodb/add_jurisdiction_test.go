@@ -0,0 +1,62 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_AddJurisdiction(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colJur = testJur
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colJur)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Незамкнутое кольцо не может быть проиндексировано.
+	open := storage.Area{
+		Type:        storage.MultiPolygon,
+		Coordinates: [][][][2]float64{{{{37.6, 55.74}, {37.64, 55.74}, {37.64, 55.77}, {37.6, 55.77}}}},
+	}
+
+	tests := []struct {
+		name    string
+		jur     storage.Jurisdiction
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			jur:     storage.Jurisdiction{Name: "Центр", Area: area},
+			wantErr: false,
+		},
+		{
+			name:    "Error Open ring",
+			jur:     storage.Jurisdiction{Name: "Центр", Area: open},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.AddJurisdiction(context.Background(), tt.jur)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.AddJurisdiction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.ID.IsZero() {
+				t.Errorf("Storage.AddJurisdiction() ID is zero")
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddMedia добавляет медиа файлы в конец списка медиа заявки по ее номеру.
+// Добавление происходит атомарно с проверкой, что общее количество файлов
+// не превысит storage.MaxMedia, иначе вернет ошибку ErrMediaLimit. Если
+// документ с указанным номером не найден, то вернет ошибку ErrReportNotFound.
+// Возвращает заявку после изменения.
+func (s *Storage) AddMedia(ctx context.Context, num int, media []storage.Media) (storage.Report, error) {
+	const operation = "storage.mongodb.AddMedia"
+
+	var report storage.Report
+	if num < 1 {
+		return report, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+	}
+	if len(media) == 0 || len(media) > storage.MaxMedia {
+		return report, fmt.Errorf("%s: %w", operation, storage.ErrMediaLimit)
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+
+	// Условие на размер массива в фильтре гарантирует, что лимит не будет
+	// превышен при одновременных запросах.
+	filter := bson.D{
+		{Key: "number", Value: num},
+		notDeleted,
+		{Key: "$expr", Value: bson.D{
+			{Key: "$lte", Value: bson.A{
+				bson.D{{Key: "$size", Value: "$media"}},
+				storage.MaxMedia - len(media),
+			}},
+		}},
+	}
+	update := bson.D{
+		{Key: "$push", Value: bson.D{
+			{Key: "media", Value: bson.D{{Key: "$each", Value: media}}},
+		}},
+		{Key: "$set", Value: bson.D{
+			{Key: "updated", Value: time.Now()},
+		}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return report, fmt.Errorf("%s: %w", operation, s.mediaError(ctx, num))
+		}
+		return report, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return report, nil
+}
+
+// mediaError определяет причину, по которой заявка с номером num не была
+// изменена фильтром с условием на медиа файлы. Если заявка существует,
+// то возвращает ErrMediaLimit, иначе ErrReportNotFound.
+func (s *Storage) mediaError(ctx context.Context, num int) error {
+	collection := s.db.Database(dbName).Collection(colReport)
+	c, err := collection.CountDocuments(ctx, bson.D{{Key: "number", Value: num}, notDeleted})
+	if err != nil {
+		return err
+	}
+	if c == 0 {
+		return storage.ErrReportNotFound
+	}
+	return storage.ErrMediaLimit
+}
@@ -0,0 +1,83 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_AddMedia(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовую заявку с одним медиа файлом.
+	_, err = st.addOne(reports[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	photo := storage.Media{URL: "https://ya.ru/1.jpg", Kind: storage.Photo}
+	tests := []struct {
+		name    string
+		num     int
+		media   []storage.Media
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "OK Two files",
+			num:     1,
+			media:   []storage.Media{photo, photo},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name:    "Error Limit exceeded",
+			num:     1,
+			media:   []storage.Media{photo, photo, photo},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "Error Empty media",
+			num:     1,
+			media:   nil,
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "Error Not found",
+			num:     5,
+			media:   []storage.Media{photo},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.AddMedia(context.Background(), tt.num, tt.media)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.AddMedia() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got.Media) != tt.want {
+				t.Errorf("Storage.AddMedia() media len = %d, want %d", len(got.Media), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddOrganization добавляет новую организацию в БД. Возвращает организацию
+// с установленным ObjectID.
+func (s *Storage) AddOrganization(ctx context.Context, org storage.Organization) (storage.Organization, error) {
+	const operation = "storage.mongodb.AddOrganization"
+
+	org.ID = primitive.NewObjectID()
+	if org.Members == nil {
+		org.Members = []storage.Member{}
+	}
+
+	collection := s.db.Database(dbName).Collection(colOrg)
+	_, err := collection.InsertOne(ctx, org)
+	if err != nil {
+		return org, fmt.Errorf("%s: %w", operation, err)
+	}
+	return org, nil
+}
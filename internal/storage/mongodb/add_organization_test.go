@@ -4,15 +4,15 @@ import (
 	"Report-Storage/internal/storage"
 	"context"
 	"os"
-	"reflect"
 	"testing"
 )
 
-func TestStorage_Statistic(t *testing.T) {
+func TestStorage_AddOrganization(t *testing.T) {
 
 	// Создаем пул подключений.
 	dbName = testDatabase
 	colReport = testCollection
+	colOrg = testOrg
 	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
 	st, err := new(opts)
 	if err != nil {
@@ -21,39 +21,39 @@ func TestStorage_Statistic(t *testing.T) {
 	defer st.Close()
 
 	// Очищаем тестовую коллекцию.
-	err = st.trun(colReport)
+	err = st.trun(colOrg)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	// Заполняем коллекцию тестовыми заявками.
-	for _, v := range reports {
-		_, err := st.addOne(v)
-		if err != nil {
-			t.Fatal(err)
-		}
-	}
-
 	tests := []struct {
 		name    string
-		want    storage.Statistic
+		org     storage.Organization
 		wantErr bool
 	}{
 		{
 			name:    "OK",
-			want:    storage.Statistic{Total: 3, Unverified: 3},
+			org:     storage.Organization{Name: "Управа района", Email: "uprava@mail.ru"},
+			wantErr: false,
+		},
+		{
+			name: "OK With members",
+			org: storage.Organization{
+				Name:    "ЖКС №1",
+				Members: []storage.Member{{User: "worker1", Name: "Иван"}},
+			},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := st.Statistic(context.Background())
+			got, err := st.AddOrganization(context.Background(), tt.org)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("Storage.Statistic() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Storage.AddOrganization() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Storage.Statistic() = %v, want %v", got, tt.want)
+			if got.ID.IsZero() {
+				t.Errorf("Storage.AddOrganization() ID is zero")
 			}
 		})
 	}
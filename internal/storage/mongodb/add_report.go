@@ -3,31 +3,78 @@ package mongodb
 import (
 	"Report-Storage/internal/storage"
 	"context"
+	"errors"
 	"fmt"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // AddReport добавляет одну новую заявку в БД. Не осуществляет валидацию
-// rep, ожидает полностью валидную заявку.
-func (s *Storage) AddReport(ctx context.Context, rep storage.Report) error {
+// rep, ожидает полностью валидную заявку. Заявке назначается территория,
+// в границах которой она находится, и, если ответственная организация
+// не указана, организация этой территории. Возвращает добавленную заявку.
+func (s *Storage) AddReport(ctx context.Context, rep storage.Report) (storage.Report, error) {
 	const operation = "storage.mongodb.AddReport"
 
 	if rep.Number < 1 {
-		return fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+		return rep, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
 	}
 
 	// Устанавливаем ObjectID.
 	rep.ID = primitive.NewObjectID()
 
-	// Меняем местами широту и долготу.
-	rep.Geo.Coordinates[0], rep.Geo.Coordinates[1] = rep.Geo.Coordinates[1], rep.Geo.Coordinates[0]
+	// Определяем территорию по координатам заявки.
+	jur, err := s.jurisdictionAt(ctx, rep.Geo.Coordinates)
+	if err != nil && !errors.Is(err, storage.ErrJurisdictionNotFound) {
+		return rep, fmt.Errorf("%s: %w", operation, err)
+	}
+	if err == nil {
+		rep.Jurisdiction = &jur.ID
+		if rep.AssigneeOrg == nil {
+			rep.AssigneeOrg = jur.Org
+		}
+	}
 
 	// Производим вставку новой заявки.
 	collection := s.db.Database(dbName).Collection(colReport)
-	_, err := collection.InsertOne(ctx, rep)
+	_, err = collection.InsertOne(ctx, rep)
+	if err != nil {
+		return rep, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return rep, nil
+}
+
+// jurisdictionAt возвращает территорию, в границах которой находится
+// точка с координатами point в порядке долгота, широта. Если точка
+// находится на пересечении нескольких территорий, то вернет первую
+// добавленную. Если территория не найдена, то вернет ошибку
+// ErrJurisdictionNotFound.
+func (s *Storage) jurisdictionAt(ctx context.Context, point storage.LonLat) (storage.Jurisdiction, error) {
+	var jur storage.Jurisdiction
+
+	collection := s.db.Database(dbName).Collection(colJur)
+	filter := bson.D{
+		{Key: "area", Value: bson.D{
+			{Key: "$geoIntersects", Value: bson.D{
+				{Key: "$geometry", Value: bson.D{
+					{Key: "type", Value: "Point"},
+					{Key: "coordinates", Value: point},
+				}},
+			}},
+		}},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	err := collection.FindOne(ctx, filter, opts).Decode(&jur)
 	if err != nil {
-		return fmt.Errorf("%s: %w", operation, err)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return jur, storage.ErrJurisdictionNotFound
+		}
+		return jur, err
 	}
-	return nil
+	return jur, nil
 }
@@ -5,6 +5,8 @@ import (
 	"context"
 	"os"
 	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestStorage_AddReport(t *testing.T) {
@@ -12,6 +14,7 @@ func TestStorage_AddReport(t *testing.T) {
 	// Создаем пул подключений.
 	dbName = testDatabase
 	colReport = testCollection
+	colJur = testJur
 	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
 	st, err := new(opts)
 	if err != nil {
@@ -19,15 +22,27 @@ func TestStorage_AddReport(t *testing.T) {
 	}
 	defer st.Close()
 
-	// Очищаем тестовую коллекцию.
+	// Очищаем тестовые коллекции.
 	err = st.trun(colReport)
 	if err != nil {
 		t.Fatal(err)
 	}
+	err = st.trun(colJur)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Добавляем территорию с ответственной организацией.
+	org := primitive.NewObjectID()
+	jur, err := st.AddJurisdiction(context.Background(), storage.Jurisdiction{Name: "Центр", Org: &org, Area: area})
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := []struct {
 		name    string
 		rep     storage.Report
+		wantJur bool
 		wantErr bool
 	}{
 		{
@@ -37,9 +52,23 @@ func TestStorage_AddReport(t *testing.T) {
 				City:        "Москва",
 				Address:     "Адрес 1",
 				Description: "Описание 1",
-				Media:       []string{"https://google.com"},
-				Geo:         storage.Geo{Type: "Point", Coordinates: [2]float64{55.75388130172051, 37.62026781374883}},
+				Media:       []storage.Media{{URL: "https://google.com", Kind: storage.Photo}},
+				Geo:         storage.Geo{Type: "Point", Coordinates: storage.LonLat{37.62026781374883, 55.75388130172051}},
 			},
+			wantJur: true,
+			wantErr: false,
+		},
+		{
+			name: "OK Outside jurisdiction",
+			rep: storage.Report{
+				Number:      2,
+				City:        "Санкт-Петербург",
+				Address:     "Адрес 2",
+				Description: "Описание 2",
+				Media:       []storage.Media{{URL: "https://google.com", Kind: storage.Photo}},
+				Geo:         storage.Geo{Type: "Point", Coordinates: storage.LonLat{30.31511987692599, 59.939543808173305}},
+			},
+			wantJur: false,
 			wantErr: false,
 		},
 		{
@@ -49,8 +78,8 @@ func TestStorage_AddReport(t *testing.T) {
 				City:        "Москва",
 				Address:     "Адрес 1",
 				Description: "Описание 1",
-				Media:       []string{"https://google.com"},
-				Geo:         storage.Geo{Type: "Point", Coordinates: [2]float64{55.75388130172051, 37.62026781374883}},
+				Media:       []storage.Media{{URL: "https://google.com", Kind: storage.Photo}},
+				Geo:         storage.Geo{Type: "Point", Coordinates: storage.LonLat{37.62026781374883, 55.75388130172051}},
 			},
 			wantErr: true,
 		},
@@ -61,16 +90,27 @@ func TestStorage_AddReport(t *testing.T) {
 				City:        "Москва",
 				Address:     "Адрес 1",
 				Description: "Описание 1",
-				Media:       []string{"https://google.com"},
-				Geo:         storage.Geo{Type: "Point", Coordinates: [2]float64{55.75388130172051, 37.62026781374883}},
+				Media:       []storage.Media{{URL: "https://google.com", Kind: storage.Photo}},
+				Geo:         storage.Geo{Type: "Point", Coordinates: storage.LonLat{37.62026781374883, 55.75388130172051}},
 			},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := st.AddReport(context.Background(), tt.rep); (err != nil) != tt.wantErr {
+			got, err := st.AddReport(context.Background(), tt.rep)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("Storage.AddReport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if (got.Jurisdiction != nil) != tt.wantJur {
+				t.Errorf("Storage.AddReport() jurisdiction = %v, wantJur %v", got.Jurisdiction, tt.wantJur)
+			}
+			if tt.wantJur && (*got.Jurisdiction != jur.ID || got.AssigneeOrg == nil || *got.AssigneeOrg != org) {
+				t.Errorf("Storage.AddReport() = %v, %v, want %v, %v", got.Jurisdiction, got.AssigneeOrg, jur.ID, org)
 			}
 		})
 	}
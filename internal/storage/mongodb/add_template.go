@@ -0,0 +1,26 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddTemplate добавляет новый шаблон письма в БД. Возвращает шаблон
+// с установленными ObjectID и временем изменения.
+func (s *Storage) AddTemplate(ctx context.Context, tpl storage.Template) (storage.Template, error) {
+	const operation = "storage.mongodb.AddTemplate"
+
+	tpl.ID = primitive.NewObjectID()
+	tpl.Updated = time.Now()
+
+	collection := s.db.Database(dbName).Collection(colTpl)
+	_, err := collection.InsertOne(ctx, tpl)
+	if err != nil {
+		return tpl, fmt.Errorf("%s: %w", operation, err)
+	}
+	return tpl, nil
+}
@@ -0,0 +1,36 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_AddTemplate(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colTpl = testTpl
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colTpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tpl := storage.Template{Name: "Жалоба", Title: "Заявка № {{.Number}}", Body: "{{.Address}}"}
+	got, err := st.AddTemplate(context.Background(), tpl)
+	if err != nil {
+		t.Fatalf("Storage.AddTemplate() error = %v", err)
+	}
+	if got.ID.IsZero() || got.Updated.IsZero() {
+		t.Errorf("Storage.AddTemplate() = %+v, want ID and update time", got)
+	}
+}
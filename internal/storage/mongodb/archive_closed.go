@@ -0,0 +1,63 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ArchiveClosed переносит в архив заявки, закрытые до момента before.
+// Время закрытия - время установки статуса, для старых заявок без него
+// используется время изменения или создания. Архивные заявки не
+// отображаются на карте, но доступны по уникальному номеру. Возвращает
+// количество перенесенных заявок.
+func (s *Storage) ArchiveClosed(ctx context.Context, before time.Time) (int, error) {
+	const operation = "storage.mongodb.ArchiveClosed"
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	archive := s.db.Database(dbName).Collection(colArchive)
+	filter := bson.D{
+		{Key: "status", Value: storage.Closed},
+		notDeleted,
+		{Key: "$expr", Value: bson.D{
+			{Key: "$lt", Value: bson.A{
+				bson.D{{Key: "$ifNull", Value: bson.A{
+					"$status_since",
+					bson.D{{Key: "$ifNull", Value: bson.A{"$updated", "$created"}}},
+				}}},
+				before,
+			}},
+		}},
+	}
+
+	var count int
+	for {
+		var report bson.Raw
+		err := collection.FindOne(ctx, filter).Decode(&report)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("%s: %w", operation, err)
+		}
+
+		// Сначала сохраняем заявку в архив, затем удаляем из основной
+		// коллекции. Если удаление не выполнится, то заявка будет
+		// перенесена при следующем запуске, копия в архиве уже есть.
+		_, err = archive.InsertOne(ctx, report)
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return count, fmt.Errorf("%s: %w", operation, err)
+		}
+		id := report.Lookup("_id")
+		_, err = collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+		if err != nil {
+			return count, fmt.Errorf("%s: %w", operation, err)
+		}
+		count++
+	}
+}
@@ -0,0 +1,96 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestStorage_ArchiveClosed(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colArchive = testArchive
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовые коллекции.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = st.trun(colArchive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовые заявки и закрываем первую.
+	for _, rep := range reports {
+		_, err = st.addOne(rep)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = st.UpdateStatus(context.Background(), 1, storage.Closed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Изменение закрытой заявки не откладывает ее перенос в архив.
+	collection := st.db.Database(dbName).Collection(colReport)
+	_, err = collection.UpdateOne(context.Background(),
+		bson.D{{Key: "number", Value: 1}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "updated", Value: time.Now().Add(2 * time.Hour)}}}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		before time.Time
+		want   int
+	}{
+		{
+			name:   "OK Not expired",
+			before: time.Now().Add(-time.Hour),
+			want:   0,
+		},
+		{
+			name:   "OK Archived",
+			before: time.Now().Add(time.Hour),
+			want:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.ArchiveClosed(context.Background(), tt.before)
+			if err != nil {
+				t.Errorf("Storage.ArchiveClosed() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Storage.ArchiveClosed() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// Архивная заявка доступна по номеру, но не среди действующих.
+	rep, err := st.ReportByNum(context.Background(), 1)
+	if err != nil || rep.Number != 1 {
+		t.Errorf("Storage.ReportByNum() = %v, error = %v, want archived report", rep.Number, err)
+	}
+	all, err := st.Reports(context.Background(), nil)
+	if err != nil || len(all) != len(reports)-1 {
+		t.Errorf("Storage.Reports() = %d, error = %v, want %d", len(all), err, len(reports)-1)
+	}
+}
@@ -0,0 +1,61 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Assign назначает заявке с номером num ответственную организацию orgID
+// и, если user не пустой, исполнителя из сотрудников этой организации.
+// Если организация не найдена, то вернет ошибку ErrOrgNotFound, если
+// пользователь не является ее сотрудником, то ErrIncorrectUser. Если
+// заявка не найдена, то вернет ошибку ErrReportNotFound.
+func (s *Storage) Assign(ctx context.Context, num int, orgID, user string) (storage.Report, error) {
+	const operation = "storage.mongodb.Assign"
+
+	var report storage.Report
+	if num < 1 {
+		return report, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+	}
+
+	org, err := s.OrganizationByID(ctx, orgID)
+	if err != nil {
+		return report, fmt.Errorf("%s: %w", operation, err)
+	}
+	if _, ok := org.Member(user); user != "" && !ok {
+		return report, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectUser)
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	filter := bson.D{{Key: "number", Value: num}, notDeleted}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "assignee_org", Value: org.ID},
+			{Key: "updated", Value: time.Now()},
+		}},
+	}
+	// Исполнитель от прежней организации не сохраняется.
+	if user != "" {
+		update[0].Value = append(update[0].Value.(bson.D), bson.E{Key: "assignee_user", Value: user})
+	} else {
+		update = append(update, bson.E{Key: "$unset", Value: bson.D{{Key: "assignee_user", Value: ""}}})
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err = collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return report, fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
+		}
+		return report, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,104 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStorage_Assign(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colOrg = testOrg
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовые коллекции.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = st.trun(colOrg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовые заявку и организацию.
+	_, err = st.addOne(reports[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	org := storage.Organization{
+		Name:    "ЖКС №1",
+		Members: []storage.Member{{User: "worker1", Name: "Иван"}},
+	}
+	org, err = st.AddOrganization(context.Background(), org)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		num     int
+		org     string
+		user    string
+		wantErr bool
+	}{
+		{
+			name:    "OK Organization",
+			num:     1,
+			org:     org.ID.Hex(),
+			wantErr: false,
+		},
+		{
+			name:    "OK Member",
+			num:     1,
+			org:     org.ID.Hex(),
+			user:    "worker1",
+			wantErr: false,
+		},
+		{
+			name:    "Error Not a member",
+			num:     1,
+			org:     org.ID.Hex(),
+			user:    "stranger",
+			wantErr: true,
+		},
+		{
+			name:    "Error Organization not found",
+			num:     1,
+			org:     primitive.NewObjectID().Hex(),
+			wantErr: true,
+		},
+		{
+			name:    "Error Report not found",
+			num:     10,
+			org:     org.ID.Hex(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.Assign(context.Background(), tt.num, tt.org, tt.user)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.Assign() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.AssigneeOrg == nil || *got.AssigneeOrg != org.ID || got.AssigneeUser != tt.user {
+				t.Errorf("Storage.Assign() = %v, %v, want %v, %v", got.AssigneeOrg, got.AssigneeUser, org.ID, tt.user)
+			}
+		})
+	}
+}
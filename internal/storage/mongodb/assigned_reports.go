@@ -0,0 +1,44 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AssignedReports возвращает заявки, назначенные пользователю user,
+// отсортированные по номеру в убывающем порядке. Если передан слайс
+// статусов, то вернет только заявки с этими статусами. Если заявки не
+// найдены, то вернет ошибку ErrArrayNotFound.
+func (s *Storage) AssignedReports(ctx context.Context, user string, status []storage.Status) ([]storage.Report, error) {
+	const operation = "storage.mongodb.AssignedReports"
+
+	var reports []storage.Report
+	if user == "" {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	filter := bson.D{{Key: "assignee_user", Value: user}, notDeleted}
+	if len(status) > 0 {
+		filter = append(filter, bson.E{Key: "status", Value: bson.M{"$in": status}})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "number", Value: -1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	err = cursor.All(ctx, &reports)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	return reports, nil
+}
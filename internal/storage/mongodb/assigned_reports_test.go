@@ -0,0 +1,99 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_AssignedReports(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colOrg = testOrg
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовые коллекции.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = st.trun(colOrg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовые заявки и назначаем две из них сотруднику.
+	for _, rep := range reports {
+		_, err = st.addOne(rep)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	org := storage.Organization{
+		Name:    "ЖКС №1",
+		Members: []storage.Member{{User: "worker1", Name: "Иван"}},
+	}
+	org, err = st.AddOrganization(context.Background(), org)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, num := range []int{1, 2} {
+		_, err = st.Assign(context.Background(), num, org.ID.Hex(), "worker1")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		user    string
+		status  []storage.Status
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			user:    "worker1",
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "OK With status",
+			user:    "worker1",
+			status:  []storage.Status{storage.Unverified},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "Error No reports with status",
+			user:    "worker1",
+			status:  []storage.Status{storage.Closed},
+			wantErr: true,
+		},
+		{
+			name:    "Error Unknown user",
+			user:    "stranger",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.AssignedReports(context.Background(), tt.user, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.AssignedReports() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Storage.AssignedReports() len = %v, want %v", len(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ClosedCount возвращает количество заявок, закрытых начиная с момента
+// from, включая перенесенные в архив. Временем закрытия считается время
+// установки статуса Closed.
+func (s *Storage) ClosedCount(ctx context.Context, from time.Time) (int, error) {
+	const operation = "storage.mongodb.ClosedCount"
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	filter := bson.D{
+		notDeleted,
+		{Key: "status", Value: storage.Closed},
+		{Key: "status_since", Value: bson.D{{Key: "$gte", Value: from}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, append(withArchive(filter),
+		bson.D{{Key: "$count", Value: "count"}},
+	))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", operation, err)
+	}
+	// Если заявки не найдены, то $count не возвращает документ.
+	var res []struct {
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(ctx, &res); err != nil {
+		return 0, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(res) == 0 {
+		return 0, nil
+	}
+	return res[0].Count, nil
+}
@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStorage_ClosedCount(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colArchive = testArchive
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовые коллекции.
+	for _, col := range []string{colReport, colArchive} {
+		if err := st.trun(col); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Заполняем коллекцию тестовыми заявками и закрываем две из них.
+	for _, v := range reports {
+		_, err := st.addOne(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, num := range []int{1, 2} {
+		_, err := st.UpdateStatus(context.Background(), num, storage.Closed)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Архивная заявка, закрытая полчаса назад, также учитывается.
+	closed := time.Now().Add(-time.Minute * 30)
+	archived := reports[2]
+	archived.Number = 10
+	if err := st.archiveOne(archived, closed.Add(-time.Hour), closed); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		from time.Time
+		want int
+	}{
+		{
+			name: "OK Closed",
+			from: time.Now().Add(-time.Hour),
+			want: 3,
+		},
+		{
+			name: "OK Closed after archived",
+			from: time.Now().Add(-time.Minute * 10),
+			want: 2,
+		},
+		{
+			name: "OK None",
+			from: time.Now().Add(time.Hour),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.ClosedCount(context.Background(), tt.from)
+			if err != nil {
+				t.Errorf("Storage.ClosedCount() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Storage.ClosedCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Clusters группирует заявки в границах области box по ячейкам сетки,
+// размер которых зависит от уровня масштаба карты zoom. Для каждой ячейки
+// возвращает центр масс заявок и их количество, всего и по статусам.
+// Если в параметр status передать nil или пустой слайс, то учитываются
+// все заявки. Не проверяет принимаемые аргументы, ожидает область,
+// прошедшую проверку BBox.Validate. Если заявки не найдены, то вернет
+// ошибку ErrArrayNotFound.
+func (s *Storage) Clusters(ctx context.Context, box storage.BBox, zoom int, status []storage.Status) ([]storage.Cluster, error) {
+	const operation = "storage.mongodb.Clusters"
+
+	collection := s.db.Database(dbName).Collection(colReport)
+
+	filter := bson.D{notDeleted, inBox(box)}
+	if len(status) > 0 {
+		filter = append(filter, bson.E{Key: "status", Value: bson.M{"$in": status}})
+	}
+
+	// Номер ячейки сетки по координате с индексом i и смещением offset,
+	// которое делает номера неотрицательными.
+	cell := storage.ClusterCell(zoom)
+	index := func(i int, offset float64) bson.D {
+		return bson.D{{Key: "$floor", Value: bson.D{{Key: "$divide", Value: bson.A{
+			bson.D{{Key: "$add", Value: bson.A{bson.D{{Key: "$arrayElemAt", Value: bson.A{"$geo.coordinates", i}}}, offset}}},
+			cell,
+		}}}}}
+	}
+	// Количество заявок со статусом st в ячейке.
+	count := func(st storage.Status) bson.D {
+		return bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{
+			bson.D{{Key: "$eq", Value: bson.A{"$status", st}}}, 1, 0,
+		}}}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "x", Value: index(0, 180)}, {Key: "y", Value: index(1, 90)}}},
+			{Key: "lon", Value: bson.D{{Key: "$avg", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$geo.coordinates", 0}}}}}},
+			{Key: "lat", Value: bson.D{{Key: "$avg", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$geo.coordinates", 1}}}}}},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "unverified", Value: count(storage.Unverified)},
+			{Key: "opened", Value: count(storage.Opened)},
+			{Key: "inprogress", Value: count(storage.InProgress)},
+			{Key: "closed", Value: count(storage.Closed)},
+			{Key: "rejected", Value: count(storage.Rejected)},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.x", Value: 1}, {Key: "_id.y", Value: 1}}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	var results []struct {
+		Lon, Lat          float64
+		storage.Statistic `bson:",inline"`
+	}
+	err = cursor.All(ctx, &results)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	clusters := make([]storage.Cluster, 0, len(results))
+	for _, r := range results {
+		clusters = append(clusters, storage.Cluster{
+			Center:    storage.LonLat{r.Lon, r.Lat},
+			Statistic: r.Statistic,
+		})
+	}
+	return clusters, nil
+}
@@ -0,0 +1,100 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_Clusters(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Заполняем коллекцию тестовыми заявками.
+	for _, v := range reports {
+		_, err := st.addOne(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	moscow := storage.BBox{Min: storage.LonLat{37.6, 55.74}, Max: storage.LonLat{37.64, 55.77}}
+	world := storage.BBox{Min: storage.LonLat{-180, -90}, Max: storage.LonLat{180, 90}}
+
+	type args struct {
+		box    storage.BBox
+		zoom   int
+		status []storage.Status
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      int
+		wantTotal int
+		wantErr   bool
+	}{
+		{
+			name:      "OK One cluster in city",
+			args:      args{box: moscow, zoom: 10, status: nil},
+			want:      1,
+			wantTotal: 2,
+			wantErr:   false,
+		},
+		{
+			name:      "OK Separate reports at high zoom",
+			args:      args{box: moscow, zoom: 18, status: nil},
+			want:      2,
+			wantTotal: 2,
+			wantErr:   false,
+		},
+		{
+			name:      "OK World",
+			args:      args{box: world, zoom: 0, status: []storage.Status{storage.Unverified}},
+			want:      1,
+			wantTotal: 3,
+			wantErr:   false,
+		},
+		{
+			name:    "Error Not found by status",
+			args:    args{box: world, zoom: 0, status: []storage.Status{storage.Closed}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.Clusters(context.Background(), tt.args.box, tt.args.zoom, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.Clusters() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Storage.Clusters() len = %v, want %v", len(got), tt.want)
+			}
+			var total int
+			for _, c := range got {
+				total += c.Statistic.Total
+				if c.Statistic.Unverified != c.Statistic.Total {
+					t.Errorf("Storage.Clusters() statistic = %+v, want all unverified", c.Statistic)
+				}
+			}
+			if total != tt.wantTotal {
+				t.Errorf("Storage.Clusters() total = %v, want %v", total, tt.wantTotal)
+			}
+		})
+	}
+}
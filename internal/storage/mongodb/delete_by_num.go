@@ -3,29 +3,34 @@ package mongodb
 import (
 	"Report-Storage/internal/storage"
 	"context"
+	"errors"
 	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// DeleteByNum удаляет заявку по ее уникальному номеру. Аргумент num
-// должен быть больше 0, иначе вернет ошибку ErrIncorrectNum. Если документ
-// с указанным номером не найден, то вернет ошибку ErrReportNotFound.
-func (s *Storage) DeleteByNum(ctx context.Context, num int) error {
+// DeleteByNum удаляет заявку по ее уникальному номеру и возвращает
+// удаленную заявку. Аргумент num должен быть больше 0, иначе вернет
+// ошибку ErrIncorrectNum. Если документ с указанным номером не найден,
+// то вернет ошибку ErrReportNotFound.
+func (s *Storage) DeleteByNum(ctx context.Context, num int) (storage.Report, error) {
 	const operation = "storage.mongodb.DeleteByNum"
 
+	var report storage.Report
 	if num < 1 {
-		return fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+		return report, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
 	}
 
 	collection := s.db.Database(dbName).Collection(colReport)
 	filter := bson.D{{Key: "number", Value: num}}
-	res, err := collection.DeleteOne(ctx, filter)
+	err := collection.FindOneAndDelete(ctx, filter).Decode(&report)
 	if err != nil {
-		return fmt.Errorf("%s: %w", operation, err)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return report, fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
+		}
+		return report, fmt.Errorf("%s: %w", operation, err)
 	}
-	if res.DeletedCount == 0 {
-		return fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
-	}
-	return nil
+
+	return report, nil
 }
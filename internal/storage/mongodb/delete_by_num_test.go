@@ -33,11 +33,13 @@ func TestStorage_DeleteByNum(t *testing.T) {
 	tests := []struct {
 		name    string
 		num     int
+		want    int64
 		wantErr bool
 	}{
 		{
 			name:    "OK",
 			num:     1,
+			want:    1,
 			wantErr: false,
 		},
 		{
@@ -53,8 +55,13 @@ func TestStorage_DeleteByNum(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := st.DeleteByNum(context.Background(), tt.num); (err != nil) != tt.wantErr {
+			got, err := st.DeleteByNum(context.Background(), tt.num)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("Storage.DeleteByNum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Number != tt.want {
+				t.Errorf("Storage.DeleteByNum() number = %d, want %d", got.Number, tt.want)
 			}
 		})
 	}
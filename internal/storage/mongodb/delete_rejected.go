@@ -3,25 +3,48 @@ package mongodb
 import (
 	"Report-Storage/internal/storage"
 	"context"
+	"errors"
 	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// DeleteRejected удаляет все заявки со статусом Rejected. Возвращает
-// количество удаленных документов и ошибку. Если не было удалено
-// ни одного документа, то вернет ошибку ErrReportNotFound.
-func (s *Storage) DeleteRejected(ctx context.Context) (int, error) {
+// DeleteRejected удаляет все заявки со статусом Rejected, кроме заявок
+// в корзине, и возвращает удаленные заявки. Если не было удалено ни одного
+// документа, то вернет ошибку ErrReportNotFound.
+func (s *Storage) DeleteRejected(ctx context.Context) ([]storage.Report, error) {
 	const operation = "storage.mongodb.DeleteRejected"
 
-	collection := s.db.Database(dbName).Collection(colReport)
-	filter := bson.D{{Key: "status", Value: storage.Rejected}}
-	res, err := collection.DeleteMany(ctx, filter)
+	// Заявки в корзине удаляются по истечении срока хранения.
+	filter := bson.D{{Key: "status", Value: storage.Rejected}, notDeleted}
+	reports, err := s.deleteEach(ctx, filter)
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", operation, err)
+		return reports, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
 	}
-	if res.DeletedCount == 0 {
-		return 0, fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
+	return reports, nil
+}
+
+// deleteEach удаляет все заявки, подходящие под фильтр, и возвращает их.
+// Заявки удаляются по одной, чтобы вернуть именно удаленные документы,
+// даже если заявка изменится во время удаления. При ошибке возвращает
+// заявки, удаленные до нее.
+func (s *Storage) deleteEach(ctx context.Context, filter bson.D) ([]storage.Report, error) {
+	var reports []storage.Report
+	collection := s.db.Database(dbName).Collection(colReport)
+
+	for {
+		var report storage.Report
+		err := collection.FindOneAndDelete(ctx, filter).Decode(&report)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return reports, nil
+		}
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
 	}
-	return int(res.DeletedCount), nil
 }
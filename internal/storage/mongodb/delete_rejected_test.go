@@ -24,12 +24,19 @@ func TestStorage_DeleteRejected(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Вставляем тестовую заявку и устанавливаем статус Rejected.
-	_, err = st.addOne(reports[0])
-	if err != nil {
-		t.Fatal(err)
+	// Вставляем тестовые заявки и устанавливаем статус Rejected. Вторая
+	// заявка перемещается в корзину и не должна быть удалена.
+	for _, rep := range reports[:2] {
+		_, err = st.addOne(rep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = st.UpdateStatus(context.Background(), int(rep.Number), 5)
+		if err != nil {
+			t.Fatal(err)
+		}
 	}
-	_, err = st.UpdateStatus(context.Background(), 1, 5)
+	err = st.TrashByNum(context.Background(), 2, "admin")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -57,8 +64,8 @@ func TestStorage_DeleteRejected(t *testing.T) {
 				t.Errorf("Storage.DeleteRejected() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("Storage.DeleteRejected() = %d, want %d", got, tt.want)
+			if len(got) != tt.want {
+				t.Errorf("Storage.DeleteRejected() = %d, want %d", len(got), tt.want)
 			}
 		})
 	}
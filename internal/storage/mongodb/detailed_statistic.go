@@ -0,0 +1,223 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DetailedStatistic возвращает количество заявок по статусам, временной
+// ряд созданных и закрытых заявок, среднее и медианное время закрытия,
+// а также эти значения в разрезе города, территории или категории.
+// Параметры статистики описаны в storage.StatQuery, значения Interval
+// и By не проверяются. Временем закрытия считается время установки
+// статуса Closed. Учитываются и заявки, перенесенные в архив. Если District содержит некорректный ObjectID, то
+// вернет ошибку ErrIncorrectID, если территория не найдена, то вернет
+// ErrJurisdictionNotFound.
+func (s *Storage) DetailedStatistic(ctx context.Context, q storage.StatQuery) (storage.StatReport, error) {
+	const operation = "storage.mongodb.DetailedStatistic"
+
+	var rep storage.StatReport
+	collection := s.db.Database(dbName).Collection(colReport)
+
+	base := bson.D{notDeleted}
+	if q.District != "" {
+		cond, err := s.district(ctx, q.District)
+		if err != nil {
+			return rep, fmt.Errorf("%s: %w", operation, err)
+		}
+		base = append(base, cond)
+	}
+
+	// Условия отбора созданных и закрытых за период заявок.
+	created := append(bson.D{}, base...)
+	if cond := period(q.From, q.To); cond != nil {
+		created = append(created, bson.E{Key: "created", Value: cond})
+	}
+	closedAt := bson.D{{Key: "$exists", Value: true}}
+	closedAt = append(closedAt, period(q.From, q.To)...)
+	closed := append(bson.D{}, base...)
+	closed = append(closed, bson.E{Key: "status", Value: storage.Closed}, bson.E{Key: "status_since", Value: closedAt})
+
+	key := breakdownKey(q.By)
+	groups := make(map[string]*storage.Breakdown)
+	group := func(k any) *storage.Breakdown {
+		name := keyString(k)
+		b, ok := groups[name]
+		if !ok {
+			b = &storage.Breakdown{Key: name}
+			groups[name] = b
+		}
+		return b
+	}
+
+	// Количество заявок по статусам.
+	cursor, err := collection.Aggregate(ctx, append(withArchive(created),
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "key", Value: key}, {Key: "status", Value: "$status"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	))
+	if err != nil {
+		return rep, fmt.Errorf("%s: %w", operation, err)
+	}
+	var totals []struct {
+		ID struct {
+			Key    any            `bson:"key"`
+			Status storage.Status `bson:"status"`
+		} `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(ctx, &totals); err != nil {
+		return rep, fmt.Errorf("%s: %w", operation, err)
+	}
+	for _, t := range totals {
+		rep.Statistic.Add(t.ID.Status, t.Count)
+		if q.By != "" {
+			group(t.ID.Key).Statistic.Add(t.ID.Status, t.Count)
+		}
+	}
+
+	// Длительности закрытия заявок в миллисекундах.
+	cursor, err = collection.Aggregate(ctx, append(withArchive(closed),
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: key},
+			{Key: "durations", Value: bson.D{{Key: "$push", Value: bson.D{
+				{Key: "$subtract", Value: bson.A{"$status_since", "$created"}},
+			}}}},
+		}}},
+	))
+	if err != nil {
+		return rep, fmt.Errorf("%s: %w", operation, err)
+	}
+	var durations []struct {
+		Key       any     `bson:"_id"`
+		Durations []int64 `bson:"durations"`
+	}
+	if err := cursor.All(ctx, &durations); err != nil {
+		return rep, fmt.Errorf("%s: %w", operation, err)
+	}
+	var all []time.Duration
+	for _, d := range durations {
+		ds := make([]time.Duration, 0, len(d.Durations))
+		for _, ms := range d.Durations {
+			ds = append(ds, time.Duration(ms)*time.Millisecond)
+		}
+		all = append(all, ds...)
+		if q.By != "" {
+			group(d.Key).TimeToClose = storage.NewCloseTime(ds)
+		}
+	}
+	rep.TimeToClose = storage.NewCloseTime(all)
+
+	for _, b := range groups {
+		rep.Breakdown = append(rep.Breakdown, *b)
+	}
+	sort.Slice(rep.Breakdown, func(i, j int) bool {
+		if rep.Breakdown[i].Statistic.Total != rep.Breakdown[j].Statistic.Total {
+			return rep.Breakdown[i].Statistic.Total > rep.Breakdown[j].Statistic.Total
+		}
+		return rep.Breakdown[i].Key < rep.Breakdown[j].Key
+	})
+
+	if q.Interval != "" {
+		rep.Series, err = s.series(ctx, created, closed, q.Interval)
+		if err != nil {
+			return rep, fmt.Errorf("%s: %w", operation, err)
+		}
+	}
+	return rep, nil
+}
+
+// series возвращает временной ряд количества заявок, в том числе
+// архивных, отобранных условиями created по времени создания и closed
+// по времени закрытия, с интервалом interval.
+func (s *Storage) series(ctx context.Context, created, closed bson.D, interval string) ([]storage.SeriesPoint, error) {
+	collection := s.db.Database(dbName).Collection(colReport)
+
+	count := func(match bson.D, field string) mongo.Pipeline {
+		trunc := bson.D{{Key: "date", Value: field}, {Key: "unit", Value: interval}}
+		if interval == storage.Week {
+			trunc = append(trunc, bson.E{Key: "startOfWeek", Value: "monday"})
+		}
+		return append(withArchive(match),
+			bson.D{{Key: "$group", Value: bson.D{
+				{Key: "_id", Value: bson.D{{Key: "$dateTrunc", Value: trunc}}},
+				{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			}}},
+		)
+	}
+
+	points := make(map[time.Time]*storage.SeriesPoint)
+	for _, p := range []struct {
+		pipeline mongo.Pipeline
+		closed   bool
+	}{
+		{pipeline: count(created, "$created")},
+		{pipeline: count(closed, "$status_since"), closed: true},
+	} {
+		cursor, err := collection.Aggregate(ctx, p.pipeline)
+		if err != nil {
+			return nil, err
+		}
+		var res []struct {
+			Start time.Time `bson:"_id"`
+			Count int       `bson:"count"`
+		}
+		if err := cursor.All(ctx, &res); err != nil {
+			return nil, err
+		}
+		for _, r := range res {
+			pt, ok := points[r.Start]
+			if !ok {
+				pt = &storage.SeriesPoint{Start: r.Start}
+				points[r.Start] = pt
+			}
+			if p.closed {
+				pt.Closed = r.Count
+			} else {
+				pt.Created = r.Count
+			}
+		}
+	}
+
+	series := make([]storage.SeriesPoint, 0, len(points))
+	for _, pt := range points {
+		series = append(series, *pt)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Start.Before(series[j].Start) })
+	return series, nil
+}
+
+// breakdownKey возвращает выражение группировки для разреза by.
+// Для пустого разреза все заявки попадают в одну группу.
+func breakdownKey(by string) any {
+	switch by {
+	case storage.ByCity:
+		return "$city"
+	case storage.ByDistrict:
+		return "$jurisdiction"
+	case storage.ByCategory:
+		return "$category"
+	default:
+		return nil
+	}
+}
+
+// keyString преобразует значение ключа группировки в строку.
+func keyString(k any) string {
+	switch v := k.(type) {
+	case string:
+		return v
+	case primitive.ObjectID:
+		return v.Hex()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,157 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestStorage_DetailedStatistic(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colArchive = testArchive
+	colJur = testJur
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовые коллекции.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = st.trun(colArchive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = st.trun(colJur)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Заполняем коллекцию тестовыми заявками.
+	for _, v := range reports {
+		_, err := st.addOne(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Закрываем заявки 1 и 2 через 2 и 4 часа после создания.
+	collection := st.db.Database(dbName).Collection(colReport)
+	for num, after := range map[int]time.Duration{1: time.Hour * 2, 2: time.Hour * 4} {
+		_, err := collection.UpdateOne(context.Background(), bson.D{{Key: "number", Value: num}}, mongo.Pipeline{
+			{{Key: "$set", Value: bson.D{
+				{Key: "status", Value: storage.Closed},
+				{Key: "status_since", Value: bson.D{{Key: "$add", Value: bson.A{"$created", after.Milliseconds()}}}},
+			}}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Добавляем в архив заявку, закрытую через 6 часов после создания
+	// 100 дней назад.
+	archivedAt := time.Now().Add(-time.Hour * 24 * 100)
+	archived := reports[0]
+	archived.Number = 10
+	err = st.archiveOne(archived, archivedAt, archivedAt.Add(time.Hour*6))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Добавляем территорию, включающую две заявки из трех.
+	jur, err := st.AddJurisdiction(context.Background(), storage.Jurisdiction{Name: "Центр", Area: area})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		q             storage.StatQuery
+		want          storage.Statistic
+		wantBreakdown int
+		wantClose     storage.CloseTime
+		wantErr       bool
+	}{
+		{
+			name:      "OK Series by day",
+			q:         storage.StatQuery{Interval: storage.Day},
+			want:      storage.Statistic{Total: 4, Unverified: 1, Closed: 3},
+			wantClose: storage.CloseTime{Count: 3, AverageHours: 4, MedianHours: 4},
+			wantErr:   false,
+		},
+		{
+			name:          "OK By city",
+			q:             storage.StatQuery{By: storage.ByCity},
+			want:          storage.Statistic{Total: 4, Unverified: 1, Closed: 3},
+			wantBreakdown: 1,
+			wantClose:     storage.CloseTime{Count: 3, AverageHours: 4, MedianHours: 4},
+			wantErr:       false,
+		},
+		{
+			name:      "OK Archived by period",
+			q:         storage.StatQuery{Interval: storage.Month, From: archivedAt.Add(-time.Hour), To: archivedAt.Add(time.Hour * 12)},
+			want:      storage.Statistic{Total: 1, Closed: 1},
+			wantClose: storage.CloseTime{Count: 1, AverageHours: 6, MedianHours: 6},
+			wantErr:   false,
+		},
+		{
+			name:      "OK District by period",
+			q:         storage.StatQuery{District: jur.ID.Hex(), From: time.Now().Add(-time.Hour), To: time.Now().Add(time.Hour * 3)},
+			want:      storage.Statistic{Total: 2, Closed: 2},
+			wantClose: storage.CloseTime{Count: 1, AverageHours: 2, MedianHours: 2},
+			wantErr:   false,
+		},
+		{
+			name:    "Error Incorrect district",
+			q:       storage.StatQuery{District: "123"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.DetailedStatistic(context.Background(), tt.q)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.DetailedStatistic() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.Statistic != tt.want {
+				t.Errorf("Storage.DetailedStatistic() statistic = %+v, want %+v", got.Statistic, tt.want)
+			}
+			if len(got.Breakdown) != tt.wantBreakdown {
+				t.Errorf("Storage.DetailedStatistic() breakdown = %v, want %d items", got.Breakdown, tt.wantBreakdown)
+			}
+			// Временной ряд содержит все созданные и закрытые заявки.
+			var created, closed int
+			for _, p := range got.Series {
+				created += p.Created
+				closed += p.Closed
+			}
+			if tt.q.Interval != "" && (created != got.Statistic.Total || closed != got.TimeToClose.Count) {
+				t.Errorf("Storage.DetailedStatistic() series = %v, want %d created and %d closed",
+					got.Series, got.Statistic.Total, got.TimeToClose.Count)
+			}
+			c := got.TimeToClose
+			if c.Count != tt.wantClose.Count || math.Abs(c.AverageHours-tt.wantClose.AverageHours) > 0.01 ||
+				math.Abs(c.MedianHours-tt.wantClose.MedianHours) > 0.01 {
+				t.Errorf("Storage.DetailedStatistic() time to close = %+v, want %+v", c, tt.wantClose)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ExpireUnverified устанавливает статус Rejected всем непроверенным
+// заявкам, созданным раньше before, и возвращает измененные заявки.
+// Если таких заявок нет, то вернет пустой слайс.
+func (s *Storage) ExpireUnverified(ctx context.Context, before time.Time) ([]storage.Report, error) {
+	const operation = "storage.mongodb.ExpireUnverified"
+
+	var reports []storage.Report
+	collection := s.db.Database(dbName).Collection(colReport)
+	filter := bson.D{
+		{Key: "status", Value: storage.Unverified},
+		{Key: "created", Value: bson.D{{Key: "$lt", Value: before}}},
+		notDeleted,
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	// Изменяем заявки по одной, чтобы вернуть именно измененные документы
+	// для отправки уведомлений.
+	for {
+		update := setStatus(storage.Rejected, time.Now())
+
+		var report storage.Report
+		err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&report)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return reports, nil
+		}
+		if err != nil {
+			return reports, fmt.Errorf("%s: %w", operation, err)
+		}
+		reports = append(reports, report)
+	}
+}
@@ -0,0 +1,80 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStorage_ExpireUnverified(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colArchive = testArchive
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовые коллекции.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = st.trun(colArchive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовые заявки со статусом Unverified.
+	for _, rep := range reports {
+		_, err = st.addOne(rep)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		before time.Time
+		want   int
+	}{
+		{
+			name:   "OK Not expired",
+			before: time.Now().Add(-time.Hour),
+			want:   0,
+		},
+		{
+			name:   "OK Expired",
+			before: time.Now().Add(time.Hour),
+			want:   len(reports),
+		},
+		{
+			name:   "OK Already rejected",
+			before: time.Now().Add(time.Hour),
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.ExpireUnverified(context.Background(), tt.before)
+			if err != nil {
+				t.Errorf("Storage.ExpireUnverified() error = %v", err)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Storage.ExpireUnverified() = %d, want %d", len(got), tt.want)
+			}
+			for _, rep := range got {
+				if rep.Status != storage.Rejected {
+					t.Errorf("Storage.ExpireUnverified() status = %d, want %d", rep.Status, storage.Rejected)
+				}
+			}
+		})
+	}
+}
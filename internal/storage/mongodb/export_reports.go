@@ -0,0 +1,63 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ExportReports последовательно передает в функцию fn все заявки,
+// соответствующие фильтру, не загружая их в память целиком. Заявки
+// упорядочены по номеру в порядке fl.Sort, по-умолчанию нисходящем.
+// Количество ограничивается только положительным значением fl.Count.
+// Если fn возвращает ошибку, то обход прекращается и ошибка возвращается.
+// Если заявки не найдены, то вернет ошибку ErrArrayNotFound.
+func (s *Storage) ExportReports(ctx context.Context, fl storage.Filter, fn func(storage.Report) error) error {
+	const operation = "storage.mongodb.ExportReports"
+
+	collection := s.db.Database(dbName).Collection(colReport)
+
+	filter, err := s.filter(ctx, fl)
+	if err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+
+	sort := -1
+	if fl.Sort == 1 {
+		sort = 1
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "number", Value: sort}})
+	if fl.Count > 0 {
+		opts.SetLimit(int64(fl.Count))
+	}
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	defer cursor.Close(ctx)
+
+	// Декодируем заявки по одной по мере получения пакетов курсора.
+	n := 0
+	for cursor.Next(ctx) {
+		var rep storage.Report
+		if err := cursor.Decode(&rep); err != nil {
+			return fmt.Errorf("%s: %w", operation, err)
+		}
+		if err := fn(rep); err != nil {
+			return fmt.Errorf("%s: %w", operation, err)
+		}
+		n++
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	return nil
+}
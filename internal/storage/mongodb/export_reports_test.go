@@ -0,0 +1,101 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStorage_ExportReports(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Заполняем коллекцию тестовыми заявками.
+	for _, v := range reports {
+		_, err := st.addOne(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Дополнительные заявки в Казани для проверки выгрузки больше
+	// количества по умолчанию в ReportsWithFilter.
+	for i := 0; i < 25; i++ {
+		rep := reports[2]
+		rep.Number = int64(100 + i)
+		rep.City = "Казань"
+		_, err := st.addOne(rep)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var kazan []int64
+	for i := 0; i < 25; i++ {
+		kazan = append(kazan, int64(100+i))
+	}
+
+	tests := []struct {
+		name    string
+		filter  storage.Filter
+		want    []int64
+		wantErr bool
+	}{
+		{name: "OK Without filter", filter: storage.Filter{City: "Москва"}, want: []int64{3, 2, 1}},
+		{name: "OK Sort and count", filter: storage.Filter{Sort: 1, Count: 2}, want: []int64{1, 2}},
+		{name: "OK More than 20", filter: storage.Filter{City: "Казань", Sort: 1}, want: kazan},
+		{name: "OK Area", filter: storage.Filter{Sort: 1, Area: &area}, want: []int64{1, 2}},
+		{name: "OK City and period", filter: storage.Filter{City: "Москва", From: time.Now().Add(-time.Hour)}, want: []int64{3, 2, 1}},
+		{name: "Error Not found by city", filter: storage.Filter{City: "Самара"}, wantErr: true},
+		{name: "Error Not found by period", filter: storage.Filter{To: time.Now().Add(-time.Hour)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int64
+			err := st.ExportReports(context.Background(), tt.filter, func(rep storage.Report) error {
+				got = append(got, rep.Number)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.ExportReports() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Storage.ExportReports() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Storage.ExportReports() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	// Ошибка функции обработки прекращает обход.
+	stop := errors.New("stop")
+	calls := 0
+	err = st.ExportReports(context.Background(), storage.Filter{}, func(storage.Report) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Storage.ExportReports() error = %v, calls = %d", err, calls)
+	}
+}
@@ -0,0 +1,141 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Heatmap считает количество заявок в ячейках геохешей длиной precision
+// символов. Учитываются заявки, созданные в интервале [from, to), в том
+// числе архивные, нулевое значение границы интервала ее не ограничивает.
+// Если в параметр status передать nil или пустой слайс, то учитываются все
+// заявки. Для каждой ячейки также считает количество разных месяцев, в
+// которые в ней создавались заявки. Ячейки отсортированы по убыванию
+// количества заявок. Подсчет выполняется агрегацией в БД по номерам ячеек
+// сетки геохеша, поэтому из БД передается по одному документу на ячейку
+// и месяц. Если заявки не найдены, то вернет ошибку ErrArrayNotFound.
+func (s *Storage) Heatmap(ctx context.Context, from, to time.Time, status []storage.Status, precision int) ([]storage.Cell, error) {
+	const operation = "storage.mongodb.Heatmap"
+
+	collection := s.db.Database(dbName).Collection(colReport)
+
+	filter := bson.D{notDeleted}
+	if created := period(from, to); created != nil {
+		filter = append(filter, bson.E{Key: "created", Value: created})
+	}
+	if len(status) > 0 {
+		filter = append(filter, bson.E{Key: "status", Value: bson.M{"$in": status}})
+	}
+
+	// Геохеш длиной precision символов содержит 5*precision бит, которые
+	// поочередно делят интервалы долготы и широты, начиная с долготы.
+	// Поэтому ячейка геохеша - ячейка равномерной сетки с 2^lonBits
+	// столбцами и 2^latBits строками.
+	lonBits := (5*precision + 1) / 2
+	latBits := 5 * precision / 2
+	pipeline := append(withArchive(filter),
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "x", Value: gridIndex(0, -180, 360, lonBits)},
+				{Key: "y", Value: gridIndex(1, -90, 180, latBits)},
+				{Key: "month", Value: bson.D{{Key: "$dateTrunc", Value: bson.D{
+					{Key: "date", Value: "$created"},
+					{Key: "unit", Value: "month"},
+				}}}},
+			}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "x", Value: "$_id.x"}, {Key: "y", Value: "$_id.y"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: "$count"}}},
+			{Key: "months", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	)
+	opts := options.Aggregate().SetAllowDiskUse(true)
+
+	cursor, err := collection.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	defer cursor.Close(ctx)
+
+	var res []storage.Cell
+	lonStep := 360 / math.Exp2(float64(lonBits))
+	latStep := 180 / math.Exp2(float64(latBits))
+	for cursor.Next(ctx) {
+		var cell struct {
+			ID struct {
+				X float64 `bson:"x"`
+				Y float64 `bson:"y"`
+			} `bson:"_id"`
+			Count  int `bson:"count"`
+			Months int `bson:"months"`
+		}
+		if err := cursor.Decode(&cell); err != nil {
+			return nil, fmt.Errorf("%s: %w", operation, err)
+		}
+
+		// Геохеш ячейки вычисляется по ее центру, который не лежит на
+		// границе и не зависит от ошибок округления.
+		center := storage.LonLat{-180 + (cell.ID.X+0.5)*lonStep, -90 + (cell.ID.Y+0.5)*latStep}
+		res = append(res, storage.Cell{
+			Geohash: storage.Geohash(center, precision),
+			Count:   cell.Count,
+			Months:  cell.Months,
+		})
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+		return res[i].Geohash < res[j].Geohash
+	})
+	return res, nil
+}
+
+// gridIndex возвращает выражение агрегации для номера ячейки сетки из 2^bits
+// ячеек по координате заявки с индексом axis: 0 - долгота, 1 - широта.
+// Интервал координаты начинается с min и имеет длину size. Значение на
+// верхней границе интервала относится к последней ячейке.
+func gridIndex(axis int, min, size float64, bits int) bson.D {
+	cells := math.Exp2(float64(bits))
+	coord := bson.D{{Key: "$arrayElemAt", Value: bson.A{"$geo.coordinates", axis}}}
+	return bson.D{{Key: "$min", Value: bson.A{
+		bson.D{{Key: "$floor", Value: bson.D{{Key: "$multiply", Value: bson.A{
+			bson.D{{Key: "$divide", Value: bson.A{
+				bson.D{{Key: "$subtract", Value: bson.A{coord, min}}},
+				size,
+			}}},
+			cells,
+		}}}}},
+		cells - 1,
+	}}}
+}
+
+// period возвращает условие фильтра по времени для интервала [from, to).
+// Нулевое значение границы интервала ее не ограничивает. Если обе
+// границы нулевые, то вернет nil.
+func period(from, to time.Time) bson.D {
+	var cond bson.D
+	if !from.IsZero() {
+		cond = append(cond, bson.E{Key: "$gte", Value: from})
+	}
+	if !to.IsZero() {
+		cond = append(cond, bson.E{Key: "$lt", Value: to})
+	}
+	return cond
+}
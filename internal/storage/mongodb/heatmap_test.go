@@ -0,0 +1,105 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStorage_Heatmap(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	colArchive = testArchive
+
+	// Очищаем тестовые коллекции.
+	for _, col := range []string{colReport, colArchive} {
+		if err := st.trun(col); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Заполняем коллекцию тестовыми заявками и добавляем в архив закрытую
+	// заявку рядом с первой.
+	for _, v := range reports {
+		_, err := st.addOne(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	archived := reports[0]
+	archived.Number = 10
+	if err := st.archiveOne(archived, time.Now(), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		from, to  time.Time
+		status    []storage.Status
+		precision int
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      int
+		wantFirst int
+		wantErr   bool
+	}{
+		{
+			name:      "OK Cities",
+			args:      args{status: nil, precision: 3},
+			want:      2,
+			wantFirst: 3,
+			wantErr:   false,
+		},
+		{
+			name:      "OK Archived",
+			args:      args{status: []storage.Status{storage.Closed}, precision: 3},
+			want:      1,
+			wantFirst: 1,
+			wantErr:   false,
+		},
+		{
+			name:      "OK Separate reports",
+			args:      args{from: time.Now().Add(-time.Hour), status: []storage.Status{storage.Unverified}, precision: 8},
+			want:      3,
+			wantFirst: 1,
+			wantErr:   false,
+		},
+		{
+			name:    "Error Not found by period",
+			args:    args{to: time.Now().Add(-time.Hour), status: nil, precision: 3},
+			wantErr: true,
+		},
+		{
+			name:    "Error Not found by status",
+			args:    args{status: []storage.Status{storage.Rejected}, precision: 3},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.Heatmap(context.Background(), tt.args.from, tt.args.to, tt.args.status, tt.args.precision)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.Heatmap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Storage.Heatmap() len = %v, want %v", len(got), tt.want)
+			}
+			if len(got) > 0 && (got[0].Count != tt.wantFirst || got[0].Months != 1) {
+				t.Errorf("Storage.Heatmap() first cell = %+v, want count %d in one month", got[0], tt.wantFirst)
+			}
+		})
+	}
+}
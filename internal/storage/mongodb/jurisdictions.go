@@ -0,0 +1,33 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Jurisdictions возвращает все территории, отсортированные по названию.
+// Если территории не найдены, то вернет ошибку ErrArrayNotFound.
+func (s *Storage) Jurisdictions(ctx context.Context) ([]storage.Jurisdiction, error) {
+	const operation = "storage.mongodb.Jurisdictions"
+
+	var jurs []storage.Jurisdiction
+	collection := s.db.Database(dbName).Collection(colJur)
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	err = cursor.All(ctx, &jurs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(jurs) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+	return jurs, nil
+}
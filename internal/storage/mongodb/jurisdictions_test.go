@@ -0,0 +1,47 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_Jurisdictions(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colJur = testJur
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colJur)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Проверяем пустую коллекцию.
+	_, err = st.Jurisdictions(context.Background())
+	if err == nil {
+		t.Errorf("Storage.Jurisdictions() error = nil, want error for empty collection")
+	}
+
+	// Вставляем тестовую территорию.
+	_, err = st.AddJurisdiction(context.Background(), storage.Jurisdiction{Name: "Центр", Area: area})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := st.Jurisdictions(context.Background())
+	if err != nil {
+		t.Fatalf("Storage.Jurisdictions() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Area.Type != storage.MultiPolygon {
+		t.Errorf("Storage.Jurisdictions() = %v, want one MultiPolygon jurisdiction", got)
+	}
+}
@@ -0,0 +1,56 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MediaFiles возвращает имена всех файлов, на которые ссылаются заявки,
+// включая заявки в корзине и в архиве: медиа файлы, кадры-превью видео
+// и медиа файлы закрытия заявки.
+func (s *Storage) MediaFiles(ctx context.Context) ([]string, error) {
+	const operation = "storage.mongodb.MediaFiles"
+
+	var names []string
+	for _, col := range []string{colReport, colArchive} {
+		n, err := s.mediaFiles(ctx, col)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", operation, err)
+		}
+		names = append(names, n...)
+	}
+	return names, nil
+}
+
+// mediaFiles возвращает имена файлов, на которые ссылаются заявки
+// из коллекции col.
+func (s *Storage) mediaFiles(ctx context.Context, col string) ([]string, error) {
+	collection := s.db.Database(dbName).Collection(col)
+
+	// Получаем только поля с медиа файлами.
+	opts := options.Find().SetProjection(bson.D{
+		{Key: "media", Value: 1},
+		{Key: "resolution", Value: 1},
+	})
+	cursor, err := collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var names []string
+	for cursor.Next(ctx) {
+		var report storage.Report
+		if err := cursor.Decode(&report); err != nil {
+			return nil, err
+		}
+		for _, url := range report.Files() {
+			names = append(names, storage.FileName(url))
+		}
+	}
+	return names, cursor.Err()
+}
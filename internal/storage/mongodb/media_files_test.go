@@ -0,0 +1,55 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestStorage_MediaFiles(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colArchive = testArchive
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовую заявку с видео и фото после ремонта.
+	rep := reports[0]
+	rep.Media = []storage.Media{
+		{URL: "https://ya.ru/photo.jpg", Kind: storage.Photo},
+		{URL: "https://ya.ru/video.mp4", Kind: storage.Video, Poster: "https://ya.ru/poster.jpg"},
+	}
+	rep.Resolution = &storage.Resolution{
+		Comment: "Люк установлен",
+		Media:   []storage.Media{{URL: "https://ya.ru/after.jpg", Kind: storage.Photo}},
+	}
+	_, err = st.addOne(rep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := st.MediaFiles(context.Background())
+	if err != nil {
+		t.Fatalf("Storage.MediaFiles() error = %v", err)
+	}
+	want := []string{"photo.jpg", "video.mp4", "poster.jpg", "after.jpg"}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("Storage.MediaFiles() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// migrateMedia переводит медиа файлы, сохраненные в старом формате строкой
+// со ссылкой на фото, в поддокументы с полями url и kind. Выполняется при
+// подключении к БД для заявок и архива, повторный запуск ничего не меняет.
+// Возвращает количество измененных документов.
+func (s *Storage) migrateMedia(ctx context.Context) (int64, error) {
+	const operation = "storage.mongodb.migrateMedia"
+
+	// Условие $type для массива выполняется, если строкой является
+	// хотя бы один его элемент.
+	filter := bson.D{{Key: "media", Value: bson.D{{Key: "$type", Value: "string"}}}}
+	update := bson.A{
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "media", Value: bson.D{{Key: "$map", Value: bson.D{
+				{Key: "input", Value: "$media"},
+				{Key: "in", Value: bson.D{{Key: "$cond", Value: bson.A{
+					bson.D{{Key: "$eq", Value: bson.A{bson.D{{Key: "$type", Value: "$$this"}}, "string"}}},
+					bson.D{{Key: "url", Value: "$$this"}, {Key: "kind", Value: storage.Photo}},
+					"$$this",
+				}}}},
+			}}}},
+		}}},
+	}
+
+	var total int64
+	for _, name := range []string{colReport, colArchive} {
+		collection := s.db.Database(dbName).Collection(name)
+		res, err := collection.UpdateMany(ctx, filter, update)
+		if err != nil {
+			return total, fmt.Errorf("%s: %w", operation, err)
+		}
+		total += res.ModifiedCount
+	}
+
+	return total, nil
+}
@@ -0,0 +1,84 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestStorage_migrateMedia(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colArchive = testArchive
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовые коллекции.
+	for _, col := range []string{colReport, colArchive} {
+		if err := st.trun(col); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Вставляем заявку в старом формате и заявку со смешанным списком.
+	collection := st.db.Database(dbName).Collection(colReport)
+	docs := []any{
+		bson.D{{Key: "number", Value: 1}, {Key: "media", Value: bson.A{"https://ya.ru/1.jpg", "https://ya.ru/2.jpg"}}},
+		bson.D{{Key: "number", Value: 2}, {Key: "media", Value: bson.A{
+			"https://ya.ru/3.jpg",
+			bson.D{{Key: "url", Value: "https://ya.ru/4.mp4"}, {Key: "kind", Value: "video"}},
+		}}},
+	}
+	if _, err := collection.InsertMany(context.Background(), docs); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := st.migrateMedia(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 2 {
+		t.Errorf("Storage.migrateMedia() = %v, want %v", got, 2)
+	}
+
+	// Повторный запуск не изменяет документы.
+	got, err = st.migrateMedia(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 0 {
+		t.Errorf("Storage.migrateMedia() second run = %v, want %v", got, 0)
+	}
+
+	var rep storage.Report
+	err = collection.FindOne(context.Background(), bson.D{{Key: "number", Value: 2}}).Decode(&rep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []storage.Media{
+		{URL: "https://ya.ru/3.jpg", Kind: storage.Photo},
+		{URL: "https://ya.ru/4.mp4", Kind: storage.Video},
+	}
+	if !reflect.DeepEqual(rep.Media, want) {
+		t.Errorf("media = %v, want %v", rep.Media, want)
+	}
+
+	// После перевода файлы находятся фильтром по полю url.
+	n, err := collection.CountDocuments(context.Background(), bson.D{{Key: "media.url", Value: "https://ya.ru/1.jpg"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("count by media.url = %v, want %v", n, 1)
+	}
+}
@@ -18,6 +18,10 @@ const (
 	database          = "reportStorage"
 	reportCollection  = "reports"
 	counterCollection = "counter"
+	archiveCollection = "archive"
+	orgCollection     = "organizations"
+	jurCollection     = "jurisdictions"
+	tplCollection     = "templates"
 )
 
 // Название базы и коллекции в БД. Используются переменные вместо констант,
@@ -26,8 +30,15 @@ var (
 	dbName     string = database
 	colReport  string = reportCollection
 	colCounter string = counterCollection
+	colArchive string = archiveCollection
+	colOrg     string = orgCollection
+	colJur     string = jurCollection
+	colTpl     string = tplCollection
 )
 
+// notDeleted - условие фильтра, исключающее заявки, перемещенные в корзину.
+var notDeleted = bson.E{Key: "deleted_at", Value: bson.D{{Key: "$exists", Value: false}}}
+
 // tmConn - таймаут на создание пула подключений.
 const tmConn time.Duration = time.Second * 10
 
@@ -74,8 +85,8 @@ func new(opts *options.ClientOptions) (*Storage, error) {
 	}
 
 	// Создаем уникальный индекс по полю number, чтобы избежать
-	// дублирования значений. И геопространственный индекс для работы
-	// с координатами.
+	// дублирования значений, геопространственный индекс для работы
+	// с координатами и индекс по времени удаления для очистки корзины.
 	collection := db.Database(dbName).Collection(colReport)
 	indexUniq := mongo.IndexModel{
 		Keys:    bson.D{{Key: "number", Value: -1}},
@@ -84,12 +95,67 @@ func new(opts *options.ClientOptions) (*Storage, error) {
 	indexGeo := mongo.IndexModel{
 		Keys: bson.D{{Key: "geo", Value: "2dsphere"}},
 	}
-	_, err = collection.Indexes().CreateMany(tm, []mongo.IndexModel{indexUniq, indexGeo})
+	indexDeleted := mongo.IndexModel{
+		Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}
+	_, err = collection.Indexes().CreateMany(tm, []mongo.IndexModel{indexUniq, indexGeo, indexDeleted})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	// Архивные заявки также запрашиваются по уникальному номеру.
+	archive := db.Database(dbName).Collection(colArchive)
+	_, err = archive.Indexes().CreateOne(tm, indexUniq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	// Индекс для выборки заявок, назначенных сотруднику.
+	indexAssignee := mongo.IndexModel{
+		Keys:    bson.D{{Key: "assignee_user", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}
+	_, err = collection.Indexes().CreateOne(tm, indexAssignee)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", operation, err)
 	}
 
-	return &Storage{db: db}, nil
+	// Геопространственный индекс для поиска территории, в границах
+	// которой находится заявка.
+	jurisdictions := db.Database(dbName).Collection(colJur)
+	indexArea := mongo.IndexModel{
+		Keys: bson.D{{Key: "area", Value: "2dsphere"}},
+	}
+	_, err = jurisdictions.Indexes().CreateOne(tm, indexArea)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	// Медиа файлы старых заявок хранятся строками, а фильтры и обновления
+	// медиа работают с поддокументами, поэтому переводим их в новый формат.
+	// Миграция большой коллекции может длиться дольше таймаута подключения,
+	// поэтому выполняется без него.
+	st := &Storage{db: db}
+	if _, err := st.migrateMedia(context.Background()); err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return st, nil
+}
+
+// withArchive возвращает начало конвейера агрегации, который отбирает
+// по условию match заявки коллекции colReport и архива colArchive.
+// Условие применяется к каждой коллекции до объединения, чтобы
+// использовать их индексы.
+func withArchive(match bson.D) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unionWith", Value: bson.D{
+			{Key: "coll", Value: colArchive},
+			{Key: "pipeline", Value: bson.A{bson.D{{Key: "$match", Value: match}}}},
+		}}},
+	}
 }
 
 // Close - обертка для закрытия пула подключений.
@@ -17,6 +17,10 @@ const (
 	testDatabase   = "unitTestDB"
 	testCollection = "unitTestCollection"
 	testCounter    = "unitTestCounter"
+	testArchive    = "unitTestArchive"
+	testOrg        = "unitTestOrganizations"
+	testJur        = "unitTestJurisdictions"
+	testTpl        = "unitTestTemplates"
 )
 
 // path - адрес БД для юнит-тестов.
@@ -30,8 +34,8 @@ var reports = []storage.Report{
 		Address:     "Адрес 1",
 		Description: "Описание заявки 1",
 		Contacts:    storage.Contacts{Email: "bob@gmail.com", Telegram: "@bob"},
-		Media:       []string{"https://google.com"},
-		Geo:         storage.Geo{Coordinates: [2]float64{55.75388130172051, 37.62026781374883}},
+		Media:       []storage.Media{{URL: "https://google.com", Kind: storage.Photo}},
+		Geo:         storage.Geo{Coordinates: storage.LonLat{37.62026781374883, 55.75388130172051}},
 	},
 	{
 		Number:      2,
@@ -39,19 +43,28 @@ var reports = []storage.Report{
 		Address:     "Адрес 2",
 		Description: "Описание заявки 2",
 		Contacts:    storage.Contacts{Email: "bill@gmail.com", Whatsapp: "+71234567890"},
-		Media:       []string{"https://google.com"},
-		Geo:         storage.Geo{Coordinates: [2]float64{55.75909434896026, 37.619124583054855}},
+		Media:       []storage.Media{{URL: "https://google.com", Kind: storage.Photo}},
+		Geo:         storage.Geo{Coordinates: storage.LonLat{37.619124583054855, 55.75909434896026}},
 	},
 	{
 		Number:      3,
 		City:        "Москва",
 		Address:     "Адрес 3",
 		Description: "Описание заявки 3",
-		Media:       []string{"https://google.com"},
-		Geo:         storage.Geo{Coordinates: [2]float64{59.939543808173305, 30.31511987692599}},
+		Media:       []storage.Media{{URL: "https://google.com", Kind: storage.Photo}},
+		Geo:         storage.Geo{Coordinates: storage.LonLat{30.31511987692599, 59.939543808173305}},
 	},
 }
 
+// area - территория для юнит-тестов, квадрат вокруг центра Москвы,
+// включающий заявки 1 и 2. Координаты в порядке долгота, широта.
+var area = storage.Area{
+	Type: storage.MultiPolygon,
+	Coordinates: [][][][2]float64{{{
+		{37.6, 55.74}, {37.64, 55.74}, {37.64, 55.77}, {37.6, 55.77}, {37.6, 55.74},
+	}}},
+}
+
 // addOne добавляет одну заявку в БД. Функция для использования в тестах.
 func (s *Storage) addOne(rep storage.Report) (string, error) {
 
@@ -60,7 +73,6 @@ func (s *Storage) addOne(rep storage.Report) (string, error) {
 	rep.Updated = time.Now()
 	rep.Status = storage.Unverified
 	rep.Geo.Type = "Point"
-	rep.Geo.Coordinates[0], rep.Geo.Coordinates[1] = rep.Geo.Coordinates[1], rep.Geo.Coordinates[0]
 
 	collection := s.db.Database(testDatabase).Collection(testCollection)
 	res, err := collection.InsertOne(context.Background(), rep)
@@ -71,6 +83,22 @@ func (s *Storage) addOne(rep storage.Report) (string, error) {
 	return hex.Hex(), nil
 }
 
+// archiveOne добавляет одну заявку, созданную в момент created и закрытую
+// в момент closed, в архив. Функция для использования в тестах.
+func (s *Storage) archiveOne(rep storage.Report, created, closed time.Time) error {
+
+	rep.ID = primitive.NewObjectID()
+	rep.Created = created
+	rep.Updated = closed
+	rep.Status = storage.Closed
+	rep.StatusSince = closed
+	rep.Geo.Type = "Point"
+
+	collection := s.db.Database(testDatabase).Collection(testArchive)
+	_, err := collection.InsertOne(context.Background(), rep)
+	return err
+}
+
 // getOne возвращает заявку по ObjectID. Функция для использования в тестах.
 func (s *Storage) getOne(id string) (storage.Report, error) {
 
@@ -0,0 +1,35 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// OrganizationByID возвращает организацию по ее ObjectID в виде строки.
+// Если id некорректен, то вернет ошибку ErrIncorrectID. Если организация
+// не найдена, то вернет ошибку ErrOrgNotFound.
+func (s *Storage) OrganizationByID(ctx context.Context, id string) (storage.Organization, error) {
+	const operation = "storage.mongodb.OrganizationByID"
+
+	var org storage.Organization
+	obj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return org, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectID)
+	}
+
+	collection := s.db.Database(dbName).Collection(colOrg)
+	err = collection.FindOne(ctx, bson.D{{Key: "_id", Value: obj}}).Decode(&org)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return org, fmt.Errorf("%s: %w", operation, storage.ErrOrgNotFound)
+		}
+		return org, fmt.Errorf("%s: %w", operation, err)
+	}
+	return org, nil
+}
@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStorage_OrganizationByID(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colOrg = testOrg
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colOrg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовую организацию.
+	org, err := st.AddOrganization(context.Background(), storage.Organization{Name: "Управа района"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			id:      org.ID.Hex(),
+			wantErr: false,
+		},
+		{
+			name:    "Error Not found",
+			id:      primitive.NewObjectID().Hex(),
+			wantErr: true,
+		},
+		{
+			name:    "Error Incorrect ID",
+			id:      "123",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.OrganizationByID(context.Background(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.OrganizationByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Name != org.Name {
+				t.Errorf("Storage.OrganizationByID() = %v, want %v", got.Name, org.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Organizations возвращает все организации, отсортированные по названию.
+// Если организации не найдены, то вернет ошибку ErrArrayNotFound.
+func (s *Storage) Organizations(ctx context.Context) ([]storage.Organization, error) {
+	const operation = "storage.mongodb.Organizations"
+
+	var orgs []storage.Organization
+	collection := s.db.Database(dbName).Collection(colOrg)
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	err = cursor.All(ctx, &orgs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(orgs) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+	return orgs, nil
+}
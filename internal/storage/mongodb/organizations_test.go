@@ -0,0 +1,50 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_Organizations(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colOrg = testOrg
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colOrg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Проверяем пустую коллекцию.
+	_, err = st.Organizations(context.Background())
+	if err == nil {
+		t.Errorf("Storage.Organizations() error = nil, want error for empty collection")
+	}
+
+	// Вставляем тестовые организации.
+	for _, name := range []string{"Управа района", "ЖКС №1"} {
+		_, err = st.AddOrganization(context.Background(), storage.Organization{Name: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := st.Organizations(context.Background())
+	if err != nil {
+		t.Fatalf("Storage.Organizations() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Storage.Organizations() len = %v, want %v", len(got), 2)
+	}
+}
@@ -0,0 +1,81 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Overdue возвращает заявки, которые находятся в текущем статусе дольше,
+// чем допускают правила rules на момент now. Заявки отсортированы по
+// времени установки статуса, сначала самые давние. Для заявок без времени
+// установки статуса используется время последнего изменения. Если заявки
+// не найдены, то вернет ошибку ErrArrayNotFound.
+func (s *Storage) Overdue(ctx context.Context, rules []storage.SLARule, now time.Time) ([]storage.Report, error) {
+	const operation = "storage.mongodb.Overdue"
+
+	var reports []storage.Report
+	filter := overdueFilter(rules, now)
+	if filter == nil {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	opts := options.Find().SetSort(bson.D{{Key: "status_since", Value: 1}})
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	err = cursor.All(ctx, &reports)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	return reports, nil
+}
+
+// overdueFilter формирует фильтр просроченных заявок по правилам rules.
+// Правила с неположительным лимитом не учитываются. Если ни одного
+// правила нет, то вернет nil.
+func overdueFilter(rules []storage.SLARule, now time.Time) bson.D {
+	// Категории, для которых заданы отдельные правила, исключаются из
+	// правила по умолчанию для того же статуса.
+	special := make(map[storage.Status][]string)
+	for _, r := range rules {
+		if r.Category != "" && r.Limit > 0 {
+			special[r.Status] = append(special[r.Status], r.Category)
+		}
+	}
+
+	var or bson.A
+	for _, r := range rules {
+		if r.Limit <= 0 {
+			continue
+		}
+		clause := bson.D{{Key: "status", Value: r.Status}}
+		switch {
+		case r.Category != "":
+			clause = append(clause, bson.E{Key: "category", Value: r.Category})
+		case len(special[r.Status]) > 0:
+			clause = append(clause, bson.E{Key: "category", Value: bson.D{{Key: "$nin", Value: special[r.Status]}}})
+		}
+		clause = append(clause, bson.E{Key: "$expr", Value: bson.D{
+			{Key: "$lt", Value: bson.A{
+				bson.D{{Key: "$ifNull", Value: bson.A{"$status_since", "$updated"}}},
+				now.Add(-r.Limit),
+			}},
+		}})
+		or = append(or, clause)
+	}
+	if len(or) == 0 {
+		return nil
+	}
+	return bson.D{notDeleted, {Key: "$or", Value: or}}
+}
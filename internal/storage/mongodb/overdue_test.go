@@ -0,0 +1,87 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStorage_Overdue(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовые заявки, первая с категорией "hatch".
+	for i, rep := range reports {
+		if i == 0 {
+			rep.Category = "hatch"
+		}
+		_, err = st.addOne(rep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = st.UpdateStatus(context.Background(), int(rep.Number), storage.Opened)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now().Add(time.Hour * 2)
+	tests := []struct {
+		name    string
+		rules   []storage.SLARule
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "OK Default rule",
+			rules: []storage.SLARule{{Status: storage.Opened, Limit: time.Hour}},
+			want:  len(reports),
+		},
+		{
+			name: "OK Category rule",
+			rules: []storage.SLARule{
+				{Status: storage.Opened, Limit: time.Hour * 3},
+				{Status: storage.Opened, Category: "hatch", Limit: time.Hour},
+			},
+			want: 1,
+		},
+		{
+			name:    "Error Not overdue",
+			rules:   []storage.SLARule{{Status: storage.InProgress, Limit: time.Hour}},
+			wantErr: true,
+		},
+		{
+			name:    "Error No rules",
+			rules:   nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.Overdue(context.Background(), tt.rules, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.Overdue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Storage.Overdue() = %d, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
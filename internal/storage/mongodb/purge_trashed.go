@@ -0,0 +1,24 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// PurgeTrashed окончательно удаляет заявки, перемещенные в корзину
+// раньше before, и возвращает удаленные заявки. Если таких заявок нет,
+// то вернет пустой слайс.
+func (s *Storage) PurgeTrashed(ctx context.Context, before time.Time) ([]storage.Report, error) {
+	const operation = "storage.mongodb.PurgeTrashed"
+
+	filter := bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$lt", Value: before}}}}
+	reports, err := s.deleteEach(ctx, filter)
+	if err != nil {
+		return reports, fmt.Errorf("%s: %w", operation, err)
+	}
+	return reports, nil
+}
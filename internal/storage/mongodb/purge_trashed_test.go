@@ -0,0 +1,68 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStorage_PurgeTrashed(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовые заявки и перемещаем первую в корзину.
+	for _, rep := range reports {
+		_, err = st.addOne(rep)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = st.TrashByNum(context.Background(), 1, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		before time.Time
+		want   int
+	}{
+		{
+			name:   "OK Retention not expired",
+			before: time.Now().Add(-time.Hour),
+			want:   0,
+		},
+		{
+			name:   "OK Retention expired",
+			before: time.Now().Add(time.Hour),
+			want:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.PurgeTrashed(context.Background(), tt.before)
+			if err != nil {
+				t.Errorf("Storage.PurgeTrashed() error = %v", err)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Storage.PurgeTrashed() = %d, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
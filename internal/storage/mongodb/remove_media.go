@@ -0,0 +1,136 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RemoveMedia удаляет медиа файл с именем name из заявки по ее номеру и
+// возвращает удаленный медиа файл и его позицию в списке медиа заявки.
+// Имя файла - последний элемент его ссылки.
+// Последний медиа файл заявки удалить нельзя, в этом случае вернет ошибку
+// ErrMediaLimit. Если файл не найден в заявке, то вернет ошибку ErrMediaNotFound.
+// Если документ с указанным номером не найден, то вернет ошибку ErrReportNotFound.
+func (s *Storage) RemoveMedia(ctx context.Context, num int, name string) (media storage.Media, pos int, err error) {
+	const operation = "storage.mongodb.RemoveMedia"
+
+	if num < 1 {
+		return media, pos, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	filter := bson.D{{Key: "number", Value: num}, notDeleted}
+
+	// Получаем заявку и ищем в ней файл по имени.
+	var report storage.Report
+	err = collection.FindOne(ctx, filter).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return media, pos, fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
+		}
+		return media, pos, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	pos = -1
+	for i, m := range report.Media {
+		if m.Name() == name {
+			media = m
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return media, 0, fmt.Errorf("%s: %w", operation, storage.ErrMediaNotFound)
+	}
+	if len(report.Media) < 2 {
+		return media, pos, fmt.Errorf("%s: %w", operation, storage.ErrMediaLimit)
+	}
+
+	// Удаляем файл с повторной проверкой условий в фильтре, так как
+	// заявка могла измениться после чтения.
+	filter = bson.D{
+		{Key: "number", Value: num},
+		notDeleted,
+		{Key: "media.url", Value: media.URL},
+		{Key: "$expr", Value: bson.D{
+			{Key: "$gt", Value: bson.A{
+				bson.D{{Key: "$size", Value: "$media"}},
+				1,
+			}},
+		}},
+	}
+	update := bson.D{
+		{Key: "$pull", Value: bson.D{
+			{Key: "media", Value: bson.D{{Key: "url", Value: media.URL}}},
+		}},
+		{Key: "$set", Value: bson.D{
+			{Key: "updated", Value: time.Now()},
+		}},
+	}
+	res, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return media, pos, fmt.Errorf("%s: %w", operation, err)
+	}
+	if res.ModifiedCount == 0 {
+		return media, pos, fmt.Errorf("%s: %w", operation, storage.ErrMediaNotFound)
+	}
+
+	return media, pos, nil
+}
+
+// RestoreMedia возвращает медиа файл, удаленный RemoveMedia, в заявку по
+// ее номеру на позицию pos в списке медиа. Используется для отмены удаления,
+// поэтому ограничение storage.MaxMedia не проверяется. Если файл уже есть
+// в заявке, то заявка не изменяется. Если документ с указанным номером не
+// найден, то вернет ошибку ErrReportNotFound.
+func (s *Storage) RestoreMedia(ctx context.Context, num int, pos int, media storage.Media) error {
+	const operation = "storage.mongodb.RestoreMedia"
+
+	if num < 1 {
+		return fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+	}
+	if pos < 0 {
+		pos = 0
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	// Условие на ссылку файла исключает его дублирование при повторном
+	// возврате.
+	filter := bson.D{
+		{Key: "number", Value: num},
+		notDeleted,
+		{Key: "media.url", Value: bson.D{{Key: "$ne", Value: media.URL}}},
+	}
+	update := bson.D{
+		{Key: "$push", Value: bson.D{
+			{Key: "media", Value: bson.D{
+				{Key: "$each", Value: []storage.Media{media}},
+				{Key: "$position", Value: pos},
+			}},
+		}},
+		{Key: "$set", Value: bson.D{
+			{Key: "updated", Value: time.Now()},
+		}},
+	}
+	res, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	if res.MatchedCount == 0 {
+		c, err := collection.CountDocuments(ctx, bson.D{{Key: "number", Value: num}, notDeleted})
+		if err != nil {
+			return fmt.Errorf("%s: %w", operation, err)
+		}
+		if c == 0 {
+			return fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
+		}
+	}
+
+	return nil
+}
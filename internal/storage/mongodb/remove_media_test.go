@@ -0,0 +1,148 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestStorage_RemoveMedia(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовую заявку с двумя медиа файлами.
+	rep := reports[0]
+	rep.Media = []storage.Media{
+		{URL: "https://ya.ru/1.jpg", Kind: storage.Photo},
+		{URL: "https://ya.ru/2.mp4", Kind: storage.Video, Poster: "https://ya.ru/3.jpg"},
+	}
+	_, err = st.addOne(rep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		num      int
+		filename string
+		want     string
+		wantPos  int
+		wantErr  bool
+	}{
+		{
+			name:     "OK",
+			num:      1,
+			filename: "2.mp4",
+			want:     "https://ya.ru/2.mp4",
+			wantPos:  1,
+			wantErr:  false,
+		},
+		{
+			name:     "Error Last file",
+			num:      1,
+			filename: "1.jpg",
+			want:     "https://ya.ru/1.jpg",
+			wantErr:  true,
+		},
+		{
+			name:     "Error File not found",
+			num:      1,
+			filename: "2.mp4",
+			want:     "",
+			wantErr:  true,
+		},
+		{
+			name:     "Error Not found",
+			num:      5,
+			filename: "1.jpg",
+			want:     "",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pos, err := st.RemoveMedia(context.Background(), tt.num, tt.filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.RemoveMedia() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.URL != tt.want {
+				t.Errorf("Storage.RemoveMedia() = %s, want %s", got.URL, tt.want)
+			}
+			if !tt.wantErr && pos != tt.wantPos {
+				t.Errorf("Storage.RemoveMedia() pos = %d, want %d", pos, tt.wantPos)
+			}
+		})
+	}
+}
+
+func TestStorage_RestoreMedia(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовую заявку с тремя медиа файлами.
+	rep := reports[0]
+	rep.Media = []storage.Media{
+		{URL: "https://ya.ru/1.jpg", Kind: storage.Photo},
+		{URL: "https://ya.ru/2.jpg", Kind: storage.Photo},
+		{URL: "https://ya.ru/3.jpg", Kind: storage.Photo},
+	}
+	id, err := st.addOne(rep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Удаленный файл возвращается на прежнее место, повторный возврат
+	// не дублирует его.
+	media, pos, err := st.RemoveMedia(context.Background(), 1, "2.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := st.RestoreMedia(context.Background(), 1, pos, media); err != nil {
+			t.Fatalf("Storage.RestoreMedia() error = %v", err)
+		}
+	}
+	got, err := st.getOne(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Media, rep.Media) {
+		t.Errorf("Storage.RestoreMedia() media = %v, want %v", got.Media, rep.Media)
+	}
+
+	if err := st.RestoreMedia(context.Background(), 5, 0, media); !errors.Is(err, storage.ErrReportNotFound) {
+		t.Errorf("Storage.RestoreMedia() error = %v, want %v", err, storage.ErrReportNotFound)
+	}
+}
@@ -0,0 +1,92 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReorderMedia меняет порядок медиа файлов заявки по ее номеру. Аргумент
+// names должен содержать имена всех файлов заявки ровно по одному разу в
+// новом порядке, иначе вернет ошибку ErrIncorrectMedia. Если документ с
+// указанным номером не найден, то вернет ошибку ErrReportNotFound.
+// Возвращает заявку после изменения.
+func (s *Storage) ReorderMedia(ctx context.Context, num int, names []string) (storage.Report, error) {
+	const operation = "storage.mongodb.ReorderMedia"
+
+	var report storage.Report
+	if num < 1 {
+		return report, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	filter := bson.D{{Key: "number", Value: num}, notDeleted}
+
+	err := collection.FindOne(ctx, filter).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return report, fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
+		}
+		return report, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	// Формируем новый порядок файлов.
+	media, err := reorder(report.Media, names)
+	if err != nil {
+		return report, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	// Фильтр по исходному списку файлов не даст перезаписать изменения,
+	// сделанные после чтения заявки.
+	filter = bson.D{
+		{Key: "number", Value: num},
+		notDeleted,
+		{Key: "media", Value: report.Media},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "media", Value: media},
+			{Key: "updated", Value: time.Now()},
+		}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err = collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return report, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectMedia)
+		}
+		return report, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return report, nil
+}
+
+// reorder возвращает медиа файлы в порядке, заданном слайсом имен.
+func reorder(media []storage.Media, names []string) ([]storage.Media, error) {
+	if len(names) != len(media) {
+		return nil, storage.ErrIncorrectMedia
+	}
+
+	byName := make(map[string]storage.Media, len(media))
+	for _, m := range media {
+		byName[m.Name()] = m
+	}
+
+	res := make([]storage.Media, 0, len(names))
+	for _, name := range names {
+		m, ok := byName[name]
+		if !ok {
+			return nil, storage.ErrIncorrectMedia
+		}
+		delete(byName, name)
+		res = append(res, m)
+	}
+	return res, nil
+}
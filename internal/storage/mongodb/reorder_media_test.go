@@ -0,0 +1,85 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_ReorderMedia(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовую заявку с тремя медиа файлами.
+	rep := reports[0]
+	rep.Media = []storage.Media{
+		{URL: "https://ya.ru/1.jpg", Kind: storage.Photo},
+		{URL: "https://ya.ru/2.jpg", Kind: storage.Photo},
+		{URL: "https://ya.ru/3.jpg", Kind: storage.Photo},
+	}
+	_, err = st.addOne(rep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		num     int
+		names   []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			num:     1,
+			names:   []string{"3.jpg", "1.jpg", "2.jpg"},
+			want:    "https://ya.ru/3.jpg",
+			wantErr: false,
+		},
+		{
+			name:    "Error Missing file",
+			num:     1,
+			names:   []string{"3.jpg", "1.jpg"},
+			wantErr: true,
+		},
+		{
+			name:    "Error Duplicate file",
+			num:     1,
+			names:   []string{"3.jpg", "3.jpg", "1.jpg"},
+			wantErr: true,
+		},
+		{
+			name:    "Error Not found",
+			num:     5,
+			names:   []string{"1.jpg"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.ReorderMedia(context.Background(), tt.num, tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.ReorderMedia() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Media[0].URL != tt.want {
+				t.Errorf("Storage.ReorderMedia() first = %s, want %s", got.Media[0].URL, tt.want)
+			}
+		})
+	}
+}
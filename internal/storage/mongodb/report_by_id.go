@@ -29,7 +29,7 @@ func (s *Storage) ReportByID(ctx context.Context, id string) (storage.Report, er
 	}
 
 	collection := s.db.Database(dbName).Collection(colReport)
-	filter := bson.D{{Key: "_id", Value: obj}}
+	filter := bson.D{{Key: "_id", Value: obj}, notDeleted}
 	err = collection.FindOne(ctx, filter).Decode(&report)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
@@ -38,8 +38,5 @@ func (s *Storage) ReportByID(ctx context.Context, id string) (storage.Report, er
 		return report, fmt.Errorf("%s: %w", operation, err)
 	}
 
-	// Меняем местами долготу и широту.
-	report.Geo.Coordinates[0], report.Geo.Coordinates[1] = report.Geo.Coordinates[1], report.Geo.Coordinates[0]
-
 	return report, nil
 }
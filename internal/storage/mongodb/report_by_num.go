@@ -10,7 +10,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// ReportByNum возвращает заявку по ее уникальному номеру. Аргумент num
+// ReportByNum возвращает заявку по ее уникальному номеру. Если заявка
+// не найдена среди действующих, то она ищется в архиве. Аргумент num
 // должен быть больше 0, иначе вернет ошибку ErrIncorrectNum. Если документ
 // с указанным номером не найден, то вернет ошибку ErrReportNotFound.
 func (s *Storage) ReportByNum(ctx context.Context, num int) (storage.Report, error) {
@@ -22,8 +23,12 @@ func (s *Storage) ReportByNum(ctx context.Context, num int) (storage.Report, err
 	}
 
 	collection := s.db.Database(dbName).Collection(colReport)
-	filter := bson.D{{Key: "number", Value: num}}
+	filter := bson.D{{Key: "number", Value: num}, notDeleted}
 	err := collection.FindOne(ctx, filter).Decode(&report)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		archive := s.db.Database(dbName).Collection(colArchive)
+		err = archive.FindOne(ctx, bson.D{{Key: "number", Value: num}}).Decode(&report)
+	}
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return report, fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
@@ -31,8 +36,5 @@ func (s *Storage) ReportByNum(ctx context.Context, num int) (storage.Report, err
 		return report, fmt.Errorf("%s: %w", operation, err)
 	}
 
-	// Меняем местами долготу и широту.
-	report.Geo.Coordinates[0], report.Geo.Coordinates[1] = report.Geo.Coordinates[1], report.Geo.Coordinates[0]
-
 	return report, nil
 }
@@ -20,11 +20,9 @@ func (s *Storage) Reports(ctx context.Context, status []storage.Status) ([]stora
 	collection := s.db.Database(dbName).Collection(colReport)
 
 	// Задаем фильтр по статусам, если они переданы.
-	filter := bson.D{}
+	filter := bson.D{notDeleted}
 	if len(status) > 0 {
-		filter = bson.D{
-			{Key: "status", Value: bson.M{"$in": status}},
-		}
+		filter = append(filter, bson.E{Key: "status", Value: bson.M{"$in": status}})
 	}
 
 	// Устанавливаем сортировку по полю number в убывающем порядке.
@@ -44,10 +42,5 @@ func (s *Storage) Reports(ctx context.Context, status []storage.Status) ([]stora
 		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
 	}
 
-	// Меняем местами долготу и широту.
-	for i := range reports {
-		reports[i].Geo.Coordinates[0], reports[i].Geo.Coordinates[1] = reports[i].Geo.Coordinates[1], reports[i].Geo.Coordinates[0]
-	}
-
 	return reports, nil
 }
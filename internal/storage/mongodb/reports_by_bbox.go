@@ -0,0 +1,74 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReportsByBBox возвращает заявки в границах прямоугольной области box
+// с фильтрацией по статусам для отображения на карте. У заявок заполнены
+// только поля number, status, category и geo. Если в параметр status
+// передать nil или пустой слайс, то вернет заявки со всеми статусами.
+// Если limit больше 0, то вернет случайную выборку не более limit заявок.
+// Не проверяет область, ожидает значение, прошедшее проверку
+// BBox.Validate. Если заявки не найдены, то вернет ошибку ErrArrayNotFound.
+func (s *Storage) ReportsByBBox(ctx context.Context, box storage.BBox, status []storage.Status, limit int) ([]storage.Report, error) {
+	const operation = "storage.mongodb.ReportsByBBox"
+
+	var reports []storage.Report
+	collection := s.db.Database(dbName).Collection(colReport)
+
+	filter := bson.D{notDeleted, inBox(box)}
+	// Расширяем фильтр статусами, если они переданы.
+	if len(status) > 0 {
+		filter = append(filter, bson.E{Key: "status", Value: bson.M{"$in": status}})
+	}
+
+	// Проекция выполняется до выборки, чтобы случайная сортировка
+	// не загружала документы заявок целиком.
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "number", Value: 1},
+			{Key: "status", Value: 1},
+			{Key: "category", Value: 1},
+			{Key: "geo", Value: 1},
+		}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$sample", Value: bson.D{{Key: "size", Value: limit}}}})
+	}
+	opts := options.Aggregate().SetAllowDiskUse(true)
+
+	// Получаем заявки из БД.
+	cursor, err := collection.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	// Записываем все заявки в массив структур.
+	err = cursor.All(ctx, &reports)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	return reports, nil
+}
+
+// inBox возвращает условие фильтра, выбирающее заявки в границах
+// прямоугольной области. Область разбивается на части не больше
+// полусферы, каждая из которых использует индекс 2dsphere.
+func inBox(box storage.BBox) bson.E {
+	parts := bson.A{}
+	for _, area := range box.Areas() {
+		parts = append(parts, bson.D{within(area)})
+	}
+	return bson.E{Key: "$or", Value: parts}
+}
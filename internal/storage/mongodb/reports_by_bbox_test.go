@@ -0,0 +1,90 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_ReportsByBBox(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Заполняем коллекцию тестовыми заявками.
+	for _, v := range reports {
+		_, err := st.addOne(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type args struct {
+		box    storage.BBox
+		status []storage.Status
+		limit  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "OK Moscow",
+			args:    args{box: storage.BBox{Min: storage.LonLat{37.6, 55.74}, Max: storage.LonLat{37.64, 55.77}}, status: nil},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "OK World",
+			args:    args{box: storage.BBox{Min: storage.LonLat{-180, -90}, Max: storage.LonLat{180, 90}}, status: []storage.Status{storage.Unverified}},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name:    "OK World sample",
+			args:    args{box: storage.BBox{Min: storage.LonLat{-180, -90}, Max: storage.LonLat{180, 90}}, status: nil, limit: 2},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "Error Not found",
+			args:    args{box: storage.BBox{Min: storage.LonLat{0, 0}, Max: storage.LonLat{1, 1}}, status: nil},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.ReportsByBBox(context.Background(), tt.args.box, tt.args.status, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.ReportsByBBox() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Storage.ReportsByBBox() len = %v, want %v", len(got), tt.want)
+			}
+			// Заявки возвращаются только с полями для карты.
+			for _, rep := range got {
+				if rep.Number == 0 || rep.Description != "" || len(rep.Media) != 0 {
+					t.Errorf("Storage.ReportsByBBox() report = %+v, want projected fields only", rep)
+				}
+			}
+		})
+	}
+}
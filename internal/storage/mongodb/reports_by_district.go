@@ -0,0 +1,72 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReportsByDistrict возвращает все заявки в границах территории с ObjectID
+// id с фильтрацией по статусам. Если в параметр status передать nil или
+// пустой слайс, то вернет все заявки. Если id некорректен, то вернет ошибку
+// ErrIncorrectID, если территория не найдена - ErrJurisdictionNotFound.
+// Если заявки не найдены, то вернет ошибку ErrArrayNotFound.
+func (s *Storage) ReportsByDistrict(ctx context.Context, id string, status []storage.Status) ([]storage.Report, error) {
+	const operation = "storage.mongodb.ReportsByDistrict"
+
+	var reports []storage.Report
+
+	// Создаем фильтр из границ территории.
+	district, err := s.district(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	filter := bson.D{notDeleted, district}
+	// Расширяем фильтр статусами, если они переданы.
+	if len(status) > 0 {
+		filter = append(filter, bson.E{Key: "status", Value: bson.M{"$in": status}})
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	err = cursor.All(ctx, &reports)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	return reports, nil
+}
+
+// district возвращает условие фильтра, выбирающее заявки в границах
+// территории с ObjectID id. Если id некорректен, то вернет ошибку
+// ErrIncorrectID, если территория не найдена - ErrJurisdictionNotFound.
+func (s *Storage) district(ctx context.Context, id string) (bson.E, error) {
+	obj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return bson.E{}, storage.ErrIncorrectID
+	}
+
+	var jur storage.Jurisdiction
+	collection := s.db.Database(dbName).Collection(colJur)
+	opts := options.FindOne().SetProjection(bson.D{{Key: "area", Value: 1}})
+	err = collection.FindOne(ctx, bson.D{{Key: "_id", Value: obj}}, opts).Decode(&jur)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return bson.E{}, storage.ErrJurisdictionNotFound
+		}
+		return bson.E{}, err
+	}
+	return within(jur.Area), nil
+}
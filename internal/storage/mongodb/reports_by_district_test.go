@@ -0,0 +1,98 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStorage_ReportsByDistrict(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colJur = testJur
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовые коллекции.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = st.trun(colJur)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Заполняем коллекцию тестовыми заявками.
+	for _, v := range reports {
+		_, err := st.addOne(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Добавляем территорию, включающую две заявки из трех.
+	jur, err := st.AddJurisdiction(context.Background(), storage.Jurisdiction{Name: "Центр", Area: area})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		status  []storage.Status
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "OK Two reports",
+			id:      jur.ID.Hex(),
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "OK With status",
+			id:      jur.ID.Hex(),
+			status:  []storage.Status{storage.Unverified},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "Error Not found by status",
+			id:      jur.ID.Hex(),
+			status:  []storage.Status{storage.Opened},
+			wantErr: true,
+		},
+		{
+			name:    "Error District not found",
+			id:      primitive.NewObjectID().Hex(),
+			wantErr: true,
+		},
+		{
+			name:    "Error Incorrect ID",
+			id:      "123",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.ReportsByDistrict(context.Background(), tt.id, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.ReportsByDistrict() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Storage.ReportsByDistrict() len = %v, want %v", len(got), tt.want)
+			}
+		})
+	}
+}
@@ -116,7 +116,7 @@ func TestStorage_ReportsByPoly(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := st.ReportsByPoly(context.Background(), tt.args.poly, tt.args.status)
+			got, err := st.ReportsByPoly(context.Background(), storage.QuadArea(tt.args.poly), tt.args.status)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Storage.ReportsByPoly() error = %v, wantErr %v", err, tt.wantErr)
 				return
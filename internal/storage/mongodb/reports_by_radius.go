@@ -6,40 +6,52 @@ import (
 	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// ReportsByRadius возвращает все заявки в радиусе от точки с фильтрацией
-// по статусам. Если в параметр status передать nil или пустой слайс, то
-// вернет все заявки. r - радиус в метрах; p - структура точки координат
-// storage.Geo, где поле Type должно иметь значение "Point". Не проверяет
-// принимаемые аргументы, ожидает полностью валидные значения. Если заявки
-// не найдены, то вернет ошибку ErrArrayNotFound.
-func (s *Storage) ReportsByRadius(ctx context.Context, r int, p storage.Geo, status []storage.Status) ([]storage.Report, error) {
+// ReportsByRadius возвращает заявки вокруг точки, отсортированные по
+// удалению от нее, вместе с расстоянием до каждой заявки в метрах.
+// r - радиус поиска в метрах, если он равен 0, то расстояние не
+// ограничивается; limit - максимальное количество заявок, если он
+// равен 0, то количество не ограничивается. Так, r = 0 и limit = k
+// возвращают k ближайших заявок. p - структура точки координат
+// storage.Geo, где поле Type должно иметь значение "Point". Если в
+// параметр status передать nil или пустой слайс, то фильтрация по
+// статусам не выполняется. Не проверяет принимаемые аргументы, ожидает
+// полностью валидные значения. Если заявки не найдены, то вернет
+// ошибку ErrArrayNotFound.
+func (s *Storage) ReportsByRadius(ctx context.Context, r int, p storage.Geo, limit int, status []storage.Status) ([]storage.NearReport, error) {
 	const operation = "storage.mongodb.ReportsByRadius"
 
-	var reports []storage.Report
+	var reports []storage.NearReport
 	collection := s.db.Database(dbName).Collection(colReport)
 
-	// Меняем местами широту и долготу, затем формируем GeoJSON.
-	p.Coordinates[0], p.Coordinates[1] = p.Coordinates[1], p.Coordinates[0]
-	point := bson.D{{Key: "type", Value: p.Type}, {Key: "coordinates", Value: p.Coordinates}}
-
-	// Создаем фильтр из точки и радиуса.
-	filter := bson.M{}
-	filter["geo"] = bson.D{
-		{Key: "$near", Value: bson.D{
-			{Key: "$geometry", Value: point},
-			{Key: "$maxDistance", Value: r},
-		}},
+	// Фильтр заявок, к которым применяется поиск по удалению.
+	query := bson.D{notDeleted}
+	if len(status) > 0 {
+		query = append(query, bson.E{Key: "status", Value: bson.M{"$in": status}})
 	}
 
-	// Расширяем фильтр статусами, если они переданы.
-	if len(status) > 0 {
-		filter["status"] = bson.M{"$in": status}
+	// Формируем стадию $geoNear, она сортирует заявки по удалению
+	// от точки и записывает расстояние в поле distance.
+	near := bson.D{
+		{Key: "near", Value: bson.D{{Key: "type", Value: p.Type}, {Key: "coordinates", Value: p.Coordinates}}},
+		{Key: "key", Value: "geo"},
+		{Key: "distanceField", Value: "distance"},
+		{Key: "spherical", Value: true},
+		{Key: "query", Value: query},
+	}
+	if r != 0 {
+		near = append(near, bson.E{Key: "maxDistance", Value: r})
 	}
 
-	// Получаем все заявки из БД.
-	cursor, err := collection.Find(ctx, filter)
+	pipeline := mongo.Pipeline{{{Key: "$geoNear", Value: near}}}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	// Получаем заявки из БД.
+	cursor, err := collection.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", operation, err)
 	}
@@ -52,10 +64,5 @@ func (s *Storage) ReportsByRadius(ctx context.Context, r int, p storage.Geo, sta
 		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
 	}
 
-	// Меняем местами долготу и широту.
-	for i := range reports {
-		reports[i].Geo.Coordinates[0], reports[i].Geo.Coordinates[1] = reports[i].Geo.Coordinates[1], reports[i].Geo.Coordinates[0]
-	}
-
 	return reports, nil
 }
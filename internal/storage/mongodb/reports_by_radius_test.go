@@ -36,6 +36,7 @@ func TestStorage_ReportsByRadius(t *testing.T) {
 	type args struct {
 		radius int
 		point  storage.Geo
+		limit  int
 		status []storage.Status
 	}
 	tests := []struct {
@@ -50,7 +51,7 @@ func TestStorage_ReportsByRadius(t *testing.T) {
 				radius: 3000,
 				point: storage.Geo{
 					Type:        "Point",
-					Coordinates: [2]float64{55.75583793441133, 37.620437229927795},
+					Coordinates: storage.LonLat{37.620437229927795, 55.75583793441133},
 				},
 				status: nil,
 			},
@@ -63,20 +64,48 @@ func TestStorage_ReportsByRadius(t *testing.T) {
 				radius: 1000,
 				point: storage.Geo{
 					Type:        "Point",
-					Coordinates: [2]float64{59.93820021009978, 30.3172182590169},
+					Coordinates: storage.LonLat{30.3172182590169, 59.93820021009978},
 				},
 				status: []storage.Status{},
 			},
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name: "OK Limit",
+			args: args{
+				radius: 3000,
+				point: storage.Geo{
+					Type:        "Point",
+					Coordinates: storage.LonLat{37.620437229927795, 55.75583793441133},
+				},
+				limit:  1,
+				status: nil,
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "OK K nearest without radius",
+			args: args{
+				radius: 0,
+				point: storage.Geo{
+					Type:        "Point",
+					Coordinates: storage.LonLat{35.91217524893142, 56.866556467082695},
+				},
+				limit:  2,
+				status: nil,
+			},
+			want:    2,
+			wantErr: false,
+		},
 		{
 			name: "Error Not found by status",
 			args: args{
 				radius: 3000,
 				point: storage.Geo{
 					Type:        "Point",
-					Coordinates: [2]float64{55.75583793441133, 37.620437229927795},
+					Coordinates: storage.LonLat{37.620437229927795, 55.75583793441133},
 				},
 				status: []storage.Status{2},
 			},
@@ -89,7 +118,7 @@ func TestStorage_ReportsByRadius(t *testing.T) {
 				radius: 3000,
 				point: storage.Geo{
 					Type:        "Point",
-					Coordinates: [2]float64{56.866556467082695, 35.91217524893142},
+					Coordinates: storage.LonLat{35.91217524893142, 56.866556467082695},
 				},
 				status: nil,
 			},
@@ -102,7 +131,7 @@ func TestStorage_ReportsByRadius(t *testing.T) {
 				radius: -1000,
 				point: storage.Geo{
 					Type:        "Point",
-					Coordinates: [2]float64{55.75583793441133, 37.620437229927795},
+					Coordinates: storage.LonLat{37.620437229927795, 55.75583793441133},
 				},
 				status: nil,
 			},
@@ -112,7 +141,7 @@ func TestStorage_ReportsByRadius(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := st.ReportsByRadius(context.Background(), tt.args.radius, tt.args.point, tt.args.status)
+			got, err := st.ReportsByRadius(context.Background(), tt.args.radius, tt.args.point, tt.args.limit, tt.args.status)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Storage.ReportsByRadius() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -120,6 +149,11 @@ func TestStorage_ReportsByRadius(t *testing.T) {
 			if len(got) != tt.want {
 				t.Errorf("Storage.ReportsByRadius() len = %v, want %v", len(got), tt.want)
 			}
+			for i := 1; i < len(got); i++ {
+				if got[i].Distance < got[i-1].Distance {
+					t.Errorf("Storage.ReportsByRadius() reports are not sorted by distance")
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ReportsByRoute возвращает заявки в коридоре шириной width метров вдоль
+// маршрута route, по половине ширины с каждой стороны, с фильтрацией по
+// статусам. Заявки отсортированы по положению вдоль маршрута от его
+// начала. Если в параметр status передать nil или пустой слайс, то
+// фильтрация по статусам не выполняется. Не проверяет маршрут, ожидает
+// значение, прошедшее проверку Route.Validate. Если заявки не найдены,
+// то вернет ошибку ErrArrayNotFound.
+func (s *Storage) ReportsByRoute(ctx context.Context, route storage.Route, width float64, status []storage.Status) ([]storage.Report, error) {
+	const operation = "storage.mongodb.ReportsByRoute"
+
+	var reports []storage.Report
+	collection := s.db.Database(dbName).Collection(colReport)
+
+	// Коридор составляется из прямоугольников вокруг отрезков маршрута
+	// и кругов вокруг его точек, каждое условие использует индекс 2dsphere.
+	half := width / 2
+	corridor := bson.A{}
+	for _, area := range route.Corridor(half) {
+		corridor = append(corridor, bson.D{within(area)})
+	}
+	for _, pt := range route.Coordinates {
+		corridor = append(corridor, bson.D{{Key: "geo", Value: bson.D{
+			{Key: "$geoWithin", Value: bson.D{
+				{Key: "$centerSphere", Value: bson.A{pt, half / storage.EarthRadius}},
+			}},
+		}}})
+	}
+
+	filter := bson.D{notDeleted, {Key: "$or", Value: corridor}}
+	// Расширяем фильтр статусами, если они переданы.
+	if len(status) > 0 {
+		filter = append(filter, bson.E{Key: "status", Value: bson.M{"$in": status}})
+	}
+
+	// Получаем все заявки из БД.
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	// Записываем все заявки в массив структур.
+	err = cursor.All(ctx, &reports)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	// Сортируем заявки по положению вдоль маршрута.
+	along := make(map[int64]float64, len(reports))
+	for _, rep := range reports {
+		along[rep.Number], _ = route.Locate(rep.Geo.Coordinates)
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return along[reports[i].Number] < along[reports[j].Number]
+	})
+
+	return reports, nil
+}
@@ -0,0 +1,93 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestStorage_ReportsByRoute(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Заполняем коллекцию тестовыми заявками.
+	for _, v := range reports {
+		_, err := st.addOne(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Маршрут с юга на север мимо заявок 1 и 2 в центре Москвы.
+	north := storage.Route{Type: storage.LineString, Coordinates: []storage.LonLat{{37.62, 55.753}, {37.6195, 55.76}}}
+	south := storage.Route{Type: storage.LineString, Coordinates: []storage.LonLat{{37.6195, 55.76}, {37.62, 55.753}}}
+
+	type args struct {
+		route  storage.Route
+		width  float64
+		status []storage.Status
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []int64
+		wantErr bool
+	}{
+		{
+			name:    "OK North",
+			args:    args{route: north, width: 200, status: nil},
+			want:    []int64{1, 2},
+			wantErr: false,
+		},
+		{
+			name:    "OK South",
+			args:    args{route: south, width: 200, status: []storage.Status{storage.Unverified}},
+			want:    []int64{2, 1},
+			wantErr: false,
+		},
+		{
+			name:    "Error Narrow corridor",
+			args:    args{route: north, width: 10, status: nil},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Error Not found by status",
+			args:    args{route: north, width: 200, status: []storage.Status{storage.Closed}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.ReportsByRoute(context.Background(), tt.args.route, tt.args.width, tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.ReportsByRoute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var nums []int64
+			for _, rep := range got {
+				nums = append(nums, rep.Number)
+			}
+			if !reflect.DeepEqual(nums, tt.want) {
+				t.Errorf("Storage.ReportsByRoute() = %v, want %v", nums, tt.want)
+			}
+		})
+	}
+}
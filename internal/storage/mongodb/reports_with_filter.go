@@ -11,20 +11,19 @@ import (
 
 // ReportsWithFilter возвращает заявки в соответствии с переданными параметрами
 // фильтра. Если параметр фильтра не задан или имеет некорректное значение, то
-// используется значение по-умолчанию. Если заявки не найдены, то вернет ошибку
-// ErrArrayNotFound.
+// используется значение по-умолчанию. Многоугольник фильтра не проверяется,
+// ожидается значение, прошедшее проверку Area.Validate. Если задана территория,
+// но она не найдена, то вернет ошибку ErrJurisdictionNotFound. Если заявки
+// не найдены, то вернет ошибку ErrArrayNotFound.
 func (s *Storage) ReportsWithFilter(ctx context.Context, fl storage.Filter) ([]storage.Report, error) {
 	const operation = "storage.mongodb.ReportsWithFilter"
 
 	var reports []storage.Report
 	collection := s.db.Database(dbName).Collection(colReport)
 
-	// Задаем фильтр по статусам, если они переданы.
-	filter := bson.D{}
-	if len(fl.Status) > 0 {
-		filter = bson.D{
-			{Key: "status", Value: bson.M{"$in": fl.Status}},
-		}
+	filter, err := s.filter(ctx, fl)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
 	}
 
 	// Задаем порядок сортировки. По-умолчанию -1, нисходящий.
@@ -55,10 +54,35 @@ func (s *Storage) ReportsWithFilter(ctx context.Context, fl storage.Filter) ([]s
 		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
 	}
 
-	// Меняем местами долготу и широту.
-	for i := range reports {
-		reports[i].Geo.Coordinates[0], reports[i].Geo.Coordinates[1] = reports[i].Geo.Coordinates[1], reports[i].Geo.Coordinates[0]
-	}
-
 	return reports, nil
 }
+
+// filter возвращает условия запроса заявок по параметрам фильтра кроме
+// количества и порядка сортировки. Если задана территория, но она
+// не найдена, то вернет ошибку ErrJurisdictionNotFound.
+func (s *Storage) filter(ctx context.Context, fl storage.Filter) (bson.D, error) {
+	filter := bson.D{notDeleted}
+	// Задаем фильтр по статусам, если они переданы.
+	if len(fl.Status) > 0 {
+		filter = append(filter, bson.E{Key: "status", Value: bson.M{"$in": fl.Status}})
+	}
+	// Задаем фильтр по границам территории, если она передана.
+	if fl.District != "" {
+		district, err := s.district(ctx, fl.District)
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, district)
+	}
+	// Задаем фильтр по времени создания, городу и многоугольнику.
+	if created := period(fl.From, fl.To); created != nil {
+		filter = append(filter, bson.E{Key: "created", Value: created})
+	}
+	if fl.City != "" {
+		filter = append(filter, bson.E{Key: "city", Value: fl.City})
+	}
+	if fl.Area != nil {
+		filter = append(filter, within(*fl.Area))
+	}
+	return filter, nil
+}
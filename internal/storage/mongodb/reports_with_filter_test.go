@@ -12,6 +12,7 @@ func TestStorage_ReportsWithFilter(t *testing.T) {
 	// Создаем пул подключений.
 	dbName = testDatabase
 	colReport = testCollection
+	colJur = testJur
 	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
 	st, err := new(opts)
 	if err != nil {
@@ -19,11 +20,15 @@ func TestStorage_ReportsWithFilter(t *testing.T) {
 	}
 	defer st.Close()
 
-	// Очищаем тестовую коллекцию.
+	// Очищаем тестовые коллекции.
 	err = st.trun(colReport)
 	if err != nil {
 		t.Fatal(err)
 	}
+	err = st.trun(colJur)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	// Заполняем коллекцию тестовыми заявками.
 	for _, v := range reports {
@@ -33,6 +38,12 @@ func TestStorage_ReportsWithFilter(t *testing.T) {
 		}
 	}
 
+	// Добавляем территорию, включающую две заявки из трех.
+	jur, err := st.AddJurisdiction(context.Background(), storage.Jurisdiction{Name: "Центр", Area: area})
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []struct {
 		name    string
 		filter  storage.Filter
@@ -57,6 +68,18 @@ func TestStorage_ReportsWithFilter(t *testing.T) {
 			want:    3,
 			wantErr: false,
 		},
+		{
+			name:    "OK District",
+			filter:  storage.Filter{District: jur.ID.Hex()},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "Error Incorrect district",
+			filter:  storage.Filter{District: "123"},
+			want:    0,
+			wantErr: true,
+		},
 		{
 			name:    "Error Not found by status",
 			filter:  storage.Filter{Count: 2, Status: []storage.Status{2}},
@@ -0,0 +1,61 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Resolve закрывает заявку по ее номеру: устанавливает статус Closed и
+// сохраняет комментарий и медиа файлы после ремонта. Закрыть можно только
+// заявку, которая еще не закрыта и не отклонена, иначе вернет ошибку
+// ErrIncorrectStatus. Если документ с указанным номером не найден, то
+// вернет ошибку ErrReportNotFound. Возвращает заявку после изменения.
+func (s *Storage) Resolve(ctx context.Context, num int, res storage.Resolution) (storage.Report, error) {
+	const operation = "storage.mongodb.Resolve"
+
+	var report storage.Report
+	if num < 1 {
+		return report, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	filter := bson.D{
+		{Key: "number", Value: num},
+		notDeleted,
+		{Key: "status", Value: bson.M{"$nin": []storage.Status{storage.Closed, storage.Rejected}}},
+	}
+	update := setStatus(storage.Closed, time.Now(), bson.E{Key: "resolution", Value: res})
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return report, fmt.Errorf("%s: %w", operation, s.statusError(ctx, num))
+		}
+		return report, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return report, nil
+}
+
+// statusError определяет причину, по которой заявка с номером num не была
+// изменена фильтром с условием на статус. Если заявка существует, то
+// возвращает ErrIncorrectStatus, иначе ErrReportNotFound.
+func (s *Storage) statusError(ctx context.Context, num int) error {
+	collection := s.db.Database(dbName).Collection(colReport)
+	c, err := collection.CountDocuments(ctx, bson.D{{Key: "number", Value: num}, notDeleted})
+	if err != nil {
+		return err
+	}
+	if c == 0 {
+		return storage.ErrReportNotFound
+	}
+	return storage.ErrIncorrectStatus
+}
@@ -0,0 +1,82 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStorage_Resolve(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовую заявку.
+	_, err = st.addOne(reports[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := storage.Resolution{
+		Comment:  "Люк установлен",
+		Media:    []storage.Media{{URL: "https://ya.ru/after.jpg", Kind: storage.Photo}},
+		Resolved: time.Now(),
+		By:       "worker",
+	}
+	tests := []struct {
+		name    string
+		num     int
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			num:     1,
+			wantErr: false,
+		},
+		{
+			name:    "Error Already closed",
+			num:     1,
+			wantErr: true,
+		},
+		{
+			name:    "Error Incorrect number",
+			num:     -1,
+			wantErr: true,
+		},
+		{
+			name:    "Error Not found",
+			num:     5,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.Resolve(context.Background(), tt.num, res)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.Resolve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.Status != storage.Closed || got.Resolution == nil {
+				t.Errorf("Storage.Resolve() status = %d, resolution = %v", got.Status, got.Resolution)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RestoreByNum возвращает заявку из корзины по ее уникальному номеру.
+// Аргумент num должен быть больше 0, иначе вернет ошибку ErrIncorrectNum.
+// Если заявка не найдена в корзине, то вернет ошибку ErrReportNotFound.
+func (s *Storage) RestoreByNum(ctx context.Context, num int) (storage.Report, error) {
+	const operation = "storage.mongodb.RestoreByNum"
+
+	var report storage.Report
+	if num < 1 {
+		return report, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	filter := bson.D{
+		{Key: "number", Value: num},
+		{Key: "deleted_at", Value: bson.D{{Key: "$exists", Value: true}}},
+	}
+	update := bson.D{
+		{Key: "$unset", Value: bson.D{
+			{Key: "deleted_at", Value: ""},
+			{Key: "deleted_by", Value: ""},
+		}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return report, fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
+		}
+		return report, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	return report, nil
+}
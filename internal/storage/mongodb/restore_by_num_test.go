@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_RestoreByNum(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовую заявку и перемещаем ее в корзину.
+	_, err = st.addOne(reports[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = st.TrashByNum(context.Background(), 1, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		num     int
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			num:     1,
+			wantErr: false,
+		},
+		{
+			name:    "Error Not in trash",
+			num:     1,
+			wantErr: true,
+		},
+		{
+			name:    "Error Incorrect number",
+			num:     -1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.RestoreByNum(context.Background(), tt.num)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.RestoreByNum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (got.Deleted != nil || got.DeletedBy != "") {
+				t.Errorf("Storage.RestoreByNum() = %v, want report without deletion fields", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TemplateByID возвращает шаблон письма по его ObjectID в виде строки.
+// Если id некорректен, то вернет ошибку ErrIncorrectID. Если шаблон
+// не найден, то вернет ошибку ErrTemplateNotFound.
+func (s *Storage) TemplateByID(ctx context.Context, id string) (storage.Template, error) {
+	const operation = "storage.mongodb.TemplateByID"
+
+	var tpl storage.Template
+	obj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return tpl, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectID)
+	}
+
+	collection := s.db.Database(dbName).Collection(colTpl)
+	err = collection.FindOne(ctx, bson.D{{Key: "_id", Value: obj}}).Decode(&tpl)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return tpl, fmt.Errorf("%s: %w", operation, storage.ErrTemplateNotFound)
+		}
+		return tpl, fmt.Errorf("%s: %w", operation, err)
+	}
+	return tpl, nil
+}
@@ -0,0 +1,69 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStorage_TemplateByID(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colTpl = testTpl
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colTpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовый шаблон.
+	tpl, err := st.AddTemplate(context.Background(), storage.Template{Name: "Жалоба", Title: "Заявка", Body: "Текст"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			id:      tpl.ID.Hex(),
+			wantErr: false,
+		},
+		{
+			name:    "Error Not found",
+			id:      primitive.NewObjectID().Hex(),
+			wantErr: true,
+		},
+		{
+			name:    "Error Incorrect ID",
+			id:      "123",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.TemplateByID(context.Background(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.TemplateByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Body != tpl.Body {
+				t.Errorf("Storage.TemplateByID() = %v, want %v", got.Body, tpl.Body)
+			}
+		})
+	}
+}
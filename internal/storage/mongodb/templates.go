@@ -0,0 +1,33 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Templates возвращает все шаблоны писем, отсортированные по названию.
+// Если шаблоны не найдены, то вернет ошибку ErrArrayNotFound.
+func (s *Storage) Templates(ctx context.Context) ([]storage.Template, error) {
+	const operation = "storage.mongodb.Templates"
+
+	var tpls []storage.Template
+	collection := s.db.Database(dbName).Collection(colTpl)
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	err = cursor.All(ctx, &tpls)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(tpls) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+	return tpls, nil
+}
@@ -0,0 +1,49 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_Templates(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colTpl = testTpl
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colTpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Проверяем пустую коллекцию.
+	_, err = st.Templates(context.Background())
+	if err == nil {
+		t.Errorf("Storage.Templates() error = nil, want error for empty collection")
+	}
+
+	// Вставляем тестовые шаблоны.
+	for _, name := range []string{"Жалоба", "Повторная жалоба"} {
+		_, err = st.AddTemplate(context.Background(), storage.Template{Name: name, Title: "Заявка", Body: "Текст"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := st.Templates(context.Background())
+	if err != nil {
+		t.Fatalf("Storage.Templates() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "Жалоба" {
+		t.Errorf("Storage.Templates() = %v, want 2 templates sorted by name", got)
+	}
+}
@@ -0,0 +1,39 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TrashByNum перемещает заявку в корзину по ее уникальному номеру,
+// сохраняя время удаления и идентификатор пользователя by. Аргумент num
+// должен быть больше 0, иначе вернет ошибку ErrIncorrectNum. Если заявка
+// не найдена или уже находится в корзине, то вернет ошибку ErrReportNotFound.
+func (s *Storage) TrashByNum(ctx context.Context, num int, by string) error {
+	const operation = "storage.mongodb.TrashByNum"
+
+	if num < 1 {
+		return fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+	}
+
+	collection := s.db.Database(dbName).Collection(colReport)
+	filter := bson.D{{Key: "number", Value: num}, notDeleted}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "deleted_at", Value: time.Now()},
+			{Key: "deleted_by", Value: by},
+		}},
+	}
+	res, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_TrashByNum(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовую заявку.
+	_, err = st.addOne(reports[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		num     int
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			num:     1,
+			wantErr: false,
+		},
+		{
+			name:    "Error Already in trash",
+			num:     1,
+			wantErr: true,
+		},
+		{
+			name:    "Error Incorrect number",
+			num:     -1,
+			wantErr: true,
+		},
+		{
+			name:    "Error Not found",
+			num:     5,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := st.TrashByNum(context.Background(), tt.num, "admin"); (err != nil) != tt.wantErr {
+				t.Errorf("Storage.TrashByNum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Заявка в корзине не должна возвращаться обычными запросами.
+	if _, err := st.ReportByNum(context.Background(), 1); err == nil {
+		t.Errorf("Storage.ReportByNum() returned trashed report")
+	}
+}
@@ -0,0 +1,37 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Trashed возвращает заявки из корзины, отсортированные по времени
+// удаления в убывающем порядке. Если заявки не найдены, то вернет
+// ошибку ErrArrayNotFound.
+func (s *Storage) Trashed(ctx context.Context) ([]storage.Report, error) {
+	const operation = "storage.mongodb.Trashed"
+
+	var reports []storage.Report
+	collection := s.db.Database(dbName).Collection(colReport)
+
+	filter := bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$exists", Value: true}}}}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	err = cursor.All(ctx, &reports)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("%s: %w", operation, storage.ErrArrayNotFound)
+	}
+
+	return reports, nil
+}
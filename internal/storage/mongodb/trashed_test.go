@@ -0,0 +1,46 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestStorage_Trashed(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовые заявки и перемещаем первую в корзину.
+	for _, rep := range reports {
+		_, err = st.addOne(rep)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = st.TrashByNum(context.Background(), 1, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := st.Trashed(context.Background())
+	if err != nil {
+		t.Fatalf("Storage.Trashed() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Number != 1 || got[0].DeletedBy != "admin" {
+		t.Errorf("Storage.Trashed() = %v, want report 1 deleted by admin", got)
+	}
+}
@@ -0,0 +1,30 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// UpdateOrganization полностью заменяет организацию с ObjectID из org,
+// включая список сотрудников. Если организация не найдена, то вернет
+// ошибку ErrOrgNotFound.
+func (s *Storage) UpdateOrganization(ctx context.Context, org storage.Organization) error {
+	const operation = "storage.mongodb.UpdateOrganization"
+
+	if org.Members == nil {
+		org.Members = []storage.Member{}
+	}
+
+	collection := s.db.Database(dbName).Collection(colOrg)
+	res, err := collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: org.ID}}, org)
+	if err != nil {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("%s: %w", operation, storage.ErrOrgNotFound)
+	}
+	return nil
+}
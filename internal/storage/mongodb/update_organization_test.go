@@ -0,0 +1,71 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStorage_UpdateOrganization(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colReport = testCollection
+	colOrg = testOrg
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colOrg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовую организацию.
+	org, err := st.AddOrganization(context.Background(), storage.Organization{Name: "Управа района"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated := org
+	updated.Members = []storage.Member{{User: "worker1", Name: "Иван"}}
+
+	tests := []struct {
+		name    string
+		org     storage.Organization
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			org:     updated,
+			wantErr: false,
+		},
+		{
+			name:    "Error Not found",
+			org:     storage.Organization{ID: primitive.NewObjectID(), Name: "Нет"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := st.UpdateOrganization(context.Background(), tt.org)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.UpdateOrganization() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	got, err := st.OrganizationByID(context.Background(), org.ID.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Member("worker1"); !ok {
+		t.Errorf("Storage.UpdateOrganization() members = %v, want worker1", got.Members)
+	}
+}
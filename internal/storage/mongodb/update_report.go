@@ -5,48 +5,91 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// UpdateReport полностью заменяет заявку на переданную по ее уникальному
-// номеру. Аргумент rep должен содержать все поля заявки с корректными
-// значениями. Возвращает заявку ДО ее изменения, либо ошибку. Если документ
-// с указанным номером не найден, то вернет ошибку ErrReportNotFound.
-func (s *Storage) UpdateReport(ctx context.Context, rep storage.Report) (storage.Report, error) {
+// UpdateReport изменяет поля заявки, которые редактирует клиент, по ее
+// уникальному номеру: город, адрес, описание, категорию, контакты, медиа
+// файлы, координаты, статус и результат закрытия. История статусов,
+// территория, исполнители и время создания изменяются только сервером и
+// сохраняются. Возвращает заявку до изменения и заявку в том виде, в
+// котором она сохранена в БД. Если документ с указанным номером не найден,
+// то вернет ошибку ErrReportNotFound.
+func (s *Storage) UpdateReport(ctx context.Context, rep storage.Report) (origin, updated storage.Report, err error) {
 	const operation = "storage.mongodb.UpdateReport"
 
-	// origin будет содержать заявку до ее изменения.
-	var origin storage.Report
-
 	if rep.Number < 1 {
-		return origin, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
+		return origin, updated, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectNum)
 	}
 	if _, err := primitive.ObjectIDFromHex(rep.ID.Hex()); err != nil {
-		return origin, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectID)
+		return origin, updated, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectID)
 	}
 	if !checkStatus(rep.Status) {
-		return origin, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectStatus)
+		return origin, updated, fmt.Errorf("%s: %w", operation, storage.ErrIncorrectStatus)
 	}
 
-	// Меняем местами широту и долготу у обновленной заявки.
-	rep.Geo.Coordinates[0], rep.Geo.Coordinates[1] = rep.Geo.Coordinates[1], rep.Geo.Coordinates[0]
-
 	collection := s.db.Database(dbName).Collection(colReport)
-	filter := bson.D{{Key: "number", Value: rep.Number}}
+	filter := bson.D{{Key: "number", Value: rep.Number}, notDeleted}
+
+	// Изменение выполняется одним запросом, чтобы не потерять изменения
+	// статуса и назначения, сделанные одновременно другими запросами.
+	// Время приводится к точности хранения в БД, чтобы возвращаемая заявка
+	// совпадала с сохраненной.
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	if rep.Resolution != nil {
+		res := *rep.Resolution
+		res.Resolved = res.Resolved.UTC().Truncate(time.Millisecond)
+		rep.Resolution = &res
+	}
+	update := setStatus(rep.Status, now,
+		bson.E{Key: "city", Value: rep.City},
+		bson.E{Key: "address", Value: rep.Address},
+		bson.E{Key: "description", Value: rep.Description},
+		bson.E{Key: "category", Value: rep.Category},
+		bson.E{Key: "contacts", Value: rep.Contacts},
+		bson.E{Key: "media", Value: rep.Media},
+		bson.E{Key: "geo", Value: rep.Geo},
+	)
+	if rep.Resolution != nil {
+		update = append(update, bson.D{{Key: "$set", Value: bson.D{
+			{Key: "resolution", Value: bson.D{{Key: "$literal", Value: rep.Resolution}}},
+		}}})
+	} else {
+		update = append(update, bson.D{{Key: "$unset", Value: "resolution"}})
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
 
-	err := collection.FindOneAndReplace(ctx, filter, rep).Decode(&origin)
+	err = collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&origin)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return origin, fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
+			return origin, updated, fmt.Errorf("%s: %w", operation, storage.ErrReportNotFound)
 		}
-		return origin, fmt.Errorf("%s: %w", operation, err)
+		return origin, updated, fmt.Errorf("%s: %w", operation, err)
 	}
 
-	// Меняем местами широту и долготу у заявки до изменения.
-	origin.Geo.Coordinates[0], origin.Geo.Coordinates[1] = origin.Geo.Coordinates[1], origin.Geo.Coordinates[0]
+	// Сохраненная заявка однозначно определяется исходной и изменением,
+	// так как изменение выполнено атомарно.
+	updated = origin
+	updated.City = rep.City
+	updated.Address = rep.Address
+	updated.Description = rep.Description
+	updated.Category = rep.Category
+	updated.Contacts = rep.Contacts
+	updated.Media = rep.Media
+	updated.Geo = rep.Geo
+	updated.Resolution = rep.Resolution
+	updated.Updated = now
+	if origin.Status != rep.Status {
+		updated.Status = rep.Status
+		updated.StatusSince = now
+		updated.History = append(origin.History[:len(origin.History):len(origin.History)],
+			storage.StatusChange{Status: rep.Status, Changed: now})
+	}
 
-	return origin, nil
+	return origin, updated, nil
 }
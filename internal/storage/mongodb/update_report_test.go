@@ -43,7 +43,7 @@ func TestStorage_UpdateReport(t *testing.T) {
 	type args struct {
 		number int
 		desc   string
-		media  []string
+		media  []storage.Media
 		status storage.Status
 	}
 	tests := []struct {
@@ -55,25 +55,25 @@ func TestStorage_UpdateReport(t *testing.T) {
 		{
 			name:      "OK Number 1",
 			reportNum: 0,
-			args:      args{number: 1, desc: "Новое описание заявки 1", media: []string{"https://bing.com", "https://ya.ru"}, status: 3},
+			args:      args{number: 1, desc: "Новое описание заявки 1", media: []storage.Media{{URL: "https://bing.com", Kind: storage.Photo}, {URL: "https://ya.ru", Kind: storage.Photo}}, status: 3},
 			wantErr:   false,
 		},
 		{
 			name:      "Error Incorrect number",
 			reportNum: 1,
-			args:      args{number: -1, desc: "Новое описание заявки 2", media: []string{}, status: 1},
+			args:      args{number: -1, desc: "Новое описание заявки 2", media: []storage.Media{}, status: 1},
 			wantErr:   true,
 		},
 		{
 			name:      "Error Incorrect status",
 			reportNum: 1,
-			args:      args{number: 2, desc: "Новое описание заявки 2", media: []string{}, status: 6},
+			args:      args{number: 2, desc: "Новое описание заявки 2", media: []storage.Media{}, status: 6},
 			wantErr:   true,
 		},
 		{
 			name:      "Error Not found",
 			reportNum: 2,
-			args:      args{number: 4, desc: "Новое описание заявки 3", media: []string{}, status: 1},
+			args:      args{number: 4, desc: "Новое описание заявки 3", media: []storage.Media{}, status: 1},
 			wantErr:   true,
 		},
 	}
@@ -87,7 +87,7 @@ func TestStorage_UpdateReport(t *testing.T) {
 			new.Status = tt.args.status
 
 			// Выполняем изменение.
-			got, err := st.UpdateReport(context.Background(), new)
+			got, stored, err := st.UpdateReport(context.Background(), new)
 			if err != nil {
 				if tt.wantErr {
 					t.Skip()
@@ -98,6 +98,19 @@ func TestStorage_UpdateReport(t *testing.T) {
 			if !reflect.DeepEqual(got, want[tt.reportNum]) {
 				t.Errorf("Storage.UpdateReport() = %v, want %v", got, want[tt.reportNum])
 			}
+
+			// Возвращенная заявка совпадает с сохраненной в БД и содержит
+			// историю статусов.
+			saved, err := st.getOne(got.ID.Hex())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(stored, saved) {
+				t.Errorf("Storage.UpdateReport() stored = %v, want %v", stored, saved)
+			}
+			if len(saved.History) != len(got.History)+1 {
+				t.Errorf("Storage.UpdateReport() history = %v, want status change appended", saved.History)
+			}
 		})
 	}
 }
@@ -29,14 +29,9 @@ func (s *Storage) UpdateStatus(ctx context.Context, num int, status storage.Stat
 	}
 
 	collection := s.db.Database(dbName).Collection(colReport)
-	filter := bson.D{{Key: "number", Value: num}}
+	filter := bson.D{{Key: "number", Value: num}, notDeleted}
 
-	update := bson.D{
-		{Key: "$set", Value: bson.D{
-			{Key: "status", Value: status},
-			{Key: "updated", Value: time.Now()},
-		}},
-	}
+	update := setStatus(status, time.Now())
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
 	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&report)
@@ -49,3 +44,32 @@ func (s *Storage) UpdateStatus(ctx context.Context, num int, status storage.Stat
 
 	return report, nil
 }
+
+// setStatus возвращает обновление, которое устанавливает заявке статус
+// status и дополнительные поля set. Если статус изменился, то обновляются
+// время установки статуса и история статусов. Значения полей set не
+// интерпретируются как выражения агрегации.
+func setStatus(status storage.Status, now time.Time, set ...bson.E) mongo.Pipeline {
+	changed := bson.D{{Key: "$ne", Value: bson.A{"$status", status}}}
+	fields := bson.D{
+		{Key: "status_since", Value: bson.D{
+			{Key: "$cond", Value: bson.A{changed, now, "$status_since"}},
+		}},
+		{Key: "history", Value: bson.D{
+			{Key: "$cond", Value: bson.A{
+				changed,
+				bson.D{{Key: "$concatArrays", Value: bson.A{
+					bson.D{{Key: "$ifNull", Value: bson.A{"$history", bson.A{}}}},
+					bson.A{storage.StatusChange{Status: status, Changed: now}},
+				}}},
+				"$history",
+			}},
+		}},
+		{Key: "status", Value: status},
+		{Key: "updated", Value: now},
+	}
+	for _, e := range set {
+		fields = append(fields, bson.E{Key: e.Key, Value: bson.D{{Key: "$literal", Value: e.Value}}})
+	}
+	return mongo.Pipeline{{{Key: "$set", Value: fields}}}
+}
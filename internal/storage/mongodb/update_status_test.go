@@ -45,6 +45,13 @@ func TestStorage_UpdateStatus(t *testing.T) {
 			wantStatus: 2,
 			wantErr:    false,
 		},
+		{
+			name:       "OK Same status",
+			num:        1,
+			status:     2,
+			wantStatus: 2,
+			wantErr:    false,
+		},
 		{
 			name:       "Error Incorrect number",
 			num:        -1,
@@ -82,4 +89,13 @@ func TestStorage_UpdateStatus(t *testing.T) {
 			}
 		})
 	}
+
+	// Повторная установка того же статуса не меняет историю.
+	got, err := st.ReportByNum(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.History) != 1 || got.History[0].Status != 2 || got.StatusSince.IsZero() {
+		t.Errorf("Storage.UpdateStatus() history = %v, since = %v", got.History, got.StatusSince)
+	}
 }
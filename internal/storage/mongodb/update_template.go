@@ -0,0 +1,29 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// UpdateTemplate полностью заменяет шаблон письма с ObjectID из tpl
+// и обновляет время его изменения. Возвращает сохраненный шаблон. Если
+// шаблон не найден, то вернет ошибку ErrTemplateNotFound.
+func (s *Storage) UpdateTemplate(ctx context.Context, tpl storage.Template) (storage.Template, error) {
+	const operation = "storage.mongodb.UpdateTemplate"
+
+	tpl.Updated = time.Now()
+
+	collection := s.db.Database(dbName).Collection(colTpl)
+	res, err := collection.ReplaceOne(ctx, bson.D{{Key: "_id", Value: tpl.ID}}, tpl)
+	if err != nil {
+		return tpl, fmt.Errorf("%s: %w", operation, err)
+	}
+	if res.MatchedCount == 0 {
+		return tpl, fmt.Errorf("%s: %w", operation, storage.ErrTemplateNotFound)
+	}
+	return tpl, nil
+}
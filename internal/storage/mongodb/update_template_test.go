@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"Report-Storage/internal/storage"
+	"context"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestStorage_UpdateTemplate(t *testing.T) {
+
+	// Создаем пул подключений.
+	dbName = testDatabase
+	colTpl = testTpl
+	opts := setOpts(path, "admin", os.Getenv("MONGO_DB_PASSWD"))
+	st, err := new(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	// Очищаем тестовую коллекцию.
+	err = st.trun(colTpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вставляем тестовый шаблон.
+	tpl, err := st.AddTemplate(context.Background(), storage.Template{Name: "Жалоба", Title: "Заявка", Body: "Текст"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated := tpl
+	updated.Body = "Новый текст"
+
+	tests := []struct {
+		name    string
+		tpl     storage.Template
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			tpl:     updated,
+			wantErr: false,
+		},
+		{
+			name:    "Error Not found",
+			tpl:     storage.Template{ID: primitive.NewObjectID(), Name: "Нет"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := st.UpdateTemplate(context.Background(), tt.tpl)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.UpdateTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	got, err := st.TemplateByID(context.Background(), tpl.ID.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Body != updated.Body || !got.Updated.After(tpl.Updated) {
+		t.Errorf("Storage.UpdateTemplate() = %+v, want body %q and later update time", got, updated.Body)
+	}
+}
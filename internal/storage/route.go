@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+)
+
+// LineString - тип геометрии GeoJSON для маршрутов.
+const LineString = "LineString"
+
+// EarthRadius - радиус Земли в метрах, который MongoDB использует
+// для сферической геометрии.
+const EarthRadius = 6378100.0
+
+// MaxRouteVertices - максимальное количество точек маршрута.
+const MaxRouteVertices = 500
+
+// Route - маршрут в формате GeoJSON LineString с координатами
+// в порядке долгота, широта.
+type Route struct {
+	Type        string   `json:"type"`
+	Coordinates []LonLat `json:"coordinates"`
+}
+
+// Validate проверяет геометрию маршрута: тип, количество точек,
+// диапазоны координат и повторяющиеся соседние точки. Возвращает
+// ошибку ErrIncorrectRoute с описанием первой найденной проблемы.
+func (rt Route) Validate() error {
+	if rt.Type != LineString {
+		return fmt.Errorf("%w: unsupported geometry type %q", ErrIncorrectRoute, rt.Type)
+	}
+	if len(rt.Coordinates) < 2 {
+		return fmt.Errorf("%w: route must have at least 2 positions, got %d", ErrIncorrectRoute, len(rt.Coordinates))
+	}
+	if len(rt.Coordinates) > MaxRouteVertices {
+		return fmt.Errorf("%w: more than %d positions", ErrIncorrectRoute, MaxRouteVertices)
+	}
+	for i, pt := range rt.Coordinates {
+		if err := validPosition(i, pt); err != nil {
+			return fmt.Errorf("%w: %s", ErrIncorrectRoute, err)
+		}
+		if i > 0 && pt == rt.Coordinates[i-1] {
+			return fmt.Errorf("%w: position %d: duplicate vertex", ErrIncorrectRoute, i)
+		}
+	}
+	return nil
+}
+
+// Corridor возвращает прямоугольники шириной 2*half метров вокруг каждого
+// отрезка маршрута. Вместе с кругами радиуса half вокруг точек маршрута
+// они покрывают коридор вдоль всего маршрута. Отрезки переводятся
+// в локальную плоскую проекцию, что допустимо для городских маршрутов.
+func (rt Route) Corridor(half float64) []Area {
+	areas := make([]Area, 0, len(rt.Coordinates))
+	for i := 0; i < len(rt.Coordinates)-1; i++ {
+		a, b := rt.Coordinates[i], rt.Coordinates[i+1]
+		lat0 := (a.Lat() + b.Lat()) / 2
+
+		// Вектор отрезка и нормаль к нему длиной half в метрах.
+		bx, by := project(a, lat0, b)
+		l := math.Hypot(bx, by)
+		nx, ny := -by/l*half, bx/l*half
+
+		ring := [][2]float64{
+			unproject(a, lat0, nx, ny),
+			unproject(a, lat0, bx+nx, by+ny),
+			unproject(a, lat0, bx-nx, by-ny),
+			unproject(a, lat0, -nx, -ny),
+		}
+		ring = append(ring, ring[0])
+		areas = append(areas, Area{Type: MultiPolygon, Coordinates: [][][][2]float64{{ring}}})
+	}
+	return areas
+}
+
+// Locate находит ближайшую к точке p точку маршрута. Возвращает
+// расстояние от начала маршрута до нее вдоль маршрута и расстояние
+// от p до маршрута, оба значения в метрах.
+func (rt Route) Locate(p LonLat) (along, offset float64) {
+	offset = math.Inf(1)
+
+	var start float64
+	for i := 0; i < len(rt.Coordinates)-1; i++ {
+		a, b := rt.Coordinates[i], rt.Coordinates[i+1]
+		lat0 := (a.Lat() + b.Lat()) / 2
+		bx, by := project(a, lat0, b)
+		px, py := project(a, lat0, p)
+
+		// Проекция точки на отрезок, t - доля длины отрезка.
+		l2 := bx*bx + by*by
+		t := math.Max(0, math.Min(1, (px*bx+py*by)/l2))
+		d := math.Hypot(px-t*bx, py-t*by)
+		l := math.Sqrt(l2)
+
+		if d < offset {
+			offset = d
+			along = start + t*l
+		}
+		start += l
+	}
+	return along, offset
+}
+
+// metersPerDegree - длина одного градуса дуги большого круга в метрах.
+const metersPerDegree = EarthRadius * math.Pi / 180
+
+// project переводит точку p в метры плоской проекции с началом
+// в точке origin и масштабом долготы на широте lat0.
+func project(origin LonLat, lat0 float64, p LonLat) (x, y float64) {
+	x = (p.Lon() - origin.Lon()) * metersPerDegree * math.Cos(lat0*math.Pi/180)
+	y = (p.Lat() - origin.Lat()) * metersPerDegree
+	return x, y
+}
+
+// unproject переводит точку плоской проекции функции project обратно
+// в координаты долгота, широта.
+func unproject(origin LonLat, lat0, x, y float64) [2]float64 {
+	lon := origin.Lon() + x/(metersPerDegree*math.Cos(lat0*math.Pi/180))
+	lat := origin.Lat() + y/metersPerDegree
+	return [2]float64{lon, lat}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRoute_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		route   Route
+		wantErr bool
+	}{
+		{
+			name:  "OK",
+			route: Route{Type: LineString, Coordinates: []LonLat{{37.6, 55.75}, {37.61, 55.76}}},
+		},
+		{
+			name:    "Error Type",
+			route:   Route{Type: "Point", Coordinates: []LonLat{{37.6, 55.75}, {37.61, 55.76}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error One position",
+			route:   Route{Type: LineString, Coordinates: []LonLat{{37.6, 55.75}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error Latitude out of range",
+			route:   Route{Type: LineString, Coordinates: []LonLat{{37.6, 55.75}, {37.61, 95}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error Duplicate vertex",
+			route:   Route{Type: LineString, Coordinates: []LonLat{{37.6, 55.75}, {37.6, 55.75}}},
+			wantErr: true,
+		},
+		{
+			name:    "Error Too many positions",
+			route:   Route{Type: LineString, Coordinates: make([]LonLat, MaxRouteVertices+1)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.route.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Route.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrIncorrectRoute) {
+				t.Errorf("Route.Validate() error = %v, want ErrIncorrectRoute", err)
+			}
+		})
+	}
+}
+
+func TestRoute_Locate(t *testing.T) {
+	// Маршрут на экваторе: 0.01 градуса на восток, затем 0.01 градуса
+	// на север. Длина каждого отрезка около 1113 метров.
+	route := Route{Type: LineString, Coordinates: []LonLat{{0, 0}, {0.01, 0}, {0.01, 0.01}}}
+	seg := 0.01 * metersPerDegree
+
+	tests := []struct {
+		name       string
+		p          LonLat
+		wantAlong  float64
+		wantOffset float64
+	}{
+		{name: "Start", p: LonLat{0, 0}, wantAlong: 0, wantOffset: 0},
+		{name: "Middle of first segment", p: LonLat{0.005, 0.001}, wantAlong: seg / 2, wantOffset: 0.001 * metersPerDegree},
+		{name: "Second segment", p: LonLat{0.011, 0.005}, wantAlong: seg * 1.5, wantOffset: 0.001 * metersPerDegree},
+		{name: "Before start", p: LonLat{-0.001, 0}, wantAlong: 0, wantOffset: 0.001 * metersPerDegree},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			along, offset := route.Locate(tt.p)
+			if math.Abs(along-tt.wantAlong) > 1 || math.Abs(offset-tt.wantOffset) > 1 {
+				t.Errorf("Route.Locate() = %v, %v, want %v, %v", along, offset, tt.wantAlong, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestRoute_Corridor(t *testing.T) {
+	route := Route{Type: LineString, Coordinates: []LonLat{{37.6, 55.75}, {37.61, 55.75}, {37.61, 55.76}}}
+	const half = 50.0
+
+	areas := route.Corridor(half)
+	if len(areas) != 2 {
+		t.Fatalf("Route.Corridor() len = %v, want 2", len(areas))
+	}
+	for i, a := range areas {
+		if err := a.Validate(); err != nil {
+			t.Errorf("Route.Corridor() area %d: %v", i, err)
+		}
+		// Вершины прямоугольника удалены от своего отрезка на half метров.
+		seg := Route{Type: LineString, Coordinates: route.Coordinates[i : i+2]}
+		for _, pt := range a.Coordinates[0][0] {
+			if _, offset := seg.Locate(LonLat(pt)); math.Abs(offset-half) > 0.5 {
+				t.Errorf("Route.Corridor() area %d vertex %v offset = %v, want %v", i, pt, offset, half)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"sort"
+	"time"
+)
+
+// Интервалы группировки статистики по времени.
+const (
+	Day   = "day"
+	Week  = "week"
+	Month = "month"
+)
+
+// Разрезы подробной статистики.
+const (
+	ByCity     = "city"
+	ByDistrict = "district"
+	ByCategory = "category"
+)
+
+// StatQuery - параметры подробной статистики заявок.
+type StatQuery struct {
+	// District содержит ObjectID территории, если статистика нужна
+	// только по заявкам в ее границах.
+	District string
+	// From и To задают интервал [From, To). Созданные заявки отбираются
+	// по времени создания, закрытые - по времени закрытия. Нулевое
+	// значение границы интервала ее не ограничивает.
+	From, To time.Time
+	// Interval - интервал временного ряда: Day, Week или Month.
+	// Пустое значение отключает временной ряд.
+	Interval string
+	// By - разрез статистики: ByCity, ByDistrict или ByCategory.
+	// Пустое значение отключает разрез.
+	By string
+}
+
+// StatReport - подробная статистика заявок. Общее количество заявок
+// по статусам встроено в структуру, поэтому ответ совместим с Statistic.
+type StatReport struct {
+	Statistic
+	// Series содержит количество созданных и закрытых заявок по интервалам.
+	Series []SeriesPoint `json:"series,omitempty"`
+	// Breakdown содержит статистику в разрезе города, территории или
+	// категории, отсортированную по убыванию количества заявок.
+	Breakdown []Breakdown `json:"breakdown,omitempty"`
+	// TimeToClose содержит время закрытия заявок.
+	TimeToClose CloseTime `json:"time_to_close"`
+}
+
+// SeriesPoint - значение временного ряда статистики.
+type SeriesPoint struct {
+	// Start содержит начало интервала.
+	Start   time.Time `json:"start"`
+	Created int       `json:"created"`
+	Closed  int       `json:"closed"`
+}
+
+// Breakdown - статистика заявок с одинаковым значением разреза.
+type Breakdown struct {
+	// Key содержит город, ObjectID территории или категорию. Пустое
+	// значение объединяет заявки, у которых значение не задано.
+	Key         string    `json:"key"`
+	Statistic   Statistic `json:"statistic"`
+	TimeToClose CloseTime `json:"time_to_close"`
+}
+
+// CloseTime - среднее и медианное время от создания до закрытия заявок.
+type CloseTime struct {
+	// Count содержит количество закрытых заявок.
+	Count        int     `json:"count"`
+	AverageHours float64 `json:"average_hours"`
+	MedianHours  float64 `json:"median_hours"`
+}
+
+// Add добавляет n заявок со статусом status к статистике.
+func (s *Statistic) Add(status Status, n int) {
+	s.Total += n
+	switch status {
+	case Unverified:
+		s.Unverified += n
+	case Opened:
+		s.Opened += n
+	case InProgress:
+		s.InProgress += n
+	case Closed:
+		s.Closed += n
+	case Rejected:
+		s.Rejected += n
+	}
+}
+
+// NewCloseTime вычисляет время закрытия по длительностям закрытия
+// заявок. Сортирует слайс durations.
+func NewCloseTime(durations []time.Duration) CloseTime {
+	ct := CloseTime{Count: len(durations)}
+	if ct.Count == 0 {
+		return ct
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+	}
+	ct.AverageHours = sum.Hours() / float64(ct.Count)
+
+	mid := ct.Count / 2
+	if ct.Count%2 == 1 {
+		ct.MedianHours = durations[mid].Hours()
+	} else {
+		ct.MedianHours = (durations[mid-1] + durations[mid]).Hours() / 2
+	}
+	return ct
+}
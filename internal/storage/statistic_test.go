@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatistic_Add(t *testing.T) {
+	var s Statistic
+	s.Add(Unverified, 2)
+	s.Add(Closed, 3)
+	s.Add(Status(9), 1)
+
+	want := Statistic{Total: 6, Unverified: 2, Closed: 3}
+	if s != want {
+		t.Errorf("Statistic.Add() = %+v, want %+v", s, want)
+	}
+}
+
+func TestNewCloseTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		want      CloseTime
+	}{
+		{name: "Empty", durations: nil, want: CloseTime{}},
+		{
+			name:      "Odd",
+			durations: []time.Duration{time.Hour * 10, time.Hour, time.Hour * 4},
+			want:      CloseTime{Count: 3, AverageHours: 5, MedianHours: 4},
+		},
+		{
+			name:      "Even",
+			durations: []time.Duration{time.Hour * 8, time.Hour, time.Hour * 3, time.Hour * 4},
+			want:      CloseTime{Count: 4, AverageHours: 4, MedianHours: 3.5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCloseTime(tt.durations); got != tt.want {
+				t.Errorf("NewCloseTime() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
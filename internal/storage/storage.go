@@ -3,20 +3,36 @@
 package storage
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 var (
-	ErrIncorrectNum    = errors.New("incorrect report number")
-	ErrIncorrectID     = errors.New("incorrect report objectid")
-	ErrIncorrectStatus = errors.New("incorrect report status")
-	ErrReportNotFound  = errors.New("report not found")
-	ErrArrayNotFound   = errors.New("reports array not found")
+	ErrIncorrectNum         = errors.New("incorrect report number")
+	ErrIncorrectID          = errors.New("incorrect report objectid")
+	ErrIncorrectStatus      = errors.New("incorrect report status")
+	ErrReportNotFound       = errors.New("report not found")
+	ErrArrayNotFound        = errors.New("reports array not found")
+	ErrMediaLimit           = errors.New("media files count out of range")
+	ErrMediaNotFound        = errors.New("media file not found")
+	ErrIncorrectMedia       = errors.New("incorrect media list")
+	ErrOrgNotFound          = errors.New("organization not found")
+	ErrIncorrectUser        = errors.New("user is not a member of organization")
+	ErrJurisdictionNotFound = errors.New("jurisdiction not found")
+	ErrIncorrectArea        = errors.New("incorrect area geometry")
+	ErrIncorrectRoute       = errors.New("incorrect route geometry")
+	ErrTemplateNotFound     = errors.New("letter template not found")
 )
 
+// MaxMedia - максимальное количество медиа файлов в одной заявке.
+const MaxMedia = 5
+
 // Status - целочисленное выражение статуса заявки.
 type Status int
 
@@ -29,12 +45,182 @@ const (
 	Rejected
 )
 
-// Geo - тип данных географических координат точки.
+// StatusName возвращает название статуса заявки для уведомлений.
+func StatusName(s Status) string {
+	switch s {
+	case Unverified:
+		return "Неподтверждена"
+	case Opened:
+		return "Создана"
+	case InProgress:
+		return "В работе"
+	case Closed:
+		return "Завершена"
+	case Rejected:
+		return "Отклонена"
+	default:
+		return ""
+	}
+}
+
+// StatusChange - запись истории изменения статуса заявки.
+type StatusChange struct {
+	Status  Status    `json:"status" bson:"status"`
+	Changed time.Time `json:"changed" bson:"changed"`
+}
+
+// SLARule - допустимое время нахождения заявки в статусе. Правило с пустой
+// категорией применяется к заявкам, для категории которых нет отдельного
+// правила с тем же статусом.
+type SLARule struct {
+	Status   Status
+	Category string
+	Limit    time.Duration
+}
+
+// Geo - тип данных географических координат точки в формате GeoJSON.
 type Geo struct {
 	// Type - тип объекта, в нашем случае всегда значение "Point".
 	Type string `json:"type,omitempty" bson:"type" validate:"omitempty,max=100"`
-	// Coordinates - координаты, первый элемент - широта, второй элемент - долгота.
-	Coordinates [2]float64 `json:"coordinates" bson:"coordinates" validate:"required,dive,required"`
+	// Coordinates - координаты в порядке GeoJSON: долгота, широта.
+	Coordinates LonLat `json:"coordinates" bson:"coordinates" validate:"required,dive,required"`
+}
+
+// MediaKind - тип медиа файла заявки.
+type MediaKind string
+
+// Константы типов медиа файлов.
+const (
+	Photo MediaKind = "photo"
+	Video MediaKind = "video"
+)
+
+// Media - структура медиа файла заявки.
+type Media struct {
+	// URL - ссылка на файл в объектном хранилище.
+	URL string `json:"url" bson:"url" validate:"required,max=300"`
+	// Kind - тип файла: фото или видео.
+	Kind MediaKind `json:"kind" bson:"kind" validate:"omitempty,oneof=photo video"`
+	// Poster - ссылка на кадр-превью, заполняется только для видео.
+	Poster string `json:"poster,omitempty" bson:"poster,omitempty" validate:"omitempty,max=300"`
+}
+
+// media - псевдоним типа Media без собственных методов декодирования.
+type media Media
+
+// UnmarshalJSON декодирует медиа файл из JSON. Поддерживает старый формат,
+// в котором медиа файл был представлен строкой со ссылкой на фото.
+func (m *Media) UnmarshalJSON(data []byte) error {
+	var url string
+	if err := json.Unmarshal(data, &url); err == nil {
+		*m = Media{URL: url, Kind: Photo}
+		return nil
+	}
+	return json.Unmarshal(data, (*media)(m))
+}
+
+// UnmarshalBSONValue декодирует медиа файл из BSON. Поддерживает старый
+// формат документов, в котором медиа файл хранился строкой со ссылкой.
+func (m *Media) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	if t == bsontype.String {
+		var url string
+		if err := bson.UnmarshalValue(t, data, &url); err != nil {
+			return err
+		}
+		*m = Media{URL: url, Kind: Photo}
+		return nil
+	}
+	return bson.UnmarshalValue(t, data, (*media)(m))
+}
+
+// Name возвращает имя файла в хранилище, то есть последний элемент ссылки.
+func (m Media) Name() string {
+	return FileName(m.URL)
+}
+
+// FileName возвращает имя файла в хранилище по его ссылке.
+func FileName(url string) string {
+	str := strings.Split(url, "/")
+	return str[len(str)-1]
+}
+
+// MediaURLs возвращает все ссылки на файлы из слайса медиа, включая
+// ссылки на кадры-превью видео.
+func MediaURLs(files []Media) []string {
+	var urls []string
+	for _, m := range files {
+		urls = append(urls, m.URL)
+		if m.Poster != "" {
+			urls = append(urls, m.Poster)
+		}
+	}
+	return urls
+}
+
+// Resolution - структура закрытия заявки ремонтной бригадой.
+type Resolution struct {
+	// Comment содержит комментарий о выполненных работах.
+	Comment string `json:"comment" bson:"comment" validate:"required,max=1000"`
+	// Media содержит фото или видео после ремонта.
+	Media []Media `json:"media" bson:"media" validate:"required,min=1,max=5,dive"`
+	// Resolved содержит время закрытия заявки.
+	Resolved time.Time `json:"resolved" bson:"resolved"`
+	// By содержит идентификатор пользователя, закрывшего заявку.
+	By string `json:"by,omitempty" bson:"by,omitempty" validate:"omitempty,max=100"`
+}
+
+// Member - структура сотрудника организации.
+type Member struct {
+	// User содержит идентификатор пользователя из JWT токена.
+	User  string `json:"user" bson:"user" validate:"required,max=100"`
+	Name  string `json:"name" bson:"name" validate:"required,max=100"`
+	Email string `json:"email,omitempty" bson:"email,omitempty" validate:"omitempty,email,max=100"`
+}
+
+// Organization - структура организации, ответственной за устранение
+// проблем: коммунальной службы, администрации района и т.п.
+type Organization struct {
+	ID    primitive.ObjectID `json:"id" bson:"_id"`
+	Name  string             `json:"name" bson:"name" validate:"required,max=200"`
+	Email string             `json:"email,omitempty" bson:"email,omitempty" validate:"omitempty,email,max=100"`
+	// Members содержит сотрудников, которым можно назначать заявки.
+	Members []Member `json:"members" bson:"members" validate:"max=500,dive"`
+}
+
+// Member возвращает сотрудника организации по идентификатору
+// пользователя user.
+func (o Organization) Member(user string) (Member, bool) {
+	for _, m := range o.Members {
+		if m.User == user {
+			return m, true
+		}
+	}
+	return Member{}, false
+}
+
+// Jurisdiction - структура территории, за которую отвечает организация:
+// района, округа или зоны обслуживания коммунальной службы.
+type Jurisdiction struct {
+	ID   primitive.ObjectID `json:"id" bson:"_id"`
+	Name string             `json:"name" bson:"name" validate:"required,max=200"`
+	// Org содержит ObjectID организации, которой назначаются новые
+	// заявки на этой территории.
+	Org  *primitive.ObjectID `json:"org,omitempty" bson:"org,omitempty"`
+	Area Area                `json:"area" bson:"area"`
+}
+
+// Template - шаблон официального письма по заявке. Заголовок и текст
+// письма задаются в синтаксисе пакета text/template.
+type Template struct {
+	ID   primitive.ObjectID `json:"id" bson:"_id"`
+	Name string             `json:"name" bson:"name" validate:"required,max=200"`
+	// Title содержит шаблон заголовка письма.
+	Title string `json:"title" bson:"title" validate:"required,max=500"`
+	// Body содержит шаблон текста письма. Абзацы разделяются переводом
+	// строки.
+	Body string `json:"body" bson:"body" validate:"required,max=10000"`
+	// Updated содержит время последнего изменения шаблона.
+	Updated time.Time `json:"updated" bson:"updated"`
 }
 
 // Contacts - структура контактов отправителя заявки.
@@ -68,11 +254,14 @@ type Report struct {
 	// Description содержит описание заявки клиентом в свободной форме.
 	Description string `json:"description" bson:"description" validate:"omitempty,max=300"`
 
+	// Category содержит категорию проблемы, от нее зависят сроки обработки.
+	Category string `json:"category,omitempty" bson:"category,omitempty" validate:"omitempty,max=50"`
+
 	// Contacts содержит возможные контакты клиента.
 	Contacts Contacts `json:"contacts,omitempty" bson:"contacts,omitempty"`
 
-	// Media содержит слайс ссылок на медиа файлы по заявке.
-	Media []string `json:"media" bson:"media" validate:"required,min=1,max=5"`
+	// Media содержит слайс медиа файлов по заявке.
+	Media []Media `json:"media" bson:"media" validate:"required,min=1,max=5,dive"`
 
 	// Тип Coordinates хранит географические координаты заявки.
 	Geo Geo `json:"geo" bson:"geo" validate:"required"`
@@ -80,6 +269,52 @@ type Report struct {
 	// Status содержит целочисленную константу, отражающую текущий
 	// статус заявки.
 	Status Status `json:"status" bson:"status" validate:"required,number,min=1,max=5"`
+
+	// StatusSince содержит время установки текущего статуса.
+	StatusSince time.Time `json:"status_since" bson:"status_since"`
+
+	// History содержит историю изменения статусов заявки.
+	History []StatusChange `json:"history,omitempty" bson:"history,omitempty"`
+
+	// Resolution содержит комментарий и медиа файлы после ремонта,
+	// заполняется при закрытии заявки.
+	Resolution *Resolution `json:"resolution,omitempty" bson:"resolution,omitempty" validate:"omitempty"`
+
+	// Jurisdiction содержит ObjectID территории, в границах которой
+	// находится заявка. Определяется автоматически при создании заявки.
+	Jurisdiction *primitive.ObjectID `json:"jurisdiction,omitempty" bson:"jurisdiction,omitempty"`
+
+	// AssigneeOrg содержит ObjectID организации, ответственной за заявку.
+	AssigneeOrg *primitive.ObjectID `json:"assignee_org,omitempty" bson:"assignee_org,omitempty"`
+
+	// AssigneeUser содержит идентификатор сотрудника организации,
+	// назначенного исполнителем заявки.
+	AssigneeUser string `json:"assignee_user,omitempty" bson:"assignee_user,omitempty"`
+
+	// Deleted содержит время перемещения заявки в корзину. Заявки
+	// в корзине не возвращаются обычными запросами.
+	Deleted *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+
+	// DeletedBy содержит идентификатор пользователя, удалившего заявку.
+	DeletedBy string `json:"deleted_by,omitempty" bson:"deleted_by,omitempty"`
+}
+
+// Files возвращает ссылки на все файлы заявки, включая медиа файлы
+// закрытия заявки.
+func (r Report) Files() []string {
+	urls := MediaURLs(r.Media)
+	if r.Resolution != nil {
+		urls = append(urls, MediaURLs(r.Resolution.Media)...)
+	}
+	return urls
+}
+
+// NearReport - заявка с расстоянием до точки поиска.
+type NearReport struct {
+	Report `bson:",inline"`
+
+	// Distance содержит расстояние от точки поиска до заявки в метрах.
+	Distance float64 `json:"distance" bson:"distance"`
 }
 
 // Filter - структура фильтра для получения заявок.
@@ -91,6 +326,16 @@ type Filter struct {
 	Sort int
 	// Слайс статусов.
 	Status []Status
+	// District содержит ObjectID территории, в границах которой
+	// находятся заявки.
+	District string
+	// From и To ограничивают время создания заявок интервалом [From, To).
+	// Нулевое значение не ограничивает интервал с соответствующей стороны.
+	From, To time.Time
+	// City содержит название города заявок.
+	City string
+	// Area содержит многоугольник, в границах которого находятся заявки.
+	Area *Area
 }
 
 // Statistic - структура статистики заявок со статусами.
@@ -0,0 +1,110 @@
+package tiles
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Cache - хранилище готовых тайлов в памяти. Тайлы удаляются из кэша
+// по истечении времени жизни и все сразу при изменении заявок.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	gen     uint64
+	entries map[Tile]entry
+}
+
+// entry - тайл в кэше.
+type entry struct {
+	data    []byte
+	expires time.Time
+}
+
+// NewCache - конструктор кэша не более чем на size тайлов со временем
+// жизни ttl. Если size не больше 0, то тайлы не кэшируются.
+func NewCache(size int, ttl time.Duration) *Cache {
+	return &Cache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[Tile]entry),
+	}
+}
+
+// Get возвращает тайл из кэша. Вместе с тайлом возвращает текущее
+// поколение кэша, которое нужно передать в Put при сохранении тайла,
+// сформированного после промаха.
+func (c *Cache) Get(t Tile) ([]byte, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[t]
+	if ok && time.Now().After(e.expires) {
+		delete(c.entries, t)
+		ok = false
+	}
+	return e.data, c.gen, ok
+}
+
+// Put сохраняет тайл в кэш. Если после получения поколения gen кэш
+// был сброшен, то тайл мог устареть и не сохраняется. При заполненном
+// кэше сначала удаляются устаревшие тайлы, затем произвольный тайл.
+func (c *Cache) Put(t Tile, gen uint64, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size <= 0 || gen != c.gen {
+		return
+	}
+	if _, ok := c.entries[t]; !ok && len(c.entries) >= c.size {
+		c.evict()
+	}
+	c.entries[t] = entry{data: data, expires: time.Now().Add(c.ttl)}
+}
+
+// evict освобождает место в кэше. Вызывается с захваченным мьютексом.
+func (c *Cache) evict() {
+	now := time.Now()
+	for t, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, t)
+		}
+	}
+	for t := range c.entries {
+		if len(c.entries) < c.size {
+			return
+		}
+		delete(c.entries, t)
+	}
+}
+
+// Invalidate удаляет все тайлы из кэша.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	clear(c.entries)
+}
+
+// Invalidator - middleware, которое сбрасывает кэш после успешного
+// выполнения запроса, изменяющего данные. Запросы GET, HEAD и OPTIONS
+// кэш не сбрасывают.
+func (c *Cache) Invalidator(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+		if ww.Status() < http.StatusBadRequest {
+			c.Invalidate()
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package tiles
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	c := NewCache(2, time.Minute)
+	a, b, d := Tile{1, 0, 0}, Tile{1, 1, 0}, Tile{1, 1, 1}
+
+	_, gen, ok := c.Get(a)
+	if ok {
+		t.Fatal("Cache.Get() found tile in empty cache")
+	}
+	c.Put(a, gen, []byte("a"))
+	if data, _, ok := c.Get(a); !ok || string(data) != "a" {
+		t.Errorf("Cache.Get() = %q, %v, want a, true", data, ok)
+	}
+
+	// Кэш не превышает заданный размер.
+	c.Put(b, gen, []byte("b"))
+	c.Put(d, gen, []byte("d"))
+	if len(c.entries) != 2 {
+		t.Errorf("Cache entries = %d, want 2", len(c.entries))
+	}
+
+	// После сброса кэша тайлы, сформированные до него, не сохраняются.
+	c.Invalidate()
+	if _, _, ok := c.Get(d); ok {
+		t.Error("Cache.Get() found tile after Invalidate")
+	}
+	c.Put(d, gen, []byte("d"))
+	if _, _, ok := c.Get(d); ok {
+		t.Error("Cache.Put() saved tile of previous generation")
+	}
+}
+
+func TestCache_Expired(t *testing.T) {
+	c := NewCache(10, -time.Second)
+	_, gen, _ := c.Get(Tile{0, 0, 0})
+	c.Put(Tile{0, 0, 0}, gen, []byte("a"))
+	if _, _, ok := c.Get(Tile{0, 0, 0}); ok {
+		t.Error("Cache.Get() returned expired tile")
+	}
+}
+
+func TestCache_Invalidator(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		status int
+		want   bool
+	}{
+		{name: "GET", method: http.MethodGet, status: http.StatusOK, want: false},
+		{name: "POST OK", method: http.MethodPost, status: http.StatusCreated, want: true},
+		{name: "PUT Error", method: http.MethodPut, status: http.StatusBadRequest, want: false},
+		{name: "DELETE No content", method: http.MethodDelete, status: http.StatusNoContent, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache(10, time.Minute)
+			_, gen, _ := c.Get(Tile{0, 0, 0})
+			c.Put(Tile{0, 0, 0}, gen, []byte("a"))
+
+			h := c.Invalidator(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, "/api/reports", nil))
+
+			if _, _, ok := c.Get(Tile{0, 0, 0}); ok == tt.want {
+				t.Errorf("Invalidator() invalidated = %v, want %v", !ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Пакет tiles формирует векторные тайлы заявок в формате Mapbox Vector
+// Tile и хранит готовые тайлы в памяти.
+package tiles
+
+// Extent - размер тайла во внутренних координатах MVT.
+const Extent = 4096
+
+// Номера полей и типы protobuf сообщений спецификации MVT 2.1.
+const (
+	tileLayers = 3
+
+	layerName     = 1
+	layerFeatures = 2
+	layerKeys     = 3
+	layerValues   = 4
+	layerExtent   = 5
+	layerVersion  = 15
+
+	featureID       = 1
+	featureTags     = 2
+	featureType     = 3
+	featureGeometry = 4
+
+	valueString = 1
+	valueSint   = 6
+
+	wireVarint = 0
+	wireBytes  = 2
+
+	geomPoint = 1
+	cmdMoveTo = 1
+)
+
+// Property - свойство объекта тайла. Значение может быть строкой или
+// целым числом.
+type Property struct {
+	Key   string
+	Value any
+}
+
+// Layer - слой векторного тайла. Ключи и значения свойств хранятся
+// в общих таблицах слоя, объекты ссылаются на них по индексам.
+type Layer struct {
+	name     string
+	keys     []string
+	keyIdx   map[string]int
+	values   [][]byte
+	valueIdx map[string]int
+	features [][]byte
+}
+
+// NewLayer - конструктор слоя с именем name.
+func NewLayer(name string) *Layer {
+	return &Layer{
+		name:     name,
+		keyIdx:   make(map[string]int),
+		valueIdx: make(map[string]int),
+	}
+}
+
+// AddPoint добавляет в слой точечный объект с идентификатором id,
+// координатами x, y внутри тайла и свойствами props. Свойства со
+// значениями неподдерживаемых типов пропускаются.
+func (l *Layer) AddPoint(id uint64, x, y int, props ...Property) {
+	var tags []byte
+	for _, p := range props {
+		v, ok := encodeValue(p.Value)
+		if !ok {
+			continue
+		}
+		tags = appendVarint(tags, uint64(l.key(p.Key)))
+		tags = appendVarint(tags, uint64(l.value(v)))
+	}
+
+	var geom []byte
+	geom = appendVarint(geom, cmdMoveTo|1<<3)
+	geom = appendVarint(geom, zigzag(int64(x)))
+	geom = appendVarint(geom, zigzag(int64(y)))
+
+	var f []byte
+	f = appendVarintField(f, featureID, id)
+	f = appendBytesField(f, featureTags, tags)
+	f = appendVarintField(f, featureType, geomPoint)
+	f = appendBytesField(f, featureGeometry, geom)
+	l.features = append(l.features, f)
+}
+
+// Len возвращает количество объектов слоя.
+func (l *Layer) Len() int {
+	return len(l.features)
+}
+
+// key возвращает индекс ключа в таблице ключей слоя.
+func (l *Layer) key(k string) int {
+	if i, ok := l.keyIdx[k]; ok {
+		return i
+	}
+	l.keys = append(l.keys, k)
+	l.keyIdx[k] = len(l.keys) - 1
+	return len(l.keys) - 1
+}
+
+// value возвращает индекс закодированного значения в таблице значений
+// слоя.
+func (l *Layer) value(v []byte) int {
+	if i, ok := l.valueIdx[string(v)]; ok {
+		return i
+	}
+	l.values = append(l.values, v)
+	l.valueIdx[string(v)] = len(l.values) - 1
+	return len(l.values) - 1
+}
+
+// marshal кодирует слой в сообщение Layer.
+func (l *Layer) marshal() []byte {
+	var b []byte
+	b = appendVarintField(b, layerVersion, 2)
+	b = appendBytesField(b, layerName, []byte(l.name))
+	for _, f := range l.features {
+		b = appendBytesField(b, layerFeatures, f)
+	}
+	for _, k := range l.keys {
+		b = appendBytesField(b, layerKeys, []byte(k))
+	}
+	for _, v := range l.values {
+		b = appendBytesField(b, layerValues, v)
+	}
+	return appendVarintField(b, layerExtent, Extent)
+}
+
+// Encode кодирует слои в векторный тайл. Пустые слои не включаются
+// в тайл, тайл без объектов имеет нулевую длину.
+func Encode(layers ...*Layer) []byte {
+	var b []byte
+	for _, l := range layers {
+		if l.Len() == 0 {
+			continue
+		}
+		b = appendBytesField(b, tileLayers, l.marshal())
+	}
+	return b
+}
+
+// encodeValue кодирует значение свойства в сообщение Value.
+func encodeValue(v any) ([]byte, bool) {
+	switch v := v.(type) {
+	case string:
+		return appendBytesField(nil, valueString, []byte(v)), true
+	case int:
+		return appendVarintField(nil, valueSint, zigzag(int64(v))), true
+	case int64:
+		return appendVarintField(nil, valueSint, zigzag(v)), true
+	default:
+		return nil, false
+	}
+}
+
+// zigzag кодирует целое число со знаком для типа sint.
+func zigzag(n int64) uint64 {
+	return uint64(n<<1) ^ uint64(n>>63)
+}
+
+// appendVarint добавляет число в формате varint.
+func appendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+// appendVarintField добавляет поле field с числовым значением.
+func appendVarintField(b []byte, field int, v uint64) []byte {
+	b = appendVarint(b, uint64(field)<<3|wireVarint)
+	return appendVarint(b, v)
+}
+
+// appendBytesField добавляет поле field с данными переменной длины.
+func appendBytesField(b []byte, field int, data []byte) []byte {
+	b = appendVarint(b, uint64(field)<<3|wireBytes)
+	b = appendVarint(b, uint64(len(data)))
+	return append(b, data...)
+}
@@ -0,0 +1,110 @@
+package tiles
+
+import (
+	"reflect"
+	"testing"
+)
+
+// field - поле protobuf сообщения для проверки результатов кодирования.
+type field struct {
+	num   int
+	value uint64
+	data  []byte
+}
+
+// readVarint считывает число в формате varint.
+func readVarint(t *testing.T, b []byte) (uint64, int) {
+	var v uint64
+	for i, c := range b {
+		v |= uint64(c&0x7F) << (7 * i)
+		if c < 0x80 {
+			return v, i + 1
+		}
+	}
+	t.Fatal("truncated varint")
+	return 0, 0
+}
+
+// fields разбирает protobuf сообщение на поля.
+func fields(t *testing.T, b []byte) []field {
+	var res []field
+	for len(b) > 0 {
+		key, n := readVarint(t, b)
+		b = b[n:]
+		f := field{num: int(key >> 3)}
+		switch key & 7 {
+		case wireVarint:
+			f.value, n = readVarint(t, b)
+			b = b[n:]
+		case wireBytes:
+			l, n := readVarint(t, b)
+			b = b[n:]
+			f.data = b[:l]
+			b = b[l:]
+		default:
+			t.Fatalf("unexpected wire type %d", key&7)
+		}
+		res = append(res, f)
+	}
+	return res
+}
+
+func Test_zigzag(t *testing.T) {
+	tests := map[int64]uint64{0: 0, -1: 1, 1: 2, -2: 3, 2048: 4096}
+	for n, want := range tests {
+		if got := zigzag(n); got != want {
+			t.Errorf("zigzag(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	if got := Encode(NewLayer("empty")); len(got) != 0 {
+		t.Errorf("Encode() empty layer len = %d, want 0", len(got))
+	}
+
+	layer := NewLayer("reports")
+	layer.AddPoint(7, 25, 17, Property{Key: "status", Value: 2}, Property{Key: "category", Value: "hatch"})
+	layer.AddPoint(8, 1, 1, Property{Key: "status", Value: 2}, Property{Key: "skip", Value: 1.5})
+
+	tile := fields(t, Encode(layer))
+	if len(tile) != 1 || tile[0].num != tileLayers {
+		t.Fatalf("Encode() tile fields = %v, want one layer", tile)
+	}
+
+	var name string
+	var keys []string
+	var features, values [][]byte
+	for _, f := range fields(t, tile[0].data) {
+		switch f.num {
+		case layerName:
+			name = string(f.data)
+		case layerKeys:
+			keys = append(keys, string(f.data))
+		case layerValues:
+			values = append(values, f.data)
+		case layerFeatures:
+			features = append(features, f.data)
+		}
+	}
+	if name != "reports" || len(features) != 2 {
+		t.Fatalf("Encode() layer name = %q, features = %d, want reports and 2", name, len(features))
+	}
+	if !reflect.DeepEqual(keys, []string{"status", "category"}) {
+		t.Errorf("Encode() keys = %v, want [status category]", keys)
+	}
+	// Одинаковые значения свойств хранятся в таблице слоя один раз.
+	if len(values) != 2 {
+		t.Errorf("Encode() values = %d, want 2", len(values))
+	}
+
+	want := []field{
+		{num: featureID, value: 7},
+		{num: featureTags, data: []byte{0, 0, 1, 1}},
+		{num: featureType, value: geomPoint},
+		{num: featureGeometry, data: []byte{9, 50, 34}},
+	}
+	if got := fields(t, features[0]); !reflect.DeepEqual(got, want) {
+		t.Errorf("Encode() feature = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+package tiles
+
+import (
+	"Report-Storage/internal/storage"
+	"fmt"
+	"math"
+)
+
+// Buffer - ширина полосы вокруг тайла во внутренних координатах MVT.
+// Точки из этой полосы попадают в тайл, чтобы значки на границе соседних
+// тайлов не обрезались.
+const Buffer = 64
+
+// LayerName - имя слоя заявок в тайле.
+const LayerName = "reports"
+
+// Tile - адрес тайла в схеме XYZ.
+type Tile struct {
+	Z, X, Y int
+}
+
+// Validate проверяет, что уровень масштаба не больше storage.MaxZoom,
+// а номера тайла находятся в сетке этого уровня.
+func (t Tile) Validate() error {
+	if t.Z < 0 || t.Z > storage.MaxZoom {
+		return fmt.Errorf("zoom %d out of range [0, %d]", t.Z, storage.MaxZoom)
+	}
+	n := 1 << t.Z
+	if t.X < 0 || t.X >= n || t.Y < 0 || t.Y >= n {
+		return fmt.Errorf("tile %d/%d out of range for zoom %d", t.X, t.Y, t.Z)
+	}
+	return nil
+}
+
+// Bounds возвращает границы тайла вместе с полосой Buffer.
+func (t Tile) Bounds() storage.BBox {
+	n := float64(int(1) << t.Z)
+	buf := float64(Buffer) / Extent
+
+	west := (float64(t.X)-buf)/n*360 - 180
+	east := (float64(t.X)+1+buf)/n*360 - 180
+	north := mercatorLat((float64(t.Y) - buf) / n)
+	south := mercatorLat((float64(t.Y) + 1 + buf) / n)
+
+	return storage.BBox{
+		Min: storage.LonLat{clamp(west, -180, 180), clamp(south, -90, 90)},
+		Max: storage.LonLat{clamp(east, -180, 180), clamp(north, -90, 90)},
+	}
+}
+
+// Point переводит координаты точки во внутренние координаты тайла.
+func (t Tile) Point(p storage.LonLat) (x, y int) {
+	n := float64(int(1) << t.Z)
+	lat := clamp(p.Lat(), -storage.MaxMercatorLat, storage.MaxMercatorLat) * math.Pi / 180
+
+	fx := (p.Lon() + 180) / 360 * n
+	fy := (1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2 * n
+	x = int(math.Round((fx - float64(t.X)) * Extent))
+	y = int(math.Round((fy - float64(t.Y)) * Extent))
+	return x, y
+}
+
+// mercatorLat возвращает широту по доле высоты карты Web Mercator,
+// отсчитанной от северного края.
+func mercatorLat(v float64) float64 {
+	return math.Atan(math.Sinh(math.Pi*(1-2*v))) * 180 / math.Pi
+}
+
+// Reports формирует векторный тайл из заявок. Каждая заявка - точка
+// с идентификатором, равным номеру заявки, и свойствами number, status
+// и category.
+func Reports(t Tile, reports []storage.Report) []byte {
+	layer := NewLayer(LayerName)
+	for _, rep := range reports {
+		x, y := t.Point(rep.Geo.Coordinates)
+		props := []Property{
+			{Key: "number", Value: rep.Number},
+			{Key: "status", Value: int(rep.Status)},
+		}
+		if rep.Category != "" {
+			props = append(props, Property{Key: "category", Value: rep.Category})
+		}
+		layer.AddPoint(uint64(rep.Number), x, y, props...)
+	}
+	return Encode(layer)
+}
+
+// clamp ограничивает значение v диапазоном [min, max].
+func clamp(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
@@ -0,0 +1,91 @@
+package tiles
+
+import (
+	"Report-Storage/internal/storage"
+	"math"
+	"testing"
+)
+
+func TestTile_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tile    Tile
+		wantErr bool
+	}{
+		{name: "OK Zero", tile: Tile{0, 0, 0}},
+		{name: "OK Max index", tile: Tile{3, 7, 7}},
+		{name: "Error Zoom", tile: Tile{storage.MaxZoom + 1, 0, 0}, wantErr: true},
+		{name: "Error X out of grid", tile: Tile{3, 8, 0}, wantErr: true},
+		{name: "Error Negative Y", tile: Tile{3, 0, -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tile.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Tile.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTile_Bounds(t *testing.T) {
+	box := Tile{0, 0, 0}.Bounds()
+	if box.Min.Lon() != -180 || box.Max.Lon() != 180 || box.Min.Lat() > -storage.MaxMercatorLat || box.Max.Lat() < storage.MaxMercatorLat {
+		t.Errorf("Tile.Bounds() = %v, want whole world", box)
+	}
+	if err := box.Validate(); err != nil {
+		t.Errorf("Tile.Bounds() invalid: %v", err)
+	}
+
+	// Тайл 1/1/0 - северо-восточная четверть карты, с полосой Buffer
+	// он немного заходит за нулевой меридиан и экватор.
+	box = Tile{1, 1, 0}.Bounds()
+	if box.Min.Lon() >= 0 || box.Min.Lat() >= 0 || box.Max.Lon() != 180 || box.Max.Lat() < storage.MaxMercatorLat {
+		t.Errorf("Tile.Bounds() = %v, want north-east quarter with buffer", box)
+	}
+}
+
+func TestTile_Point(t *testing.T) {
+	tests := []struct {
+		name  string
+		tile  Tile
+		p     storage.LonLat
+		wantX int
+		wantY int
+	}{
+		{name: "Center of world", tile: Tile{0, 0, 0}, p: storage.LonLat{0, 0}, wantX: Extent / 2, wantY: Extent / 2},
+		{name: "North-west corner", tile: Tile{1, 0, 0}, p: storage.LonLat{-180, storage.MaxMercatorLat}, wantX: 0, wantY: 0},
+		{name: "Outside tile", tile: Tile{1, 1, 1}, p: storage.LonLat{-90, 0}, wantX: -Extent / 2, wantY: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := tt.tile.Point(tt.p)
+			if math.Abs(float64(x-tt.wantX)) > 1 || math.Abs(float64(y-tt.wantY)) > 1 {
+				t.Errorf("Tile.Point() = %d, %d, want %d, %d", x, y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+func TestReports(t *testing.T) {
+	if got := Reports(Tile{0, 0, 0}, nil); len(got) != 0 {
+		t.Errorf("Reports() without reports len = %d, want 0", len(got))
+	}
+
+	reports := []storage.Report{
+		{Number: 1, Status: storage.Opened, Category: "hatch", Geo: storage.Geo{Coordinates: storage.LonLat{37.62, 55.75}}},
+		{Number: 2, Status: storage.Closed, Geo: storage.Geo{Coordinates: storage.LonLat{30.31, 59.93}}},
+	}
+	tile := fields(t, Reports(Tile{0, 0, 0}, reports))
+	if len(tile) != 1 {
+		t.Fatalf("Reports() layers = %d, want 1", len(tile))
+	}
+	var n int
+	for _, f := range fields(t, tile[0].data) {
+		if f.num == layerFeatures {
+			n++
+		}
+	}
+	if n != 2 {
+		t.Errorf("Reports() features = %d, want 2", n)
+	}
+}
@@ -0,0 +1,232 @@
+// Пакет xlsx записывает таблицы в формате Office Open XML (.xlsx) потоком,
+// строка за строкой, без хранения всей таблицы в памяти.
+package xlsx
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxSheetName - максимальная длина названия листа в Excel.
+const MaxSheetName = 31
+
+// ErrClosed - ошибка записи в закрытую таблицу.
+var ErrClosed = errors.New("xlsx: writer is closed")
+
+// Служебные части пакета, не зависящие от содержимого таблицы.
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`
+
+	rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+
+	workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	// styles содержит единственный дополнительный стиль ячейки с форматом
+	// даты и времени, его индекс - dateStyle.
+	styles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+		`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+		`</styleSheet>`
+
+	sheetHead = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetTail = `</sheetData></worksheet>`
+
+	dateStyle = 1
+)
+
+// epoch - нулевая дата в системе дат Excel 1900. Даты после 1 марта 1900
+// года отсчитываются от нее с учетом несуществующего 29 февраля 1900.
+var epoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Writer записывает таблицу из одного листа. Служебные части пакета
+// записываются при создании, строки листа - по мере вызова Write. Файл
+// корректен только после вызова Close.
+type Writer struct {
+	zw    *zip.Writer
+	sheet io.Writer
+	row   int
+	buf   []byte
+}
+
+// NewWriter создает таблицу с листом name и записывает ее в w. Название
+// листа должно быть непустым, не длиннее MaxSheetName символов и не
+// содержать символов []:*?/\.
+func NewWriter(w io.Writer, name string) (*Writer, error) {
+	if name == "" || utf8.RuneCountInString(name) > MaxSheetName || strings.ContainsAny(name, `[]:*?/\`) {
+		return nil, fmt.Errorf("xlsx: incorrect sheet name %q", name)
+	}
+
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, data string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, escape(name))},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+		{"xl/styles.xml", styles},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, p.data); err != nil {
+			return nil, err
+		}
+	}
+
+	// Лист создается последним, чтобы строки записывались прямо в него.
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, sheetHead); err != nil {
+		return nil, err
+	}
+	return &Writer{zw: zw, sheet: sheet}, nil
+}
+
+// Write добавляет строку в лист. Значения типов int, int64 и float64
+// записываются числами, time.Time - датой в UTC, nil - пустой ячейкой,
+// значения остальных типов - строками.
+func (w *Writer) Write(row []any) error {
+	if w.sheet == nil {
+		return ErrClosed
+	}
+	w.row++
+	b := w.buf[:0]
+	b = append(b, `<row r="`...)
+	b = strconv.AppendInt(b, int64(w.row), 10)
+	b = append(b, `">`...)
+	for i, v := range row {
+		if v == nil {
+			continue
+		}
+		b = append(b, `<c r="`...)
+		b = append(b, Column(i)...)
+		b = strconv.AppendInt(b, int64(w.row), 10)
+		b = append(b, '"')
+		switch v := v.(type) {
+		case int:
+			b = number(b, float64(v))
+		case int64:
+			b = number(b, float64(v))
+		case float64:
+			b = number(b, v)
+		case time.Time:
+			b = append(b, ` s="`...)
+			b = strconv.AppendInt(b, dateStyle, 10)
+			b = append(b, '"')
+			b = number(b, Serial(v))
+		case string:
+			b = inline(b, v)
+		default:
+			b = inline(b, fmt.Sprint(v))
+		}
+	}
+	b = append(b, `</row>`...)
+	w.buf = b
+	_, err := w.sheet.Write(b)
+	return err
+}
+
+// Close завершает лист и записывает оглавление архива. Не закрывает
+// исходный io.Writer.
+func (w *Writer) Close() error {
+	if w.sheet == nil {
+		return ErrClosed
+	}
+	_, err := io.WriteString(w.sheet, sheetTail)
+	w.sheet = nil
+	if err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+// Column возвращает буквенное обозначение столбца по его индексу
+// начиная с 0: A, B, ..., Z, AA, AB и т.д.
+func Column(i int) string {
+	var b []byte
+	for i++; i > 0; i = (i - 1) / 26 {
+		b = append([]byte{byte('A' + (i-1)%26)}, b...)
+	}
+	return string(b)
+}
+
+// Serial возвращает время t в UTC как порядковый номер дня в системе дат
+// Excel, дробная часть которого - время суток.
+func Serial(t time.Time) float64 {
+	t = t.UTC()
+	return float64(t.Sub(epoch)) / float64(24*time.Hour)
+}
+
+// number дописывает в b окончание ячейки с числом v.
+func number(b []byte, v float64) []byte {
+	b = append(b, `><v>`...)
+	b = strconv.AppendFloat(b, v, 'g', -1, 64)
+	return append(b, `</v></c>`...)
+}
+
+// inline дописывает в b окончание ячейки со строкой s. Строки хранятся
+// в самой ячейке, чтобы не накапливать общую таблицу строк в памяти.
+func inline(b []byte, s string) []byte {
+	b = append(b, ` t="inlineStr"><is><t xml:space="preserve">`...)
+	b = append(b, escape(s)...)
+	return append(b, `</t></is></c>`...)
+}
+
+// escape экранирует специальные символы XML и заменяет символы,
+// недопустимые в XML 1.0, на U+FFFD.
+func escape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '<':
+			sb.WriteString("&lt;")
+		case r == '>':
+			sb.WriteString("&gt;")
+		case r == '&':
+			sb.WriteString("&amp;")
+		case r == '"':
+			sb.WriteString("&quot;")
+		case r == '\t' || r == '\n' || r == '\r':
+			sb.WriteRune(r)
+		case r < 0x20 || r == 0xFFFE || r == 0xFFFF || r == utf8.RuneError:
+			sb.WriteRune(utf8.RuneError)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Заявки")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	rows := [][]any{
+		{"Номер", "Описание"},
+		{int64(7), "Люк <открыт> & \x01опасен", nil, 55.75, created},
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(rows[0]); err != ErrClosed {
+		t.Errorf("Writer.Write() after Close error = %v, want %v", err, ErrClosed)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Каждая часть пакета должна быть корректным XML.
+		dec := xml.NewDecoder(bytes.NewReader(data))
+		for {
+			_, err := dec.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("part %s is not valid XML: %v", f.Name, err)
+			}
+		}
+		files[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("part %s not found", name)
+		}
+	}
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">Номер</t></is></c>`,
+		`<c r="A2"><v>7</v></c>`,
+		`Люк &lt;открыт&gt; &amp; �опасен`,
+		`<c r="D2"><v>55.75</v></c>`,
+		`<c r="E2" s="1"><v>45352.5</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet does not contain %s", want)
+		}
+	}
+	if strings.Contains(sheet, `r="C2"`) {
+		t.Errorf("sheet contains cell for nil value")
+	}
+}
+
+func TestNewWriter_Name(t *testing.T) {
+	for _, name := range []string{"", "a/b", strings.Repeat("я", MaxSheetName+1)} {
+		if _, err := NewWriter(io.Discard, name); err == nil {
+			t.Errorf("NewWriter(%q) error = nil", name)
+		}
+	}
+}
+
+func TestColumn(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for i, want := range tests {
+		if got := Column(i); got != want {
+			t.Errorf("Column(%d) = %s, want %s", i, got, want)
+		}
+	}
+}